@@ -0,0 +1,166 @@
+package alieninvasion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg"
+	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/batch"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagRuns      = "runs"
+	flagShortRuns = "n"
+
+	flagParallel      = "parallel"
+	flagShortParallel = "p"
+
+	flagFormat      = "format"
+	flagShortFormat = "f"
+
+	flagOutput      = "out"
+	flagShortOutput = "o"
+)
+
+// NewBatchCmd creates the /batch command.
+func NewBatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Runs a number of headless simulations with different seeds and prints aggregated statistics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Inputs build
+			if err := loadConfig(cmd); err != nil {
+				return err
+			}
+
+			cityMap, err := buildCityMap(cmd)
+			if err != nil {
+				return err
+			}
+
+			simCfg, err := buildSimConfig()
+			if err != nil {
+				return err
+			}
+
+			seed, err := buildSeed(cmd)
+			if err != nil {
+				return err
+			}
+
+			aliensCount, err := pkg.GetUintFlag(cmd, flagAliens, false)
+			if err != nil {
+				return err
+			}
+
+			runs, err := pkg.GetUintFlag(cmd, flagRuns, false)
+			if err != nil {
+				return err
+			}
+
+			parallel, err := pkg.GetUintFlag(cmd, flagParallel, false)
+			if err != nil {
+				return err
+			}
+
+			format, err := pkg.GetStringFlag(cmd, flagFormat, false)
+			if err != nil {
+				return err
+			}
+			if err := batch.ValidateFormat(*format); err != nil {
+				return pkg.BuildParamErr(flagFormat, pkg.ParamTypeFlag, err)
+			}
+
+			output, closeOutput, err := buildOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer closeOutput()
+
+			// Logs are written to stderr not to mix with the report
+			logger, err := buildLogger(logging.WithOutput(os.Stderr))
+			if err != nil {
+				return err
+			}
+			ctx := logging.SetCtxLogger(context.Background(), logger)
+
+			// Runner
+			runsDone := int32(0)
+			runner, err := batch.New(
+				batch.WithConfig(simCfg),
+				batch.WithCityMap(cityMap),
+				batch.WithAliensCount(*aliensCount),
+				batch.WithRuns(*runs, *parallel),
+				batch.WithBaseSeed(seed),
+				batch.WithProgress(func(idx int, result model.SimResult) {
+					logger.Info().Msgf("Run %d / %d done (seed: %d): %s", atomic.AddInt32(&runsDone, 1), *runs, result.Seed, result.Status.StopReason)
+				}),
+			)
+			if err != nil {
+				return fmt.Errorf("building batch runner: %w", err)
+			}
+
+			// Run
+			ctx, ctxCancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+			defer ctxCancel()
+
+			logger.Info().Msgf("Starting %d runs (parallel: %d, base seed: %d)", *runs, *parallel, seed)
+			results, err := runner.Run(ctx)
+			if err != nil {
+				return fmt.Errorf("batch run: %w", err)
+			}
+			if ctx.Err() != nil {
+				logger.Info().Msgf("Batch interrupted: reporting %d completed runs", len(results))
+			}
+
+			// Report
+			report := batch.NewReport(cityMap, results)
+			if err := report.Write(output, *format); err != nil {
+				return fmt.Errorf("writing report: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(flagConfigPath, flagShortConfigPath, "./config.toml", "Config file path (optional)")
+	cmd.Flags().StringP(flagMapPath, flagShortMapPath, "./map.aimap", "Map file path")
+	cmd.Flags().UintP(flagAliens, flagShortAliens, 25, "Number of Aliens to disembark")
+	cmd.Flags().Int64P(flagSeed, flagShortSeed, 0, "First run random source seed, incremented for the following runs (optional, time based if not set)")
+	cmd.Flags().UintP(flagRuns, flagShortRuns, 100, "Number of runs")
+	cmd.Flags().UintP(flagParallel, flagShortParallel, 4, "Number of runs executed in parallel")
+	cmd.Flags().StringP(flagFormat, flagShortFormat, batch.FormatTable, "Output format [table, json, csv]")
+	cmd.Flags().StringP(flagOutput, flagShortOutput, "", "Output file path (optional, stdout if not set)")
+
+	return cmd
+}
+
+// buildOutput returns the output file writer (or stdout if not set) and its close function.
+func buildOutput(cmd *cobra.Command) (io.Writer, func(), error) {
+	outputPath, err := pkg.GetStringFlag(cmd, flagOutput, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if outputPath == nil {
+		return os.Stdout, func() {}, nil
+	}
+
+	f, err := os.Create(*outputPath)
+	if err != nil {
+		return nil, nil, pkg.BuildParamErr(
+			flagOutput, pkg.ParamTypeFlag,
+			fmt.Errorf("creating file: %w", err),
+		)
+	}
+
+	return f, func() { _ = f.Close() }, nil
+}
@@ -2,10 +2,20 @@ package alieninvasion
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg"
+	"github.com/itiky/alienInvasion/pkg/config"
+	"github.com/itiky/alienInvasion/pkg/layout"
+	"github.com/itiky/alienInvasion/pkg/logging"
 	"github.com/itiky/alienInvasion/service/monitor/display"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+	"github.com/itiky/alienInvasion/service/monitor/render"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // NewMapCmd creates the /map command.
@@ -39,5 +49,124 @@ func NewMapCmd() *cobra.Command {
 	cmd.Flags().StringP(flagConfigPath, flagShortConfigPath, "./config.toml", "Config file path (optional)")
 	cmd.Flags().StringP(flagMapPath, flagShortMapPath, "./map.aimap", "Map file path")
 
+	cmd.AddCommand(
+		NewMapRenderCmd(),
+		NewMapEditCmd(),
+	)
+
+	return cmd
+}
+
+// NewMapRenderCmd creates the /map/render command.
+func NewMapRenderCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Renders a map into an SVG image without simulation",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Inputs build
+			if err := loadConfig(cmd); err != nil {
+				return err
+			}
+
+			cityMap, err := buildCityMap(cmd)
+			if err != nil {
+				return err
+			}
+
+			w, closeOutput, err := buildOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer closeOutput()
+
+			logger, err := buildLogger(logging.WithOutput(os.Stderr))
+			if err != nil {
+				return err
+			}
+
+			// Render
+			grid := layout.New(cityMap)
+			for _, conflict := range grid.Conflicts {
+				logger.Warn().Str("method", grid.Method).Msgf("Layout conflict: %s", conflict)
+			}
+
+			if err := render.WriteSVG(w, grid, mirror.New(cityMap, nil).Snapshot()); err != nil {
+				return fmt.Errorf("rendering SVG: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(flagConfigPath, flagShortConfigPath, "./config.toml", "Config file path (optional)")
+	cmd.Flags().StringP(flagMapPath, flagShortMapPath, "./map.aimap", "Map file path")
+	cmd.Flags().StringP(flagOutput, flagShortOutput, "", "Output file path (optional, stdout if not set)")
+
+	return cmd
+}
+
+// NewMapEditCmd creates the /map/edit command.
+func NewMapEditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Opens a map in the window editor (a new map is created if the file doesn't exist)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Inputs build
+			if err := loadConfig(cmd); err != nil {
+				return err
+			}
+
+			mapPath, err := pkg.GetStringFlag(cmd, flagMapPath, false)
+			if err != nil {
+				return err
+			}
+
+			outputPath, err := pkg.GetStringFlag(cmd, flagOutput, true)
+			if err != nil {
+				return err
+			}
+			if outputPath == nil {
+				outputPath = mapPath
+			}
+
+			cityMap := make(model.CityMap)
+			if _, err := os.Stat(*mapPath); err == nil {
+				if cityMap, err = buildCityMap(cmd); err != nil {
+					return err
+				}
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return pkg.BuildParamErr(
+					flagMapPath, pkg.ParamTypeFlag,
+					fmt.Errorf("reading map file: %w", err),
+				)
+			}
+
+			displayOpts, err := buildDisplayThemeOpts()
+			if err != nil {
+				return err
+			}
+			displayOpts = append(displayOpts,
+				display.WithScreenSize(
+					viper.GetInt(config.AppScreenWidth), viper.GetInt(config.AppScreenHeight),
+				),
+				display.WithEditor(*outputPath),
+			)
+
+			monitor, err := display.New(cityMap, nil, displayOpts...)
+			if err != nil {
+				return fmt.Errorf("building map editor: %w", err)
+			}
+
+			// Run
+			monitor.Run(context.Background())
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(flagConfigPath, flagShortConfigPath, "./config.toml", "Config file path (optional)")
+	cmd.Flags().StringP(flagMapPath, flagShortMapPath, "./map.aimap", "Map file path (created on save if doesn't exist)")
+	cmd.Flags().StringP(flagOutput, flagShortOutput, "", "Output map file path (optional, the map file is overwritten if not set)")
+
 	return cmd
 }
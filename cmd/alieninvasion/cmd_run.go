@@ -0,0 +1,57 @@
+package alieninvasion
+
+import (
+	"fmt"
+
+	"github.com/itiky/alienInvasion/pkg"
+	"github.com/itiky/alienInvasion/pkg/scenario"
+	"github.com/spf13/cobra"
+)
+
+const (
+	argScenarioPath = "scenario"
+)
+
+// NewRunCmd creates the /run command.
+func NewRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run [scenario]",
+		Short: "Starts the simulation engine using a scenario file (map, aliens, config and seed)",
+		Long: `Starts the simulation engine using a scenario file (TOML with the .toml extension).
+Scenario file has the same app / city / alien sections as the config file and the scenario section:
+
+[scenario]
+  name = "Demo"
+  seed = 42
+  map = "map_28.aimap"      # relative to the scenario file (or an inline definition: mapInline = """...""")
+  aliens = 25               # number of aliens to generate (or an explicit roster):
+  [[scenario.roster]]
+    name = "#00000000"
+    power = 5
+    speed = "750ms"
+    maxSteps = 25`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Inputs build
+			s, err := scenario.LoadFile(args[0])
+			if err != nil {
+				return pkg.BuildParamErr(
+					argScenarioPath, pkg.ParamTypeArg,
+					fmt.Errorf("loading scenario: %w", err),
+				)
+			}
+
+			// Run
+			return runSimulation(cmd, simInputs{
+				cityMap: s.CityMap,
+				aliens:  s.Aliens,
+				cfg:     s.Config,
+				seed:    s.Seed,
+			})
+		},
+	}
+
+	addMonitorFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,114 @@
+package alieninvasion
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/itiky/alienInvasion/pkg"
+	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/api"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagAddress = "address"
+
+	flagMaxAliens = "max-aliens"
+	flagMaxSims   = "max-sims"
+
+	flagStoppedSimTTL  = "stopped-ttl"
+	flagMaxStoppedSims = "max-stopped"
+)
+
+// NewServeCmd creates the /serve command.
+func NewServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Starts the HTTP JSON API server to launch, control and inspect simulations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Inputs build
+			if err := loadConfig(cmd); err != nil {
+				return err
+			}
+
+			simCfg, err := buildSimConfig()
+			if err != nil {
+				return err
+			}
+
+			address, err := pkg.GetStringFlag(cmd, flagAddress, false)
+			if err != nil {
+				return err
+			}
+
+			aliensCount, err := pkg.GetUintFlag(cmd, flagAliens, false)
+			if err != nil {
+				return err
+			}
+
+			maxAliens, err := pkg.GetUintFlag(cmd, flagMaxAliens, false)
+			if err != nil {
+				return err
+			}
+
+			maxSims, err := pkg.GetUintFlag(cmd, flagMaxSims, false)
+			if err != nil {
+				return err
+			}
+
+			stoppedSimTTL, err := pkg.GetDurationFlag(cmd, flagStoppedSimTTL, false)
+			if err != nil {
+				return err
+			}
+
+			maxStoppedSims, err := pkg.GetUintFlag(cmd, flagMaxStoppedSims, false)
+			if err != nil {
+				return err
+			}
+
+			logger, err := buildLogger()
+			if err != nil {
+				return err
+			}
+			ctx := logging.SetCtxLogger(context.Background(), logger)
+
+			// Server
+			server, err := api.New(
+				api.WithAddress(*address),
+				api.WithConfig(simCfg),
+				api.WithAliensCount(*aliensCount),
+				api.WithMaxAliens(*maxAliens),
+				api.WithMaxSims(int(*maxSims)),
+				api.WithStoppedSimTTL(*stoppedSimTTL),
+				api.WithMaxStoppedSims(int(*maxStoppedSims)),
+			)
+			if err != nil {
+				return fmt.Errorf("building API server: %w", err)
+			}
+
+			// Run
+			ctx, ctxCancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+			defer ctxCancel()
+
+			if err := server.Run(ctx); err != nil {
+				return fmt.Errorf("API server: %w", err)
+			}
+			logger.Info().Msg("Closing app: signal received")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(flagConfigPath, flagShortConfigPath, "./config.toml", "Config file path (optional)")
+	cmd.Flags().String(flagAddress, "127.0.0.1:8080", "HTTP server listen address")
+	cmd.Flags().UintP(flagAliens, flagShortAliens, 25, "Default number of Aliens to disembark (if not set by a request)")
+	cmd.Flags().Uint(flagMaxAliens, 10000, "Max number of Aliens per simulation (requests exceeding it are rejected)")
+	cmd.Flags().Uint(flagMaxSims, 16, "Max number of simulations running at the same time")
+	cmd.Flags().Duration(flagStoppedSimTTL, 10*time.Minute, "Period a stopped simulation is kept for (its result is available) before the eviction")
+	cmd.Flags().Uint(flagMaxStoppedSims, 64, "Max number of stopped simulations kept (the oldest stopped are evicted first)")
+
+	return cmd
+}
@@ -3,25 +3,66 @@ package alieninvasion
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/itiky/alienInvasion/model"
 	"github.com/itiky/alienInvasion/pkg"
 	"github.com/itiky/alienInvasion/pkg/config"
+	"github.com/itiky/alienInvasion/pkg/layout"
 	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/batch"
 	"github.com/itiky/alienInvasion/service/monitor"
 	"github.com/itiky/alienInvasion/service/monitor/display"
+	"github.com/itiky/alienInvasion/service/monitor/metrics"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
 	"github.com/itiky/alienInvasion/service/monitor/noop"
+	"github.com/itiky/alienInvasion/service/monitor/render"
+	"github.com/itiky/alienInvasion/service/monitor/tui"
+	"github.com/itiky/alienInvasion/service/monitor/web"
 	"github.com/itiky/alienInvasion/service/sim"
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 const (
-	flagDisplay      = "display"
-	flagShortDisplay = "d"
+	flagDisplay        = "display"
+	flagShortDisplay   = "d"
+	flagDisplayAddress = "display-address"
+	flagHeatmapReport  = "heatmap-report"
+
+	flagMetrics = "metrics"
+
+	flagRender         = "render"
+	flagRenderFrames   = "render-frames"
+	flagRenderInterval = "render-interval"
+	flagRenderScale    = "render-scale"
+	flagRenderMaxGIF   = "render-max-frames"
+
+	flagSVGFinal = "svg-final"
+)
+
+// Display modes (the --display flag values).
+const (
+	displayModeWindow = "window"
+	displayModeWeb    = "web"
+	displayModeTUI    = "tui"
 )
 
+// simInputs keeps the simulation inputs built from CLI flags or a scenario file.
+type simInputs struct {
+	cityMap   model.CityMap
+	aliens    []model.Alien
+	cfg       config.SimConfig
+	seed      int64
+	genAliens func(rnd *rand.Rand) ([]model.Alien, error) // Aliens generator for a restart with a new seed (optional, aliens are reused if nil)
+}
+
 // NewStartCmd creates the /start command.
 func NewStartCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -38,79 +79,499 @@ func NewStartCmd() *cobra.Command {
 				return err
 			}
 
-			aliens, err := buildAliens(cmd)
+			simCfg, err := buildSimConfig()
 			if err != nil {
 				return err
 			}
 
-			logger, err := buildLogger()
+			seed, err := buildSeed(cmd)
 			if err != nil {
 				return err
 			}
-			ctx := logging.SetCtxLogger(context.Background(), logger)
 
-			// Monitor
-			visualizationEnabled, err := pkg.GetBoolFlag(cmd, flagDisplay, false)
+			aliens, err := buildAliens(cmd, rand.New(rand.NewSource(seed)), simCfg) //nolint:gosec
 			if err != nil {
 				return err
 			}
 
-			var monitorSvc monitor.WorldEventsListener
-			monitorStopCh := make(chan struct{})
-			if *visualizationEnabled {
-				m, err := display.New(
-					cityMap, aliens,
-					display.WithScreenSize(
-						viper.GetInt(config.AppScreenWidth), viper.GetInt(config.AppScreenHeight),
-					),
-				)
-				if err != nil {
-					return fmt.Errorf("building visualization service: %w", err)
-				}
-				monitorSvc = m
-			} else {
-				monitorSvc = noop.New(
-					noop.WithLogs(),
-				)
-			}
-
-			// Simulation engine
-			simSvc, err := sim.New(
-				sim.WithCityMap(cityMap),
-				sim.WithAliens(aliens),
-				sim.WithMonitor(monitorSvc),
-			)
-			if err != nil {
-				return fmt.Errorf("building simulation service: %w", err)
-			}
-
 			// Run
-			ctx, ctxCancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
-			defer ctxCancel()
-
-			simStopCh := simSvc.Start(ctx)
-			if svc, ok := monitorSvc.(*display.Monitor); ok {
-				svc.Run(ctx)
-				close(monitorStopCh)
-			}
-
-			select {
-			case <-ctx.Done():
-				logger.Info().Msg("Closing app: signal received")
-			case <-simStopCh:
-				logger.Info().Msg("Closing app: simulation stopped")
-			case <-monitorStopCh:
-				logger.Info().Msg("Closing app: monitor stopped")
-			}
-
-			return nil
+			return runSimulation(cmd, simInputs{
+				cityMap: cityMap,
+				aliens:  aliens,
+				cfg:     simCfg,
+				seed:    seed,
+				genAliens: func(rnd *rand.Rand) ([]model.Alien, error) {
+					return buildAliens(cmd, rnd, simCfg)
+				},
+			})
 		},
 	}
 
 	cmd.Flags().StringP(flagConfigPath, flagShortConfigPath, "./config.toml", "Config file path (optional)")
 	cmd.Flags().StringP(flagMapPath, flagShortMapPath, "./map.aimap", "Map file path")
 	cmd.Flags().UintP(flagAliens, flagShortAliens, 25, "Number of Aliens to disembark")
-	cmd.Flags().BoolP(flagDisplay, flagShortDisplay, false, "Enable visualization")
+	cmd.Flags().Int64P(flagSeed, flagShortSeed, 0, "Random source seed (optional, time based if not set)")
+	addMonitorFlags(cmd)
 
 	return cmd
 }
+
+// addMonitorFlags adds the monitor flags shared by simulation commands.
+func addMonitorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(flagDisplay, flagShortDisplay, "", fmt.Sprintf("Enable visualization (%q, %q, %q)", displayModeWindow, displayModeWeb, displayModeTUI))
+	cmd.Flags().Lookup(flagDisplay).NoOptDefVal = displayModeWindow
+	cmd.Flags().String(flagDisplayAddress, "127.0.0.1:8090", fmt.Sprintf("Viewer HTTP server address (%q display mode)", displayModeWeb))
+	cmd.Flags().String(flagHeatmapReport, "", fmt.Sprintf("Batch JSON report path used by the window heatmap overlay for City destruction probabilities (%q display mode, optional)", displayModeWindow))
+	cmd.Flags().String(flagMetrics, "", "Serve Prometheus metrics on the address (optional, \"127.0.0.1:9090\" for example)")
+	cmd.Flags().String(flagRender, "", "Render the simulation into an animated GIF file (optional, headless)")
+	cmd.Flags().String(flagRenderFrames, "", "Render the simulation into a directory of PNG frames (optional, headless)")
+	cmd.Flags().Duration(flagRenderInterval, 250*time.Millisecond, "Rendering frames capture period (simulated time, scaled by the simulation speed)")
+	cmd.Flags().Float64(flagRenderScale, 0.5, "Rendering frame size scale (1.0 is the window size)")
+	cmd.Flags().Uint(flagRenderMaxGIF, 600, "Max number of animated GIF frames kept in memory (frames are thinned out once reached)")
+	cmd.Flags().String(flagSVGFinal, "", "Write the final World state into an SVG file (optional)")
+}
+
+// runSimulation starts the simulation engine with a monitor picked by CLI flags and waits for it to stop.
+func runSimulation(cmd *cobra.Command, inputs simInputs) error {
+	logger, err := buildLogger()
+	if err != nil {
+		return err
+	}
+	ctx := logging.SetCtxLogger(context.Background(), logger)
+
+	// Monitor
+	displayMode, err := pkg.GetStringFlag(cmd, flagDisplay, true)
+	if err != nil {
+		return err
+	}
+
+	displayAddress, err := pkg.GetStringFlag(cmd, flagDisplayAddress, false)
+	if err != nil {
+		return err
+	}
+
+	metricsAddress, err := pkg.GetStringFlag(cmd, flagMetrics, true)
+	if err != nil {
+		return err
+	}
+
+	renderSvc, err := buildRenderMonitor(cmd, inputs)
+	if err != nil {
+		return err
+	}
+
+	svgFinalPath, err := pkg.GetStringFlag(cmd, flagSVGFinal, true)
+	if err != nil {
+		return err
+	}
+
+	simCtl := &simController{
+		inputs:      inputs,
+		restartable: renderSvc == nil && metricsAddress == nil && svgFinalPath == nil,
+	}
+
+	var monitorSvc monitor.WorldEventsListener
+	var displaySvc *display.Monitor
+	var webSvc *web.Monitor
+	var tuiSvc *tui.Monitor
+	monitorStopCh := make(chan struct{})
+	switch {
+	case displayMode == nil:
+		monitorSvc = noop.New(
+			noop.WithLogs(),
+		)
+	case *displayMode == displayModeWindow:
+		displayOpts := []display.Option{
+			display.WithScreenSize(
+				viper.GetInt(config.AppScreenWidth), viper.GetInt(config.AppScreenHeight),
+			),
+			display.WithController(simCtl),
+		}
+
+		themeOpts, err := buildDisplayThemeOpts()
+		if err != nil {
+			return err
+		}
+		displayOpts = append(displayOpts, themeOpts...)
+
+		destroyProbs, err := readDestroyProbs(cmd)
+		if err != nil {
+			return err
+		}
+		if destroyProbs != nil {
+			displayOpts = append(displayOpts, display.WithDestructionProbabilities(destroyProbs))
+		}
+
+		m, err := display.New(inputs.cityMap, inputs.aliens, displayOpts...)
+		if err != nil {
+			return fmt.Errorf("building visualization service: %w", err)
+		}
+		monitorSvc, displaySvc = m, m
+	case *displayMode == displayModeWeb:
+		webOpts := []web.Option{
+			web.WithAddress(*displayAddress),
+		}
+
+		t, err := buildDisplayTheme()
+		if err != nil {
+			return err
+		}
+		if t != nil {
+			webOpts = append(webOpts, web.WithTheme(*t))
+		}
+
+		m, err := web.New(inputs.cityMap, inputs.aliens, webOpts...)
+		if err != nil {
+			return pkg.BuildParamErr(
+				flagDisplayAddress, pkg.ParamTypeFlag,
+				fmt.Errorf("building viewer service: %w", err),
+			)
+		}
+		monitorSvc, webSvc = m, m
+	case *displayMode == displayModeTUI:
+		m, err := tui.New(
+			inputs.cityMap, inputs.aliens,
+			tui.WithTitle(fmt.Sprintf("Alien invasion simulation (seed: %d)", inputs.seed)),
+		)
+		if err != nil {
+			return fmt.Errorf("building terminal UI service: %w", err)
+		}
+		monitorSvc, tuiSvc = m, m
+
+		// Events are shown by the UI itself, simulation warnings and errors are redirected to the events log
+		simLogger := logging.WithPlainOutput(m.LogWriter())(logger)
+		if simLogger.GetLevel() < zerolog.WarnLevel {
+			simLogger = simLogger.Level(zerolog.WarnLevel)
+		}
+		ctx = logging.SetCtxLogger(ctx, simLogger)
+	default:
+		return pkg.BuildParamErr(
+			flagDisplay, pkg.ParamTypeFlag,
+			fmt.Errorf("display mode (%s): unknown", *displayMode),
+		)
+	}
+
+	var metricsSvc *metrics.Monitor
+	if metricsAddress != nil {
+		m, err := metrics.New(inputs.cityMap, inputs.aliens, metrics.WithAddress(*metricsAddress))
+		if err != nil {
+			return pkg.BuildParamErr(
+				flagMetrics, pkg.ParamTypeFlag,
+				fmt.Errorf("building metrics service: %w", err),
+			)
+		}
+		monitorSvc, metricsSvc = monitor.NewMulti(monitorSvc, m), m
+	}
+
+	if renderSvc != nil {
+		monitorSvc = monitor.NewMulti(monitorSvc, renderSvc)
+	}
+
+	var svgMirror *mirror.Monitor
+	if svgFinalPath != nil {
+		svgMirror = mirror.New(inputs.cityMap, inputs.aliens)
+		monitorSvc = monitor.NewMulti(monitorSvc, svgMirror)
+	}
+
+	// Run
+	ctx, ctxCancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer ctxCancel()
+
+	if metricsSvc != nil {
+		if err := metricsSvc.Start(ctx); err != nil {
+			return fmt.Errorf("starting metrics service: %w", err)
+		}
+	}
+
+	renderErrCh := make(chan error, 1)
+	if renderSvc != nil {
+		go func() {
+			renderErrCh <- renderSvc.Run(ctx)
+		}()
+	}
+
+	if webSvc != nil {
+		if err := webSvc.Start(ctx); err != nil {
+			return fmt.Errorf("starting viewer service: %w", err)
+		}
+	}
+
+	simCtl.ctx, simCtl.monitor, simCtl.display = ctx, monitorSvc, displaySvc
+	if err := simCtl.Start(); err != nil {
+		return err
+	}
+	if displaySvc != nil {
+		displaySvc.Run(ctx)
+		close(monitorStopCh)
+	}
+	simStopCh := simCtl.StopCh() // might have been restarted
+	if tuiSvc != nil {
+		tuiSvc.Run(ctx)
+		close(monitorStopCh)
+	}
+
+	select {
+	case <-ctx.Done():
+		logger.Info().Msg("Closing app: signal received")
+	case <-simStopCh:
+		if webSvc != nil {
+			// Keep the final state available for the viewer
+			logger.Info().Msg("Simulation stopped: press Ctrl+C to close the viewer")
+			<-ctx.Done()
+		}
+		logger.Info().Msg("Closing app: simulation stopped")
+	case <-monitorStopCh:
+		logger.Info().Msg("Closing app: monitor stopped")
+	}
+
+	// Wait for the rendering outputs to be written
+	if renderSvc != nil {
+		ctxCancel()
+		if err := <-renderErrCh; err != nil {
+			return fmt.Errorf("rendering: %w", err)
+		}
+	}
+
+	// Final (or current if interrupted) World state
+	if svgMirror != nil {
+		if err := render.WriteSVGFile(*svgFinalPath, layout.New(inputs.cityMap), svgMirror.Snapshot()); err != nil {
+			return pkg.BuildParamErr(flagSVGFinal, pkg.ParamTypeFlag, err)
+		}
+		logger.Info().Msgf("Final state saved to %s", *svgFinalPath)
+	}
+
+	return nil
+}
+
+// readDestroyProbs reads City destruction probabilities from the batch JSON report if the heatmap report is set.
+func readDestroyProbs(cmd *cobra.Command) (map[string]float64, error) {
+	reportPath, err := pkg.GetStringFlag(cmd, flagHeatmapReport, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if reportPath == nil {
+		return nil, nil
+	}
+
+	f, err := os.Open(*reportPath)
+	if err != nil {
+		return nil, pkg.BuildParamErr(
+			flagHeatmapReport, pkg.ParamTypeFlag,
+			fmt.Errorf("opening file: %w", err),
+		)
+	}
+	defer f.Close()
+
+	report, err := batch.ReadReportJSON(f)
+	if err != nil {
+		return nil, pkg.BuildParamErr(flagHeatmapReport, pkg.ParamTypeFlag, err)
+	}
+
+	if len(report.Cities) == 0 {
+		return nil, pkg.BuildParamErr(
+			flagHeatmapReport, pkg.ParamTypeFlag,
+			fmt.Errorf("report has no cities"),
+		)
+	}
+
+	probs := make(map[string]float64, len(report.Cities))
+	for _, city := range report.Cities {
+		probs[city.Name] = city.Probability
+	}
+
+	return probs, nil
+}
+
+// buildRenderMonitor builds the headless rendering monitor if any of the rendering outputs is set.
+func buildRenderMonitor(cmd *cobra.Command, inputs simInputs) (*render.Monitor, error) {
+	gifPath, err := pkg.GetStringFlag(cmd, flagRender, true)
+	if err != nil {
+		return nil, err
+	}
+
+	framesDir, err := pkg.GetStringFlag(cmd, flagRenderFrames, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if gifPath == nil && framesDir == nil {
+		return nil, nil
+	}
+
+	interval, err := pkg.GetDurationFlag(cmd, flagRenderInterval, false)
+	if err != nil {
+		return nil, err
+	}
+
+	scale, err := pkg.GetFloat64Flag(cmd, flagRenderScale, false)
+	if err != nil {
+		return nil, err
+	}
+
+	maxGIFFrames, err := pkg.GetUintFlag(cmd, flagRenderMaxGIF, false)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []render.Option{
+		render.WithInterval(*interval),
+		render.WithScale(*scale),
+		render.WithMaxGIFFrames(int(*maxGIFFrames)),
+	}
+	if gifPath != nil {
+		opts = append(opts, render.WithGIF(*gifPath))
+	}
+	if framesDir != nil {
+		opts = append(opts, render.WithFramesDir(*framesDir))
+	}
+
+	t, err := buildDisplayTheme()
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		opts = append(opts, render.WithTheme(*t))
+	}
+
+	m, err := render.New(inputs.cityMap, inputs.aliens, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("building rendering service: %w", err)
+	}
+
+	return m, nil
+}
+
+// simController implements the display.Controller interface driving the simulation engine lifecycle.
+// Restart re-creates the engine with a new seed (and Aliens if the generator is set), so it is only
+// allowed if the display is the only monitor (other outputs follow a single simulation run).
+type simController struct {
+	sync.Mutex
+
+	ctx         context.Context
+	inputs      simInputs
+	monitor     monitor.WorldEventsListener
+	display     *display.Monitor // reset on restart (optional)
+	restartable bool
+
+	speed     float64 // current speed multiplier (kept on restart)
+	simSvc    *sim.Processor
+	simStopCh chan struct{}
+}
+
+var _ display.Controller = (*simController)(nil)
+
+// Start builds and starts the simulation engine for the current inputs.
+func (c *simController) Start() error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.start()
+}
+
+// StopCh returns the current simulation stopped channel.
+func (c *simController) StopCh() chan struct{} {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.simStopCh
+}
+
+// Pause implements the display.Controller interface.
+func (c *simController) Pause() error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.simSvc.Pause()
+}
+
+// Resume implements the display.Controller interface.
+func (c *simController) Resume() error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.simSvc.Resume()
+}
+
+// Step implements the display.Controller interface.
+func (c *simController) Step() error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.simSvc.Step()
+}
+
+// SetSpeed implements the display.Controller interface.
+func (c *simController) SetSpeed(speed float64) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.simSvc.SetSpeed(speed); err != nil {
+		return err
+	}
+	c.speed = speed
+
+	return nil
+}
+
+// Restart implements the display.Controller interface.
+func (c *simController) Restart() error {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.restartable {
+		return fmt.Errorf("restart: not supported with rendering, metrics or SVG outputs enabled")
+	}
+
+	// Stop the current run (no-op if already stopped) and wait for the last events to be emitted
+	if err := c.simSvc.Stop("restarted"); err != nil {
+		return err
+	}
+	select {
+	case <-c.simStopCh:
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+
+	// New inputs
+	c.inputs.seed = time.Now().UnixNano()
+	if c.inputs.genAliens != nil {
+		aliens, err := c.inputs.genAliens(rand.New(rand.NewSource(c.inputs.seed))) //nolint:gosec
+		if err != nil {
+			return fmt.Errorf("restart: generating aliens: %w", err)
+		}
+		c.inputs.aliens = aliens
+	}
+
+	if c.display != nil {
+		c.display.Reset(c.inputs.aliens)
+	}
+
+	return c.start()
+}
+
+// start builds and starts the simulation engine applying the current speed.
+func (c *simController) start() error {
+	simSvc, err := sim.New(
+		sim.WithConfig(c.inputs.cfg),
+		sim.WithSeed(c.inputs.seed),
+		sim.WithCityMap(c.inputs.cityMap),
+		sim.WithAliens(c.inputs.aliens),
+		sim.WithMonitor(c.monitor),
+	)
+	if err != nil {
+		return fmt.Errorf("building simulation service: %w", err)
+	}
+
+	_, logger := logging.GetCtxLogger(c.ctx)
+	logger.Info().Msgf("Starting simulation (seed: %d)", simSvc.Seed())
+
+	c.simSvc, c.simStopCh = simSvc, simSvc.Start(c.ctx)
+	if c.speed != 0 && c.speed != 1.0 {
+		if err := c.simSvc.SetSpeed(c.speed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
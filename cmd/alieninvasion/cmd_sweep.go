@@ -0,0 +1,151 @@
+package alieninvasion
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/itiky/alienInvasion/pkg"
+	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/sweep"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagParam = "param"
+
+	flagSeeds = "seeds"
+
+	flagSample = "sample"
+)
+
+// NewSweepCmd creates the /sweep command.
+func NewSweepCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sweep",
+		Short: "Runs headless simulations over a parameters space and writes a CSV row per run",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Inputs build
+			if err := loadConfig(cmd); err != nil {
+				return err
+			}
+
+			cityMap, err := buildCityMap(cmd)
+			if err != nil {
+				return err
+			}
+
+			simCfg, err := buildSimConfig()
+			if err != nil {
+				return err
+			}
+
+			seed, err := buildSeed(cmd)
+			if err != nil {
+				return err
+			}
+
+			aliensCount, err := pkg.GetUintFlag(cmd, flagAliens, false)
+			if err != nil {
+				return err
+			}
+
+			paramDefs, err := cmd.Flags().GetStringArray(flagParam)
+			if err != nil {
+				return pkg.BuildParamErr(flagParam, pkg.ParamTypeFlag, err)
+			}
+			params := make([]sweep.Param, 0, len(paramDefs))
+			for _, paramDef := range paramDefs {
+				param, err := sweep.ParseParam(paramDef)
+				if err != nil {
+					return pkg.BuildParamErr(flagParam, pkg.ParamTypeFlag, err)
+				}
+				params = append(params, param)
+			}
+
+			seeds, err := pkg.GetUintFlag(cmd, flagSeeds, false)
+			if err != nil {
+				return err
+			}
+
+			sample, err := pkg.GetUintFlag(cmd, flagSample, false)
+			if err != nil {
+				return err
+			}
+
+			parallel, err := pkg.GetUintFlag(cmd, flagParallel, false)
+			if err != nil {
+				return err
+			}
+
+			output, closeOutput, err := buildOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer closeOutput()
+
+			// Logs are written to stderr not to mix with the report
+			logger, err := buildLogger(logging.WithOutput(os.Stderr))
+			if err != nil {
+				return err
+			}
+			ctx := logging.SetCtxLogger(context.Background(), logger)
+
+			// Runner
+			runner, err := sweep.New(
+				sweep.WithConfig(simCfg),
+				sweep.WithCityMap(cityMap),
+				sweep.WithAliensCount(*aliensCount),
+				sweep.WithParams(params...),
+				sweep.WithSample(*sample),
+				sweep.WithSeeds(*seeds, *parallel),
+				sweep.WithBaseSeed(seed),
+			)
+			if err != nil {
+				return fmt.Errorf("building sweep runner: %w", err)
+			}
+
+			csvWriter, err := sweep.NewCSVWriter(output, runner.Params())
+			if err != nil {
+				return fmt.Errorf("building CSV writer: %w", err)
+			}
+
+			// Run
+			ctx, ctxCancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+			defer ctxCancel()
+
+			pointsCnt := len(runner.Points())
+			runsTotal, runsDone := pointsCnt*int(*seeds), 0
+			logger.Info().Msgf("Starting %d points x %d seeds (parallel: %d, base seed: %d)", pointsCnt, *seeds, *parallel, seed)
+
+			err = runner.Run(ctx, func(result sweep.Result) error {
+				runsDone++
+				logger.Info().Msgf("Run %d / %d done (point: %d, seed: %d): %s", runsDone, runsTotal, result.PointIdx, result.Seed, result.SimResult.Status.StopReason)
+
+				return csvWriter.Write(result)
+			})
+			if err != nil {
+				return fmt.Errorf("sweep run: %w", err)
+			}
+			if ctx.Err() != nil {
+				logger.Info().Msgf("Sweep interrupted: %d runs completed", runsDone)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(flagConfigPath, flagShortConfigPath, "./config.toml", "Config file path (optional)")
+	cmd.Flags().StringP(flagMapPath, flagShortMapPath, "./map.aimap", "Map file path")
+	cmd.Flags().UintP(flagAliens, flagShortAliens, 25, "Number of Aliens to disembark (if not swept)")
+	cmd.Flags().Int64P(flagSeed, flagShortSeed, 0, "First run random source seed of every point, incremented for the following runs (optional, time based if not set)")
+	cmd.Flags().StringArray(flagParam, nil, "Swept param: config key (or \"aliens\") with a values list (key=v1,v2) or an inclusive range (key=from:to:step), repeatable")
+	cmd.Flags().Uint(flagSeeds, 3, "Number of runs (seeds) per point")
+	cmd.Flags().Uint(flagSample, 0, "Number of randomly sampled points (optional, the whole grid if not set)")
+	cmd.Flags().UintP(flagParallel, flagShortParallel, 4, "Number of runs executed in parallel")
+	cmd.Flags().StringP(flagOutput, flagShortOutput, "", "Output CSV file path (optional, stdout if not set)")
+
+	return cmd
+}
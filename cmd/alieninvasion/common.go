@@ -2,11 +2,15 @@ package alieninvasion
 
 import (
 	"fmt"
+	"math/rand"
+	"time"
 
 	"github.com/itiky/alienInvasion/model"
 	"github.com/itiky/alienInvasion/pkg"
 	"github.com/itiky/alienInvasion/pkg/config"
 	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/monitor/display"
+	"github.com/itiky/alienInvasion/service/monitor/display/theme"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -21,6 +25,9 @@ const (
 
 	flagAliens      = "aliens"
 	flagShortAliens = "a"
+
+	flagSeed      = "seed"
+	flagShortSeed = "s"
 )
 
 // loadConfig loads and validate a config file if file path is provided.
@@ -66,26 +73,75 @@ func buildCityMap(cmd *cobra.Command) (model.CityMap, error) {
 	return cityMap, nil
 }
 
+// buildSimConfig builds a SimConfig from Viper values.
+func buildSimConfig() (config.SimConfig, error) {
+	cfg, err := config.NewSimConfig(viper.GetViper())
+	if err != nil {
+		return config.SimConfig{}, fmt.Errorf("building simulation config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// buildSeed returns the random source seed flag value or a time based one if not set.
+func buildSeed(cmd *cobra.Command) (int64, error) {
+	seed, err := pkg.GetInt64Flag(cmd, flagSeed, true)
+	if err != nil {
+		return 0, err
+	}
+
+	if seed == nil {
+		return time.Now().UnixNano(), nil
+	}
+
+	return *seed, nil
+}
+
 // buildAliens generates aliens slice by count provided.
-func buildAliens(cmd *cobra.Command) ([]model.Alien, error) {
+func buildAliens(cmd *cobra.Command, rnd *rand.Rand, cfg config.SimConfig) ([]model.Alien, error) {
 	aliensCount, err := pkg.GetUintFlag(cmd, flagAliens, false)
 	if err != nil {
 		return nil, err
 	}
 
-	return model.GenAliensFromConfig(*aliensCount), nil
+	return model.GenAliensFromConfig(rnd, cfg.Alien, *aliensCount), nil
 }
 
 // buildLogger builds a new logger using logLevel from config.
-func buildLogger() (zerolog.Logger, error) {
+func buildLogger(opts ...logging.LoggerOption) (zerolog.Logger, error) {
 	logLevel, err := zerolog.ParseLevel(viper.GetString(config.AppLogLevel))
 	if err != nil {
 		return zerolog.Logger{}, fmt.Errorf("parsing logLevel: %w", err)
 	}
 
 	logger := logging.NewLogger(
-		logging.WithLogLevel(logLevel),
+		append([]logging.LoggerOption{logging.WithLogLevel(logLevel)}, opts...)...,
 	)
 
 	return logger, nil
 }
+
+// buildDisplayTheme loads the display theme (nil if the theme asset directory is not configured).
+func buildDisplayTheme() (*theme.Theme, error) {
+	themeDir := viper.GetString(config.AppDisplayTheme)
+	if themeDir == "" {
+		return nil, nil
+	}
+
+	t, err := theme.Load(themeDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading display theme (%s): %w", config.AppDisplayTheme, err)
+	}
+
+	return &t, nil
+}
+
+// buildDisplayThemeOpts returns the display theme option if the theme asset directory is configured.
+func buildDisplayThemeOpts() ([]display.Option, error) {
+	t, err := buildDisplayTheme()
+	if err != nil || t == nil {
+		return nil, err
+	}
+
+	return []display.Option{display.WithTheme(*t)}, nil
+}
@@ -13,6 +13,10 @@ func NewRootCmd() *cobra.Command {
 
 	cmd.AddCommand(
 		NewStartCmd(),
+		NewRunCmd(),
+		NewBatchCmd(),
+		NewSweepCmd(),
+		NewServeCmd(),
 		NewMapCmd(),
 		NewVersionCmd(),
 	)
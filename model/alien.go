@@ -8,7 +8,12 @@ import (
 	"github.com/itiky/alienInvasion/pkg/config"
 	"github.com/itiky/alienInvasion/pkg/logging"
 	"github.com/rs/zerolog"
-	"github.com/spf13/viper"
+)
+
+// Alien dismiss reasons.
+const (
+	AlienDismissReasonEvacuated = "evacuated" // Alien is out of steps
+	AlienDismissReasonDestroyed = "destroyed" // Alien has been destroyed with a City
 )
 
 // Alien keeps alien params.
@@ -32,31 +37,31 @@ func (a Alien) GetLoggerContext(logCtx zerolog.Context) zerolog.Context {
 		Str(logging.AlienNameKey, a.Name)
 }
 
-// GenAliensFromConfig generates Aliens with random stats according to config params.
+// GenAliensFromConfig generates Aliens with random stats according to config params using the {rnd} source.
 // Contract: config is valid.
-func GenAliensFromConfig(n uint) []Alien {
-	stepMinDur, stepMaxDur := viper.GetDuration(config.AlienStepMinDur), viper.GetDuration(config.AlienStepMaxDur)
-	pwrMin, pwrMax := viper.GetUint(config.AlienMinPower), viper.GetUint(config.AlienMaxPower)
+func GenAliensFromConfig(rnd *rand.Rand, cfg config.AlienConfig, n uint) []Alien {
+	stepMinDur, stepMaxDur := cfg.StepMinDur, cfg.StepMaxDur
+	pwrMin, pwrMax := cfg.MinPower, cfg.MaxPower
 
 	aliens := make([]Alien, 0, n)
 	for id := uint(0); id < n; id++ {
 		pwr := pwrMin
 		if pwrMax != pwrMin {
 			diff := int64(pwrMax - pwrMin)
-			pwr += uint(rand.Int63n(diff)) //nolint:gosec
+			pwr += uint(rnd.Int63n(diff))
 		}
 
 		stepDur := stepMinDur
 		if stepMaxDur != stepMinDur {
 			diff := int64(stepMaxDur - stepMinDur)
-			stepDur += time.Duration(rand.Int63n(diff)) //nolint:gosec
+			stepDur += time.Duration(rnd.Int63n(diff))
 		}
 
 		aliens = append(aliens, Alien{
 			Name:     fmt.Sprintf("#%08d", id),
 			Power:    pwr,
 			Speed:    stepDur,
-			MaxSteps: viper.GetUint(config.AlienMaxSteps),
+			MaxSteps: cfg.MaxSteps,
 		})
 	}
 
@@ -3,8 +3,10 @@ package model
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -49,13 +51,24 @@ func (m CityMap) Validate() error {
 	return nil
 }
 
-// NewCityMapFromFile parses city map file.
+// NewCityMapFromFile parses city map file (see NewCityMapFromReader for the format).
+func NewCityMapFromFile(filePath string) (CityMap, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	return NewCityMapFromReader(f)
+}
+
+// NewCityMapFromReader parses city map definition.
 // Format:
 //   {CityName} [(north/east/south/west)={OtherCityName}]
 // Example:
 //   Foo north=Bar west=Baz south=Qu-ux
 //   Bar south=Foo west=Bee
-func NewCityMapFromFile(filePath string) (CityMap, error) {
+func NewCityMapFromReader(r io.Reader) (CityMap, error) {
 	const (
 		northRoadKey = "north"
 		eastRoadKey  = "east"
@@ -63,21 +76,16 @@ func NewCityMapFromFile(filePath string) (CityMap, error) {
 		westRoadKey  = "west"
 	)
 
-	f, err := os.Open(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("opening file: %w", err)
-	}
-	defer f.Close()
-
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	scanner.Split(bufio.ScanLines)
 
 	cityMap := make(CityMap)
 	for lineN := 0; scanner.Scan(); lineN++ {
-		lineParts := strings.Split(scanner.Text(), " ")
-		if len(lineParts) == 0 {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
 			continue
 		}
+		lineParts := strings.Split(line, " ")
 
 		city := City{
 			Name: lineParts[0],
@@ -109,5 +117,68 @@ func NewCityMapFromFile(filePath string) (CityMap, error) {
 		cityMap[city.Name] = city
 	}
 
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading: %w", err)
+	}
+
 	return cityMap, nil
 }
+
+// WriteFile writes the city map file (see Write for the format), an existing file is overwritten.
+func (m CityMap) WriteFile(filePath string) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+
+	if err := m.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing file: %w", err)
+	}
+
+	return nil
+}
+
+// Write writes the city map definition in the NewCityMapFromReader format.
+// Output is deterministic: cities are sorted by name, roads follow the north / east / south / west order.
+func (m CityMap) Write(w io.Writer) error {
+	cityIDs := make([]string, 0, len(m))
+	for cityID := range m {
+		cityIDs = append(cityIDs, cityID)
+	}
+	sort.Strings(cityIDs)
+
+	bw := bufio.NewWriter(w)
+	for _, cityID := range cityIDs {
+		city := m[cityID]
+
+		line := city.Name
+		for _, road := range []struct {
+			side   string
+			cityID string
+		}{
+			{side: "north", cityID: city.NorthRoad},
+			{side: "east", cityID: city.EastRoad},
+			{side: "south", cityID: city.SouthRoad},
+			{side: "west", cityID: city.WestRoad},
+		} {
+			if road.cityID != "" {
+				line += " " + road.side + "=" + road.cityID
+			}
+		}
+
+		if _, err := bw.WriteString(line + "\n"); err != nil {
+			return fmt.Errorf("writing city (%s): %w", cityID, err)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package model
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewCityMapFromReader(t *testing.T) {
+	type testCase struct {
+		name        string
+		input       string
+		expected    CityMap
+		errExpected bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "OK: empty input",
+			input:    "",
+			expected: CityMap{},
+		},
+		{
+			name:  "OK: roads, isolated city and empty lines",
+			input: "Foo north=Bar west=Baz\n\n  Bar south=Foo  \nBaz east=Foo\nQu-ux\n",
+			expected: CityMap{
+				"Foo":   City{Name: "Foo", NorthRoad: "Bar", WestRoad: "Baz"},
+				"Bar":   City{Name: "Bar", SouthRoad: "Foo"},
+				"Baz":   City{Name: "Baz", EastRoad: "Foo"},
+				"Qu-ux": City{Name: "Qu-ux"},
+			},
+		},
+		{
+			name:  "OK: side is case-insensitive",
+			input: "Foo NORTH=Bar\nBar South=Foo",
+			expected: CityMap{
+				"Foo": City{Name: "Foo", NorthRoad: "Bar"},
+				"Bar": City{Name: "Bar", SouthRoad: "Foo"},
+			},
+		},
+		{
+			name:        "Fail: invalid road format",
+			input:       "Foo north:Bar",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: invalid side",
+			input:       "Foo up=Bar",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: duplicate city",
+			input:       "Foo north=Bar\nBar south=Foo\nFoo",
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cityMap, err := NewCityMapFromReader(strings.NewReader(tc.input))
+			if tc.errExpected {
+				if err == nil {
+					t.Fatalf("error expected, got: %+v", cityMap)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(cityMap, tc.expected) {
+				t.Errorf("city map: got %+v, expected %+v", cityMap, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCityMapWrite(t *testing.T) {
+	type testCase struct {
+		name     string
+		cityMap  CityMap
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Empty map",
+			cityMap:  CityMap{},
+			expected: "",
+		},
+		{
+			name: "Isolated cities",
+			cityMap: CityMap{
+				"Foo": City{Name: "Foo"},
+				"Bar": City{Name: "Bar"},
+			},
+			expected: "Bar\nFoo\n",
+		},
+		{
+			name: "Cities sorted by name, roads in north / east / south / west order",
+			cityMap: CityMap{
+				"Foo":   City{Name: "Foo", NorthRoad: "Bar", EastRoad: "Qu-ux", SouthRoad: "Bee", WestRoad: "Baz"},
+				"Bar":   City{Name: "Bar", SouthRoad: "Foo"},
+				"Baz":   City{Name: "Baz", EastRoad: "Foo"},
+				"Bee":   City{Name: "Bee", NorthRoad: "Foo"},
+				"Qu-ux": City{Name: "Qu-ux", WestRoad: "Foo"},
+				"Zed":   City{Name: "Zed"},
+			},
+			expected: "Bar south=Foo\n" +
+				"Baz east=Foo\n" +
+				"Bee north=Foo\n" +
+				"Foo north=Bar east=Qu-ux south=Bee west=Baz\n" +
+				"Qu-ux west=Foo\n" +
+				"Zed\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			if err := tc.cityMap.Write(&buf); err != nil {
+				t.Fatalf("writing: %v", err)
+			}
+			if out := buf.String(); out != tc.expected {
+				t.Fatalf("output: got %q, expected %q", out, tc.expected)
+			}
+
+			// Round-trip
+			readCityMap, err := NewCityMapFromReader(&buf)
+			if err != nil {
+				t.Fatalf("reading: %v", err)
+			}
+			if !reflect.DeepEqual(readCityMap, tc.cityMap) {
+				t.Errorf("city map: got %+v, expected %+v", readCityMap, tc.cityMap)
+			}
+
+			// File round-trip
+			filePath := filepath.Join(t.TempDir(), "map.aimap")
+			if err := tc.cityMap.WriteFile(filePath); err != nil {
+				t.Fatalf("writing file: %v", err)
+			}
+			readCityMap, err = NewCityMapFromFile(filePath)
+			if err != nil {
+				t.Fatalf("reading file: %v", err)
+			}
+			if !reflect.DeepEqual(readCityMap, tc.cityMap) {
+				t.Errorf("city map (file): got %+v, expected %+v", readCityMap, tc.cityMap)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package model
+
+import "time"
+
+// SimPhase defines the simulation lifecycle phase.
+type SimPhase string
+
+const (
+	SimPhaseDisembarking SimPhase = "disembarking" // Aliens are landing, stop conditions are not checked
+	SimPhaseRunning      SimPhase = "running"      // all Aliens have landed (or failed to), stop conditions are checked
+	SimPhaseDraining     SimPhase = "draining"     // stop condition is met, ongoing fights are finishing
+	SimPhasePaused       SimPhase = "paused"       // simulation is frozen (Aliens, fights and disembark), resumes to the previous phase
+	SimPhaseStopped      SimPhase = "stopped"      // simulation is over
+)
+
+// SimStatus keeps the simulation state counters.
+type SimStatus struct {
+	// Simulation lifecycle phase
+	Phase SimPhase
+
+	// Aliens on the map / waiting to land
+	Aliens        int
+	AliensWaiting int
+
+	// Cities left / initial number of cities
+	Cities        int
+	CitiesInitial int
+
+	// Number of ongoing City fights
+	Fights int
+
+	// Number of World events emitted
+	Events uint64
+
+	// Number of requests waiting in the World input queues (Aliens / Cities and disembark)
+	AlienRequestsQueued int
+	WorldRequestsQueued int
+
+	// Simulated time (time the World was running multiplied by the simulation speed)
+	SimElapsed time.Duration
+
+	// Wall-clock time since the simulation start
+	WallElapsed time.Duration
+
+	// Simulated time passed since the last Alien move (landing included)
+	SinceLastMove time.Duration
+
+	// Simulation speed multiplier (1.0 is the real time)
+	Speed float64
+
+	// Simulation is stopped flag and a reason why
+	Stopped    bool
+	StopReason string
+}
+
+// CitiesDestroyed returns the number of destroyed cities.
+func (s SimStatus) CitiesDestroyed() int {
+	return s.CitiesInitial - s.Cities
+}
+
+// SimResult keeps the simulation outcome.
+type SimResult struct {
+	// Random source seed used
+	Seed int64
+
+	// Final simulation status
+	Status SimStatus
+
+	// Cities destroyed (in order) / left
+	CitiesDestroyed []string
+	CitiesLeft      []string
+
+	// Aliens outcome counters
+	AliensTotal      int
+	AliensNotLanded  int
+	AliensEvacuated  int
+	AliensDestroyed  int
+	AliensSurvived   int
+	SurvivorAlienIDs []string
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -52,6 +53,48 @@ func GetUintFlag(cmd *cobra.Command, flagName string, isOptional bool) (*uint, e
 	return &v, nil
 }
 
+// GetInt64Flag returns CLI int64 flag value.
+func GetInt64Flag(cmd *cobra.Command, flagName string, isOptional bool) (*int64, error) {
+	if !shouldHandleFlag(cmd, flagName, isOptional) {
+		return nil, nil
+	}
+
+	v, err := cmd.Flags().GetInt64(flagName)
+	if err != nil {
+		return nil, BuildParamErr(flagName, ParamTypeFlag, err)
+	}
+
+	return &v, nil
+}
+
+// GetDurationFlag returns CLI duration flag value.
+func GetDurationFlag(cmd *cobra.Command, flagName string, isOptional bool) (*time.Duration, error) {
+	if !shouldHandleFlag(cmd, flagName, isOptional) {
+		return nil, nil
+	}
+
+	v, err := cmd.Flags().GetDuration(flagName)
+	if err != nil {
+		return nil, BuildParamErr(flagName, ParamTypeFlag, err)
+	}
+
+	return &v, nil
+}
+
+// GetFloat64Flag returns CLI float64 flag value.
+func GetFloat64Flag(cmd *cobra.Command, flagName string, isOptional bool) (*float64, error) {
+	if !shouldHandleFlag(cmd, flagName, isOptional) {
+		return nil, nil
+	}
+
+	v, err := cmd.Flags().GetFloat64(flagName)
+	if err != nil {
+		return nil, BuildParamErr(flagName, ParamTypeFlag, err)
+	}
+
+	return &v, nil
+}
+
 // GetUintArg returns CLI uint arg value.
 func GetUintArg(argName, argValue string) (uint, error) {
 	v, err := strconv.ParseUint(argValue, 10, 16)
@@ -1,8 +1,6 @@
 package config
 
 import (
-	"time"
-
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 )
@@ -13,11 +11,14 @@ const (
 	AppLogLevel     = appPrefix + "logLevel"     // Logging level [debug, info, warn, error, fatal]
 	AppScreenWidth  = appPrefix + "screenWidth"  // Visualization screen width [int]
 	AppScreenHeight = appPrefix + "screenHeight" // Visualization screen height [int]
+	AppDisplayTheme = appPrefix + "displayTheme" // Visualization theme asset directory with the theme.json manifest (optional) [string]
 
 	AppAliensDisembarkMinRate = appPrefix + "aliensDisembarkMinRate" // Minimum time offset to disembark an alien [duration]
 	AppAliensDisembarkMaxRate = appPrefix + "aliensDisembarkMaxRate" // Maximum time offset to disembark an alien [duration]
 
-	AppSimStopCheckRate = appPrefix + "simStopCheckRate" // Check if simulation should be stopped rate [duration]
+	AppSimStopCheckRate  = appPrefix + "simStopCheckRate"  // Check if simulation should be stopped rate [duration]
+	AppSimStopConditions = appPrefix + "simStopConditions" // Simulation stop conditions with and / or composition [string]
+	AppSimDrainTimeout   = appPrefix + "simDrainTimeout"   // Max time to wait for ongoing fights once a stop condition is met [duration]
 )
 
 const (
@@ -38,27 +39,37 @@ const (
 	AlienMaxPower = alienPrefix + "maxPower" // Maximum fighting power [uint]
 )
 
-func init() {
+// SetDefaults sets default values for all config keys.
+func SetDefaults(v *viper.Viper) {
+	simDefaults := DefaultSimConfig()
+
 	// app. defaults
-	viper.SetDefault(AppLogLevel, zerolog.LevelInfoValue)
+	v.SetDefault(AppLogLevel, zerolog.LevelInfoValue)
 
-	viper.SetDefault(AppAliensDisembarkMinRate, 250*time.Millisecond)
-	viper.SetDefault(AppAliensDisembarkMaxRate, 500*time.Millisecond)
+	v.SetDefault(AppAliensDisembarkMinRate, simDefaults.AliensDisembarkMinRate)
+	v.SetDefault(AppAliensDisembarkMaxRate, simDefaults.AliensDisembarkMaxRate)
 
-	viper.SetDefault(AppSimStopCheckRate, 1*time.Second)
+	v.SetDefault(AppSimStopCheckRate, simDefaults.StopCheckRate)
+	v.SetDefault(AppSimStopConditions, simDefaults.StopConditions)
+	v.SetDefault(AppSimDrainTimeout, simDefaults.DrainTimeout)
 
-	viper.SetDefault(AppScreenWidth, 1200)
-	viper.SetDefault(AppScreenHeight, 1000)
+	v.SetDefault(AppScreenWidth, 1200)
+	v.SetDefault(AppScreenHeight, 1000)
+	v.SetDefault(AppDisplayTheme, "")
 
 	// city. defaults
-	viper.SetDefault(CityFightDurK, 150*time.Millisecond)
+	v.SetDefault(CityFightDurK, simDefaults.City.FightDurK)
 
 	// alien. defaults
-	viper.SetDefault(AlienStepMinDur, 500*time.Millisecond)
-	viper.SetDefault(AlienStepMaxDur, 1*time.Second)
+	v.SetDefault(AlienStepMinDur, simDefaults.Alien.StepMinDur)
+	v.SetDefault(AlienStepMaxDur, simDefaults.Alien.StepMaxDur)
 
-	viper.SetDefault(AlienMaxSteps, 25)
+	v.SetDefault(AlienMaxSteps, simDefaults.Alien.MaxSteps)
 
-	viper.SetDefault(AlienMinPower, 0)
-	viper.SetDefault(AlienMaxPower, 10)
+	v.SetDefault(AlienMinPower, simDefaults.Alien.MinPower)
+	v.SetDefault(AlienMaxPower, simDefaults.Alien.MaxPower)
+}
+
+func init() {
+	SetDefaults(viper.GetViper())
 }
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type (
+	// SimConfig keeps the simulation engine params.
+	SimConfig struct {
+		// Time offset range to disembark an Alien
+		AliensDisembarkMinRate time.Duration
+		AliensDisembarkMaxRate time.Duration
+
+		// Stop conditions check rate and definition (and / or composition)
+		StopCheckRate  time.Duration
+		StopConditions string
+
+		// Max time to wait for ongoing fights once a stop condition is met
+		DrainTimeout time.Duration
+
+		// City params
+		City CityConfig
+
+		// Alien generation params
+		Alien AlienConfig
+	}
+
+	// CityConfig keeps the City params.
+	CityConfig struct {
+		// Fight duration per Alien power (K * totalAliensPower = OverallFightDuration)
+		FightDurK time.Duration
+	}
+
+	// AlienConfig keeps the Alien generation params.
+	AlienConfig struct {
+		// Time offset range to move from a City
+		StepMinDur time.Duration
+		StepMaxDur time.Duration
+
+		// Maximum number of steps before Alien stops moving
+		MaxSteps uint
+
+		// Fighting power range
+		MinPower uint
+		MaxPower uint
+	}
+)
+
+// DefaultSimConfig returns the default SimConfig (used as Viper defaults as well).
+func DefaultSimConfig() SimConfig {
+	return SimConfig{
+		AliensDisembarkMinRate: 250 * time.Millisecond,
+		AliensDisembarkMaxRate: 500 * time.Millisecond,
+		StopCheckRate:          1 * time.Second,
+		StopConditions:         "lastAlien or allCitiesDestroyed",
+		DrainTimeout:           10 * time.Second,
+		City: CityConfig{
+			FightDurK: 150 * time.Millisecond,
+		},
+		Alien: AlienConfig{
+			StepMinDur: 500 * time.Millisecond,
+			StepMaxDur: 1 * time.Second,
+			MaxSteps:   25,
+			MinPower:   0,
+			MaxPower:   10,
+		},
+	}
+}
+
+// NewSimConfig builds a SimConfig using Viper values and validates it.
+func NewSimConfig(v *viper.Viper) (SimConfig, error) {
+	c := SimConfig{
+		AliensDisembarkMinRate: v.GetDuration(AppAliensDisembarkMinRate),
+		AliensDisembarkMaxRate: v.GetDuration(AppAliensDisembarkMaxRate),
+		StopCheckRate:          v.GetDuration(AppSimStopCheckRate),
+		StopConditions:         v.GetString(AppSimStopConditions),
+		DrainTimeout:           v.GetDuration(AppSimDrainTimeout),
+		City: CityConfig{
+			FightDurK: v.GetDuration(CityFightDurK),
+		},
+		Alien: AlienConfig{
+			StepMinDur: v.GetDuration(AlienStepMinDur),
+			StepMaxDur: v.GetDuration(AlienStepMaxDur),
+			MaxSteps:   v.GetUint(AlienMaxSteps),
+			MinPower:   v.GetUint(AlienMinPower),
+			MaxPower:   v.GetUint(AlienMaxPower),
+		},
+	}
+
+	if err := c.Validate(); err != nil {
+		return SimConfig{}, err
+	}
+
+	return c, nil
+}
+
+// Validate performs SimConfig values validation (errors refer to the corresponding config keys).
+func (c SimConfig) Validate() error {
+	// app
+	{
+		if c.AliensDisembarkMinRate < 0 {
+			return fmt.Errorf("%s key: must be GTE 0", AppAliensDisembarkMinRate)
+		}
+		if c.AliensDisembarkMaxRate < 0 {
+			return fmt.Errorf("%s key: must be GTE 0", AppAliensDisembarkMaxRate)
+		}
+		if c.AliensDisembarkMaxRate < c.AliensDisembarkMinRate {
+			return fmt.Errorf("%s key: must be GTE %s", AppAliensDisembarkMaxRate, AppAliensDisembarkMinRate)
+		}
+
+		if c.StopCheckRate <= 0 {
+			return fmt.Errorf("%s key: must be GT 0", AppSimStopCheckRate)
+		}
+
+		if strings.TrimSpace(c.StopConditions) == "" {
+			return fmt.Errorf("%s key: must be non-empty", AppSimStopConditions)
+		}
+
+		if c.DrainTimeout < 0 {
+			return fmt.Errorf("%s key: must be GTE 0", AppSimDrainTimeout)
+		}
+	}
+
+	// city
+	{
+		if c.City.FightDurK < 0 {
+			return fmt.Errorf("%s key: must be GTE 0", CityFightDurK)
+		}
+	}
+
+	// alien
+	{
+		if c.Alien.StepMinDur <= 0 {
+			return fmt.Errorf("%s key: must be GT 0", AlienStepMinDur)
+		}
+		if c.Alien.StepMaxDur <= 0 {
+			return fmt.Errorf("%s key: must be GT 0", AlienStepMaxDur)
+		}
+		if c.Alien.StepMaxDur < c.Alien.StepMinDur {
+			return fmt.Errorf("%s key: must be GTE %s", AlienStepMaxDur, AlienStepMinDur)
+		}
+
+		if c.Alien.MaxPower < c.Alien.MinPower {
+			return fmt.Errorf("%s key: must be GTE %s", AlienMaxPower, AlienMinPower)
+		}
+
+		if c.Alien.MaxSteps == 0 {
+			return fmt.Errorf("%s key: must be GT 0", AlienMaxSteps)
+		}
+	}
+
+	return nil
+}
+
+// SimKeys returns all config keys the SimConfig is built from.
+func SimKeys() []string {
+	return []string{
+		AppAliensDisembarkMinRate, AppAliensDisembarkMaxRate,
+		AppSimStopCheckRate, AppSimStopConditions, AppSimDrainTimeout,
+		CityFightDurK,
+		AlienStepMinDur, AlienStepMaxDur, AlienMaxSteps, AlienMinPower, AlienMaxPower,
+	}
+}
+
+// Override returns a copy of the SimConfig with values overridden by config keys (values are parsed the Viper way).
+func (c SimConfig) Override(values map[string]string) (SimConfig, error) {
+	v := viper.New()
+	v.Set(AppAliensDisembarkMinRate, c.AliensDisembarkMinRate)
+	v.Set(AppAliensDisembarkMaxRate, c.AliensDisembarkMaxRate)
+	v.Set(AppSimStopCheckRate, c.StopCheckRate)
+	v.Set(AppSimStopConditions, c.StopConditions)
+	v.Set(AppSimDrainTimeout, c.DrainTimeout)
+	v.Set(CityFightDurK, c.City.FightDurK)
+	v.Set(AlienStepMinDur, c.Alien.StepMinDur)
+	v.Set(AlienStepMaxDur, c.Alien.StepMaxDur)
+	v.Set(AlienMaxSteps, c.Alien.MaxSteps)
+	v.Set(AlienMinPower, c.Alien.MinPower)
+	v.Set(AlienMaxPower, c.Alien.MaxPower)
+
+	simKeys := make(map[string]struct{})
+	for _, key := range SimKeys() {
+		simKeys[key] = struct{}{}
+	}
+
+	for key, value := range values {
+		if _, ok := simKeys[key]; !ok {
+			return SimConfig{}, fmt.Errorf("%s key: unknown simulation config key", key)
+		}
+		v.Set(key, value)
+	}
+
+	return NewSimConfig(v)
+}
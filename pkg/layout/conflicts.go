@@ -0,0 +1,82 @@
+package layout
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+const (
+	// ConflictPosition defines a conflict of cities sharing the same grid position.
+	ConflictPosition = "position"
+	// ConflictRoad defines a conflict of a road that doesn't lead to the adjacent grid position in its direction.
+	ConflictRoad = "road"
+)
+
+// Conflict keeps a grid placement conflict.
+type Conflict struct {
+	Type     string   `json:"type"`
+	CityIDs  []string `json:"cityIds"` // sorted for the position conflict, [from, to] for the road one
+	Position Position `json:"position"`
+}
+
+// String implements the fmt.Stringer interface.
+func (c Conflict) String() string {
+	switch c.Type {
+	case ConflictPosition:
+		return fmt.Sprintf("cities %v share the (%d, %d) position", c.CityIDs, c.Position.X, c.Position.Y)
+	case ConflictRoad:
+		return fmt.Sprintf("road %s -> %s doesn't lead to the adjacent position", c.CityIDs[0], c.CityIDs[1])
+	default:
+		return c.Type
+	}
+}
+
+// detectConflicts returns position and road conflicts for the cities placement (sorted).
+func detectConflicts(cityMap model.CityMap, positions map[string]Position) []Conflict {
+	var conflicts []Conflict
+
+	// Cities sharing a position
+	posCities := make(map[Position][]string, len(positions))
+	for cityID, pos := range positions {
+		posCities[pos] = append(posCities[pos], cityID)
+	}
+	for pos, cityIDs := range posCities {
+		if len(cityIDs) < 2 {
+			continue
+		}
+		sort.Strings(cityIDs)
+		conflicts = append(conflicts, Conflict{Type: ConflictPosition, CityIDs: cityIDs, Position: pos})
+	}
+
+	// Roads leading to non-adjacent positions
+	for _, cityID := range sortedCityIDs(cityMap) {
+		pos, ok := positions[cityID]
+		if !ok {
+			continue
+		}
+
+		for _, dir := range compassDirs {
+			neighbourID := dir.road(cityMap[cityID])
+			if neighbourID == "" {
+				continue
+			}
+
+			neighbourPos, ok := positions[neighbourID]
+			if !ok || neighbourPos == (Position{X: pos.X + dir.dx, Y: pos.Y + dir.dy}) {
+				continue
+			}
+			conflicts = append(conflicts, Conflict{Type: ConflictRoad, CityIDs: []string{cityID, neighbourID}, Position: pos})
+		}
+	}
+
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if conflicts[i].Type != conflicts[j].Type {
+			return conflicts[i].Type < conflicts[j].Type
+		}
+		return conflicts[i].CityIDs[0] < conflicts[j].CityIDs[0]
+	})
+
+	return conflicts
+}
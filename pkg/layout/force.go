@@ -0,0 +1,152 @@
+package layout
+
+import (
+	"math"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+// Force-directed placement params.
+const (
+	forceIterations   = 300
+	forceStepStart    = 0.5 // max position change per iteration (grid cells), decays linearly to forceStepEnd
+	forceStepEnd      = 0.01
+	forceSpringCoef   = 0.5 // road spring: pulls a neighbour towards the adjacent position in the road direction
+	forceRepulseCoef  = 1.0 // repulsion: pushes cities closer than forceRepulseDist apart
+	forceRepulseDist  = 1.0
+	forceSnapMaxRange = 1000 // max free cell search radius (never reached for sane maps)
+)
+
+// NewForceGrid places all cities using the force-directed placement for maps that don't fit a grid.
+// Roads act as springs that keep neighbours in their compass directions, cities repel each other.
+// Placement starts from the {initial} grid (usually the compass one) and is deterministic.
+// Final positions are snapped to free grid cells, so there are no position conflicts.
+func NewForceGrid(cityMap model.CityMap, initial Grid) Grid {
+	g := Grid{
+		Positions: make(map[string]Position, len(cityMap)),
+		Method:    MethodForce,
+	}
+	if len(cityMap) == 0 {
+		return g
+	}
+
+	cityIDs := sortedCityIDs(cityMap)
+	cityIdxs := make(map[string]int, len(cityIDs))
+	for i, cityID := range cityIDs {
+		cityIdxs[cityID] = i
+	}
+
+	// Initial positions (cities sharing a position are spread around it)
+	xs, ys := make([]float64, len(cityIDs)), make([]float64, len(cityIDs))
+	for i, cityID := range cityIDs {
+		pos := initial.Positions[cityID]
+		angle := float64(i) * 2.399963 // golden angle
+		xs[i] = float64(pos.X) + 0.1*math.Cos(angle)
+		ys[i] = float64(pos.Y) + 0.1*math.Sin(angle)
+	}
+
+	// Relax
+	dxs, dys := make([]float64, len(cityIDs)), make([]float64, len(cityIDs))
+	for iter := 0; iter < forceIterations; iter++ {
+		for i := range dxs {
+			dxs[i], dys[i] = 0, 0
+		}
+
+		// Road springs
+		for i, cityID := range cityIDs {
+			for _, dir := range compassDirs {
+				j, ok := cityIdxs[dir.road(cityMap[cityID])]
+				if !ok {
+					continue
+				}
+
+				fx := (xs[i] + float64(dir.dx) - xs[j]) * forceSpringCoef
+				fy := (ys[i] + float64(dir.dy) - ys[j]) * forceSpringCoef
+				dxs[j], dys[j] = dxs[j]+fx, dys[j]+fy
+				dxs[i], dys[i] = dxs[i]-fx, dys[i]-fy
+			}
+		}
+
+		// Repulsion
+		for i := range cityIDs {
+			for j := i + 1; j < len(cityIDs); j++ {
+				vx, vy := xs[j]-xs[i], ys[j]-ys[i]
+				dist := math.Hypot(vx, vy)
+				if dist >= forceRepulseDist {
+					continue
+				}
+				if dist < 1e-9 {
+					angle := float64(i+j) * 2.399963
+					vx, vy, dist = math.Cos(angle), math.Sin(angle), 1.0
+				}
+
+				f := (forceRepulseDist - dist) / dist * forceRepulseCoef
+				dxs[j], dys[j] = dxs[j]+vx*f, dys[j]+vy*f
+				dxs[i], dys[i] = dxs[i]-vx*f, dys[i]-vy*f
+			}
+		}
+
+		// Move (limited by the current step)
+		step := forceStepStart + (forceStepEnd-forceStepStart)*float64(iter)/float64(forceIterations-1)
+		for i := range cityIDs {
+			if d := math.Hypot(dxs[i], dys[i]); d > step {
+				dxs[i], dys[i] = dxs[i]/d*step, dys[i]/d*step
+			}
+			xs[i], ys[i] = xs[i]+dxs[i], ys[i]+dys[i]
+		}
+	}
+
+	// Snap to the nearest free cells
+	occupied := make(map[Position]bool, len(cityIDs))
+	for i, cityID := range cityIDs {
+		pos := nearestFreeCell(xs[i], ys[i], occupied)
+		occupied[pos] = true
+		g.Positions[cityID] = pos
+	}
+
+	g.resize()
+	g.Conflicts = detectConflicts(cityMap, g.Positions)
+
+	return g
+}
+
+// nearestFreeCell returns the free grid cell closest to the {x, y} point.
+// Cells are searched within growing square rings (ties are resolved by the ring traversal order).
+func nearestFreeCell(x, y float64, occupied map[Position]bool) Position {
+	center := Position{X: int(math.Round(x)), Y: int(math.Round(y))}
+	if !occupied[center] {
+		return center
+	}
+
+	for r := 1; r <= forceSnapMaxRange; r++ {
+		best, bestDist := Position{}, math.Inf(1)
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if maxInt(absInt(dx), absInt(dy)) != r {
+					continue
+				}
+
+				pos := Position{X: center.X + dx, Y: center.Y + dy}
+				if occupied[pos] {
+					continue
+				}
+				if dist := math.Hypot(float64(pos.X)-x, float64(pos.Y)-y); dist < bestDist {
+					best, bestDist = pos, dist
+				}
+			}
+		}
+		if !math.IsInf(bestDist, 1) {
+			return best
+		}
+	}
+
+	return center
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+
+	return a
+}
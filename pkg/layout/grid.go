@@ -0,0 +1,214 @@
+package layout
+
+import (
+	"sort"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+const (
+	// MethodCompass defines the grid built by following the roads compass directions.
+	MethodCompass = "compass"
+	// MethodForce defines the grid built by the force-directed placement.
+	MethodForce = "force"
+)
+
+type (
+	// Position keeps a City grid coordinates (column and row, non-negative).
+	Position struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+
+	// Grid keeps Cities placed onto a grid using their compass connections.
+	Grid struct {
+		Positions map[string]Position `json:"positions"` // key: CityID
+		Cols      int                 `json:"cols"`
+		Rows      int                 `json:"rows"`
+		Method    string              `json:"method"`
+		Conflicts []Conflict          `json:"conflicts,omitempty"`
+	}
+
+	// compassDir defines a road direction grid offset.
+	compassDir struct {
+		dx, dy int
+		road   func(c model.City) string
+	}
+)
+
+// compassDirs defines all road directions (north, east, south, west).
+var compassDirs = []compassDir{
+	{dx: 0, dy: -1, road: func(c model.City) string { return c.NorthRoad }},
+	{dx: 1, dy: 0, road: func(c model.City) string { return c.EastRoad }},
+	{dx: 0, dy: 1, road: func(c model.City) string { return c.SouthRoad }},
+	{dx: -1, dy: 0, road: func(c model.City) string { return c.WestRoad }},
+}
+
+// New builds the Cities grid layout used by renderers.
+// The compass placement is used if it has no position conflicts, the force-directed one otherwise.
+func New(cityMap model.CityMap) Grid {
+	g := NewGrid(cityMap)
+	if !g.HasPositionConflicts() {
+		return g
+	}
+
+	return NewForceGrid(cityMap, g)
+}
+
+// NewGrid places all cities onto a grid following the roads compass directions (relative to other cities).
+// Placement is deterministic: every connected group of cities starts from its first City by name.
+// Groups are placed from left to right (biggest first), isolated cities are stacked into trailing columns.
+// Cities that can't be placed consistently are reported via Conflicts.
+func NewGrid(cityMap model.CityMap) Grid {
+	g := Grid{
+		Positions: make(map[string]Position, len(cityMap)),
+		Method:    MethodCompass,
+	}
+	if len(cityMap) == 0 {
+		return g
+	}
+
+	// Place every connected group separately
+	var groups []map[string]Position
+	placed := make(map[string]bool, len(cityMap))
+	for _, cityID := range sortedCityIDs(cityMap) {
+		if placed[cityID] {
+			continue
+		}
+		groups = append(groups, placeGroup(cityMap, cityID, placed))
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i]) > len(groups[j])
+	})
+
+	// Groups from left to right
+	xOffset, isolated := 0, make([]string, 0)
+	for _, group := range groups {
+		if len(group) == 1 {
+			for cityID := range group {
+				isolated = append(isolated, cityID)
+			}
+			continue
+		}
+
+		cols, rows := 0, 0
+		for cityID, pos := range group {
+			g.Positions[cityID] = Position{X: pos.X + xOffset, Y: pos.Y}
+			cols, rows = maxInt(cols, pos.X+1), maxInt(rows, pos.Y+1)
+		}
+		xOffset += cols
+		g.Rows = maxInt(g.Rows, rows)
+	}
+
+	// Isolated cities: trailing columns with the height of the tallest group
+	sort.Strings(isolated)
+	colHeight := maxInt(g.Rows, 1)
+	for i, cityID := range isolated {
+		g.Positions[cityID] = Position{X: xOffset + i/colHeight, Y: i % colHeight}
+	}
+
+	g.resize()
+	g.Conflicts = detectConflicts(cityMap, g.Positions)
+
+	return g
+}
+
+// HasPositionConflicts checks if some cities share the same grid position.
+func (g Grid) HasPositionConflicts() bool {
+	for _, c := range g.Conflicts {
+		if c.Type == ConflictPosition {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resize shifts positions to remove negative coordinates and updates the grid size.
+func (g *Grid) resize() {
+	if len(g.Positions) == 0 {
+		g.Cols, g.Rows = 0, 0
+		return
+	}
+
+	normalize(g.Positions)
+
+	g.Cols, g.Rows = 0, 0
+	for _, pos := range g.Positions {
+		g.Cols, g.Rows = maxInt(g.Cols, pos.X+1), maxInt(g.Rows, pos.Y+1)
+	}
+}
+
+// placeGroup places all cities reachable from the root City (roads are followed in the compass order).
+// A City is placed once: the first road leading to it defines its position.
+func placeGroup(cityMap model.CityMap, rootID string, placed map[string]bool) map[string]Position {
+	group := map[string]Position{rootID: {}}
+	placed[rootID] = true
+
+	// Depth-first traversal (explicit stack to avoid stack overflow on big maps)
+	stack := []string{rootID}
+	for len(stack) > 0 {
+		cityID := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		pos, city := group[cityID], cityMap[cityID]
+		for i := len(compassDirs) - 1; i >= 0; i-- {
+			dir := compassDirs[i]
+
+			neighbourID := dir.road(city)
+			if neighbourID == "" || placed[neighbourID] {
+				continue
+			}
+			if _, ok := cityMap[neighbourID]; !ok {
+				continue
+			}
+
+			group[neighbourID] = Position{X: pos.X + dir.dx, Y: pos.Y + dir.dy}
+			placed[neighbourID] = true
+			stack = append(stack, neighbourID)
+		}
+	}
+
+	normalize(group)
+
+	return group
+}
+
+// normalize shifts positions to make the minimal ones zero.
+func normalize(positions map[string]Position) {
+	first := true
+	xMin, yMin := 0, 0
+	for _, pos := range positions {
+		if first || pos.X < xMin {
+			xMin = pos.X
+		}
+		if first || pos.Y < yMin {
+			yMin = pos.Y
+		}
+		first = false
+	}
+
+	for cityID, pos := range positions {
+		positions[cityID] = Position{X: pos.X - xMin, Y: pos.Y - yMin}
+	}
+}
+
+// sortedCityIDs returns all CityIDs sorted by name.
+func sortedCityIDs(cityMap model.CityMap) []string {
+	cityIDs := make([]string, 0, len(cityMap))
+	for cityID := range cityMap {
+		cityIDs = append(cityIDs, cityID)
+	}
+	sort.Strings(cityIDs)
+
+	return cityIDs
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,328 @@
+package layout
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+// newTestCityMap parses the .aimap definition lines.
+func newTestCityMap(t *testing.T, lines ...string) model.CityMap {
+	t.Helper()
+
+	cityMap, err := model.NewCityMapFromReader(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("parsing city map: %v", err)
+	}
+
+	return cityMap
+}
+
+func TestNewGrid(t *testing.T) {
+	type testCase struct {
+		name              string
+		lines             []string
+		expectedPositions map[string]Position
+		expectedCols      int
+		expectedRows      int
+		expectedConflicts []Conflict
+	}
+
+	testCases := []testCase{
+		{
+			name:              "Empty map",
+			expectedPositions: map[string]Position{},
+		},
+		{
+			name: "Line",
+			lines: []string{
+				"A east=B",
+				"B west=A east=C",
+				"C west=B",
+			},
+			expectedPositions: map[string]Position{"A": {0, 0}, "B": {1, 0}, "C": {2, 0}},
+			expectedCols:      3,
+			expectedRows:      1,
+		},
+		{
+			name: "Negative coordinates are shifted",
+			lines: []string{
+				"A north=B west=C",
+				"B south=A",
+				"C east=A",
+			},
+			expectedPositions: map[string]Position{"A": {1, 1}, "B": {1, 0}, "C": {0, 1}},
+			expectedCols:      2,
+			expectedRows:      2,
+		},
+		{
+			name: "Closed square",
+			lines: []string{
+				"A east=B south=D",
+				"B west=A south=C",
+				"C north=B west=D",
+				"D north=A east=C",
+			},
+			expectedPositions: map[string]Position{"A": {0, 0}, "B": {1, 0}, "C": {1, 1}, "D": {0, 1}},
+			expectedCols:      2,
+			expectedRows:      2,
+		},
+		{
+			name: "Groups: biggest first, isolated cities trail",
+			lines: []string{
+				"A east=B",
+				"B west=A",
+				"P south=Q",
+				"Q north=P south=R",
+				"R north=Q",
+				"X",
+				"Y",
+				"Z",
+			},
+			expectedPositions: map[string]Position{
+				"P": {0, 0}, "Q": {0, 1}, "R": {0, 2},
+				"A": {1, 0}, "B": {2, 0},
+				"X": {3, 0}, "Y": {3, 1}, "Z": {3, 2},
+			},
+			expectedCols: 4,
+			expectedRows: 3,
+		},
+		{
+			name: "Isolated cities only",
+			lines: []string{
+				"B",
+				"A",
+			},
+			expectedPositions: map[string]Position{"A": {0, 0}, "B": {1, 0}},
+			expectedCols:      2,
+			expectedRows:      1,
+		},
+		{
+			name: "Roads to unknown cities are ignored",
+			lines: []string{
+				"A east=B north=Ghost",
+				"B west=A",
+			},
+			expectedPositions: map[string]Position{"A": {0, 0}, "B": {1, 0}},
+			expectedCols:      2,
+			expectedRows:      1,
+		},
+		{
+			name: "Conflict: shared position",
+			lines: []string{
+				"A east=B south=C",
+				"B west=A south=D",
+				"C north=A east=E",
+				"D north=B",
+				"E west=C",
+			},
+			expectedPositions: map[string]Position{"A": {0, 0}, "B": {1, 0}, "C": {0, 1}, "D": {1, 1}, "E": {1, 1}},
+			expectedCols:      2,
+			expectedRows:      2,
+			expectedConflicts: []Conflict{
+				{Type: ConflictPosition, CityIDs: []string{"D", "E"}, Position: Position{1, 1}},
+			},
+		},
+		{
+			name: "Conflict: roads not leading to the adjacent position",
+			lines: []string{
+				"A east=B south=E",
+				"B west=A east=C",
+				"C west=B south=D",
+				"D north=C west=E",
+				"E north=A east=D",
+			},
+			expectedPositions: map[string]Position{"A": {0, 0}, "B": {1, 0}, "C": {2, 0}, "D": {2, 1}, "E": {0, 1}},
+			expectedCols:      3,
+			expectedRows:      2,
+			expectedConflicts: []Conflict{
+				{Type: ConflictRoad, CityIDs: []string{"D", "E"}, Position: Position{2, 1}},
+				{Type: ConflictRoad, CityIDs: []string{"E", "D"}, Position: Position{0, 1}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGrid(newTestCityMap(t, tc.lines...))
+
+			if g.Method != MethodCompass {
+				t.Errorf("method: got %s, expected %s", g.Method, MethodCompass)
+			}
+			if !reflect.DeepEqual(g.Positions, tc.expectedPositions) {
+				t.Errorf("positions: got %v, expected %v", g.Positions, tc.expectedPositions)
+			}
+			if g.Cols != tc.expectedCols || g.Rows != tc.expectedRows {
+				t.Errorf("size: got %dx%d, expected %dx%d", g.Cols, g.Rows, tc.expectedCols, tc.expectedRows)
+			}
+			if !reflect.DeepEqual(g.Conflicts, tc.expectedConflicts) {
+				t.Errorf("conflicts: got %v, expected %v", g.Conflicts, tc.expectedConflicts)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	type testCase struct {
+		name           string
+		lines          []string
+		expectedMethod string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Compass: no conflicts",
+			lines: []string{
+				"A east=B",
+				"B west=A",
+			},
+			expectedMethod: MethodCompass,
+		},
+		{
+			name: "Compass: road conflicts only",
+			lines: []string{
+				"A east=B south=E",
+				"B west=A east=C",
+				"C west=B south=D",
+				"D north=C west=E",
+				"E north=A east=D",
+			},
+			expectedMethod: MethodCompass,
+		},
+		{
+			name: "Force: shared position",
+			lines: []string{
+				"A east=B south=C",
+				"B west=A south=D",
+				"C north=A east=E",
+				"D north=B",
+				"E west=C",
+			},
+			expectedMethod: MethodForce,
+		},
+		{
+			name: "Force: a path folded onto itself with an isolated city",
+			lines: []string{
+				"A east=B",
+				"B west=A south=C",
+				"C north=B west=D",
+				"D east=C north=E",
+				"E south=D",
+				"G",
+			},
+			expectedMethod: MethodForce,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cityMap := newTestCityMap(t, tc.lines...)
+			g := New(cityMap)
+
+			if g.Method != tc.expectedMethod {
+				t.Fatalf("method: got %s, expected %s", g.Method, tc.expectedMethod)
+			}
+			if g.HasPositionConflicts() {
+				t.Errorf("position conflicts: %v", g.Conflicts)
+			}
+
+			// Every City is placed within the grid
+			if len(g.Positions) != len(cityMap) {
+				t.Fatalf("positions: got %d, expected %d", len(g.Positions), len(cityMap))
+			}
+			for cityID, pos := range g.Positions {
+				if pos.X < 0 || pos.Y < 0 || pos.X >= g.Cols || pos.Y >= g.Rows {
+					t.Errorf("city %s: position (%d, %d) is out of the %dx%d grid", cityID, pos.X, pos.Y, g.Cols, g.Rows)
+				}
+			}
+
+			// Placement is deterministic
+			if g2 := New(cityMap); !reflect.DeepEqual(g, g2) {
+				t.Errorf("grid is not deterministic:\n  first:  %+v\n  second: %+v", g, g2)
+			}
+		})
+	}
+}
+
+func TestNearestFreeCell(t *testing.T) {
+	type testCase struct {
+		name     string
+		x, y     float64
+		occupied []Position
+		expected Position
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Free: rounded point",
+			x:        1.4,
+			y:        2.6,
+			expected: Position{1, 3},
+		},
+		{
+			name:     "Occupied: closest ring cell",
+			x:        1.4,
+			y:        1.0,
+			occupied: []Position{{1, 1}},
+			expected: Position{2, 1},
+		},
+		{
+			name:     "Occupied: negative cells allowed",
+			x:        0.0,
+			y:        -0.3,
+			occupied: []Position{{0, 0}},
+			expected: Position{0, -1},
+		},
+		{
+			name:     "Occupied ring: next ring",
+			x:        0.0,
+			y:        0.0,
+			occupied: []Position{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {0, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}},
+			expected: Position{0, -2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			occupied := make(map[Position]bool, len(tc.occupied))
+			for _, pos := range tc.occupied {
+				occupied[pos] = true
+			}
+
+			if pos := nearestFreeCell(tc.x, tc.y, occupied); pos != tc.expected {
+				t.Errorf("cell: got %v, expected %v", pos, tc.expected)
+			}
+		})
+	}
+}
+
+func TestConflictString(t *testing.T) {
+	type testCase struct {
+		name     string
+		conflict Conflict
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Position",
+			conflict: Conflict{Type: ConflictPosition, CityIDs: []string{"D", "E"}, Position: Position{1, 1}},
+			expected: "cities [D E] share the (1, 1) position",
+		},
+		{
+			name:     "Road",
+			conflict: Conflict{Type: ConflictRoad, CityIDs: []string{"D", "E"}, Position: Position{2, 1}},
+			expected: "road D -> E doesn't lead to the adjacent position",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if str := tc.conflict.String(); str != tc.expected {
+				t.Errorf("string: got %q, expected %q", str, tc.expected)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"io"
 	"os"
 
 	"github.com/rs/zerolog"
@@ -16,6 +17,20 @@ func WithLogLevel(level zerolog.Level) LoggerOption {
 	}
 }
 
+// WithOutput sets log output writer (stdout by default).
+func WithOutput(w io.Writer) LoggerOption {
+	return func(logger zerolog.Logger) zerolog.Logger {
+		return logger.Output(zerolog.ConsoleWriter{Out: w})
+	}
+}
+
+// WithPlainOutput sets log output writer with colors disabled (for writers that render logs on their own).
+func WithPlainOutput(w io.Writer) LoggerOption {
+	return func(logger zerolog.Logger) zerolog.Logger {
+		return logger.Output(zerolog.ConsoleWriter{Out: w, NoColor: true})
+	}
+}
+
 // NewLogger creates a new customizable logger.
 func NewLogger(opts ...LoggerOption) zerolog.Logger {
 	logger := zerolog.New(os.Stdout).
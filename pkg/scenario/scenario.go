@@ -0,0 +1,197 @@
+package scenario
+
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/config"
+	"github.com/spf13/viper"
+)
+
+const (
+	scenarioPrefix = "scenario."
+
+	KeyName      = scenarioPrefix + "name"      // Scenario name [string]
+	KeySeed      = scenarioPrefix + "seed"      // Random source seed (time based if not set) [int]
+	KeyMap       = scenarioPrefix + "map"       // Map file path (relative to the scenario file) [string]
+	KeyMapInline = scenarioPrefix + "mapInline" // Inline map definition (used instead of the map file) [string]
+	KeyAliens    = scenarioPrefix + "aliens"    // Number of Aliens to generate using the alien. config keys [uint]
+	KeyRoster    = scenarioPrefix + "roster"    // Explicit Aliens list (used instead of generation) [array of tables]
+
+	defAliensCount = 25
+)
+
+type (
+	// Scenario keeps everything needed to reproduce a simulation run.
+	Scenario struct {
+		// Scenario name (optional)
+		Name string
+
+		// Random source seed
+		Seed int64
+
+		// Simulation inputs
+		CityMap model.CityMap
+		Aliens  []model.Alien
+		Config  config.SimConfig
+	}
+
+	// rosterEntry defines a single Alien definition within the scenario roster.
+	rosterEntry struct {
+		Name     string        `mapstructure:"name"`
+		Power    uint          `mapstructure:"power"`
+		Speed    time.Duration `mapstructure:"speed"`    // alien.stepMinDuration config key value if not set
+		MaxSteps uint          `mapstructure:"maxSteps"` // alien.maxSteps config key value if not set
+	}
+)
+
+// LoadFile reads a scenario file (TOML) and builds a Scenario.
+// File is self-contained: default config values are used for missing keys (ENVs are ignored).
+// Format (app / city / alien sections are the same as for the config file):
+//   [scenario]
+//     name = "Two cities"
+//     seed = 42
+//     map = "map_28.aimap"   # or inline: mapInline = """..."""
+//     aliens = 25             # or an explicit roster:
+//     [[scenario.roster]]
+//       name = "#00000000"
+//       power = 5
+//       speed = "750ms"
+//       maxSteps = 25
+//   [app]
+//     simStopConditions = "lastAlien or wallTime(5m)"
+func LoadFile(filePath string) (Scenario, error) {
+	v := viper.New()
+	config.SetDefaults(v)
+
+	v.SetConfigFile(filePath)
+	v.SetConfigType("toml")
+	if err := v.ReadInConfig(); err != nil {
+		return Scenario{}, fmt.Errorf("reading scenario file: %w", err)
+	}
+
+	return NewFromViper(v, filepath.Dir(filePath))
+}
+
+// NewFromViper builds a Scenario from Viper values.
+// {baseDir} is used to resolve a relative map file path.
+func NewFromViper(v *viper.Viper, baseDir string) (Scenario, error) {
+	s := Scenario{
+		Name: v.GetString(KeyName),
+		Seed: time.Now().UnixNano(),
+	}
+	if v.IsSet(KeySeed) {
+		s.Seed = v.GetInt64(KeySeed)
+	}
+
+	// Config
+	cfg, err := config.NewSimConfig(v)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("building simulation config: %w", err)
+	}
+	s.Config = cfg
+
+	// Map
+	cityMap, err := buildCityMap(v, baseDir)
+	if err != nil {
+		return Scenario{}, err
+	}
+	if err := cityMap.Validate(); err != nil {
+		return Scenario{}, fmt.Errorf("validating map: %w", err)
+	}
+	s.CityMap = cityMap
+
+	// Aliens
+	aliens, err := buildAliens(v, s.Seed, cfg.Alien)
+	if err != nil {
+		return Scenario{}, err
+	}
+	s.Aliens = aliens
+
+	return s, nil
+}
+
+// buildCityMap parses the inline map definition or reads the map file.
+func buildCityMap(v *viper.Viper, baseDir string) (model.CityMap, error) {
+	if mapInline := v.GetString(KeyMapInline); mapInline != "" {
+		cityMap, err := model.NewCityMapFromReader(strings.NewReader(mapInline))
+		if err != nil {
+			return nil, fmt.Errorf("%s key: parsing map: %w", KeyMapInline, err)
+		}
+
+		return cityMap, nil
+	}
+
+	mapPath := v.GetString(KeyMap)
+	if mapPath == "" {
+		return nil, fmt.Errorf("%s / %s key: one must be set", KeyMap, KeyMapInline)
+	}
+	if !filepath.IsAbs(mapPath) {
+		mapPath = filepath.Join(baseDir, mapPath)
+	}
+
+	cityMap, err := model.NewCityMapFromFile(mapPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s key: parsing map file: %w", KeyMap, err)
+	}
+
+	return cityMap, nil
+}
+
+// buildAliens builds Aliens from the roster or generates them using the scenario seed.
+func buildAliens(v *viper.Viper, seed int64, cfg config.AlienConfig) ([]model.Alien, error) {
+	var roster []rosterEntry
+	if err := v.UnmarshalKey(KeyRoster, &roster); err != nil {
+		return nil, fmt.Errorf("%s key: decoding: %w", KeyRoster, err)
+	}
+
+	if len(roster) == 0 {
+		aliensCount := uint(defAliensCount)
+		if v.IsSet(KeyAliens) {
+			aliensCount = v.GetUint(KeyAliens)
+		}
+		if aliensCount == 0 {
+			return nil, fmt.Errorf("%s key: must be GT 0", KeyAliens)
+		}
+
+		rnd := rand.New(rand.NewSource(seed)) //nolint:gosec
+
+		return model.GenAliensFromConfig(rnd, cfg, aliensCount), nil
+	}
+
+	aliens := make([]model.Alien, 0, len(roster))
+	names := make(map[string]struct{}, len(roster))
+	for idx, entry := range roster {
+		if entry.Name == "" {
+			return nil, fmt.Errorf("%s key: entry (%d): name: empty", KeyRoster, idx)
+		}
+		if _, ok := names[entry.Name]; ok {
+			return nil, fmt.Errorf("%s key: entry (%d): name: duplicate (%s)", KeyRoster, idx, entry.Name)
+		}
+		names[entry.Name] = struct{}{}
+
+		alien := model.Alien{
+			Name:     entry.Name,
+			Power:    entry.Power,
+			Speed:    entry.Speed,
+			MaxSteps: entry.MaxSteps,
+		}
+		if alien.Speed == 0 {
+			alien.Speed = cfg.StepMinDur
+		}
+		if alien.Speed < 0 {
+			return nil, fmt.Errorf("%s key: entry (%d): speed: must be GT 0", KeyRoster, idx)
+		}
+		if alien.MaxSteps == 0 {
+			alien.MaxSteps = cfg.MaxSteps
+		}
+
+		aliens = append(aliens, alien)
+	}
+
+	return aliens, nil
+}
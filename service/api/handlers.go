@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+)
+
+// Max request body size (maps are small).
+const maxBodySize = 1 << 20
+
+var errTooManySims = errors.New("too many running simulations")
+
+// router builds the API routes:
+//   * GET    /sims                - list simulations;
+//   * POST   /sims                - start a new simulation (CreateSimRequest);
+//   * GET    /sims/{id}           - live state snapshot;
+//   * DELETE /sims/{id}           - stop and remove a simulation;
+//   * POST   /sims/{id}/pause     - pause a simulation (control requests are applied asynchronously: 202);
+//   * POST   /sims/{id}/resume    - resume a paused simulation;
+//   * POST   /sims/{id}/stop      - stop a simulation;
+//   * GET    /sims/{id}/result    - final result (409 if the simulation is not stopped yet);
+//   * GET    /sims/{id}/events    - Server-Sent Events stream (snapshot first, then events);
+func (s *Server) router(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sims", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, s.listSims())
+		case http.MethodPost:
+			s.handleCreate(ctx, w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s: not allowed", r.Method))
+		}
+	})
+	mux.HandleFunc("/sims/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/sims/"), "/"), "/")
+		if len(parts) > 2 || parts[0] == "" {
+			writeError(w, http.StatusNotFound, fmt.Errorf("path %s: not found", r.URL.Path))
+			return
+		}
+
+		e, ok := s.getSim(parts[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("simulation (%s): not found", parts[0]))
+			return
+		}
+
+		action := ""
+		if len(parts) == 2 {
+			action = parts[1]
+		}
+		s.handleSim(w, r, e, action)
+	})
+
+	return mux
+}
+
+// handleCreate handles the new simulation request.
+func (s *Server) handleCreate(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	req := CreateSimRequest{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decoding request: %w", err))
+		return
+	}
+
+	e, err := s.createSim(ctx, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errTooManySims) {
+			status = http.StatusTooManyRequests
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, e.info(e.mirror.Snapshot()))
+}
+
+// handleSim handles a single simulation requests.
+func (s *Server) handleSim(w http.ResponseWriter, r *http.Request, e *simEntry, action string) {
+	allowMethod := func(method string) bool {
+		if r.Method != method {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s: not allowed", r.Method))
+			return false
+		}
+
+		return true
+	}
+
+	control := func(fn func() error) {
+		if !allowMethod(http.MethodPost) {
+			return
+		}
+		if e.isStopped() {
+			writeError(w, http.StatusConflict, fmt.Errorf("simulation (%s): stopped", e.id))
+			return
+		}
+		if err := fn(); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+
+		// Request is handled by the World asynchronously
+		writeJSON(w, http.StatusAccepted, e.info(e.mirror.Snapshot()))
+	}
+
+	switch action {
+	case "":
+		switch r.Method {
+		case http.MethodGet:
+			snapshot := e.mirror.Snapshot()
+			writeJSON(w, http.StatusOK, SimSnapshot{SimInfo: e.info(snapshot), Snapshot: snapshot})
+		case http.MethodDelete:
+			s.deleteSim(e.id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s: not allowed", r.Method))
+		}
+	case "pause":
+		control(e.processor.Pause)
+	case "resume":
+		control(e.processor.Resume)
+	case "stop":
+		control(func() error {
+			return e.processor.Stop("stopped via API")
+		})
+	case "result":
+		if !allowMethod(http.MethodGet) {
+			return
+		}
+
+		result, ok := e.processor.Result()
+		if !ok {
+			writeError(w, http.StatusConflict, fmt.Errorf("simulation (%s): not stopped yet", e.id))
+			return
+		}
+		writeJSON(w, http.StatusOK, NewSimResult(e.id, result))
+	case "events":
+		if !allowMethod(http.MethodGet) {
+			return
+		}
+		e.mirror.ServeEvents(w, r, func(snapshot mirror.Snapshot) interface{} {
+			return SimSnapshot{SimInfo: e.info(snapshot), Snapshot: snapshot}
+		})
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("action (%s): not found", action))
+	}
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
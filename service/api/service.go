@@ -0,0 +1,411 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/config"
+	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+	"github.com/itiky/alienInvasion/service/sim"
+	"github.com/rs/zerolog"
+)
+
+const serviceName = "APIServer"
+
+// stoppedSimsEvictRate defines how often stopped simulations are checked for eviction.
+const stoppedSimsEvictRate = 5 * time.Second
+
+type (
+	// Server serves the HTTP JSON API to launch, control and inspect concurrent simulations.
+	Server struct {
+		// Params
+		address     string
+		cfg         config.SimConfig // base config (overridden by a request)
+		aliensCount uint             // default number of Aliens
+		maxAliens   uint             // max number of Aliens per simulation
+		maxSims     int              // max number of running simulations
+		stoppedTTL  time.Duration    // stopped simulation retention period
+		maxStopped  int              // max number of stopped simulations kept
+
+		// State
+		simsLock sync.RWMutex
+		sims     map[string]*simEntry // key: SimID
+		simsSeq  uint64
+	}
+
+	// Option defines the New constructor options.
+	Option func(s *Server) error
+
+	// simEntry keeps a single simulation state.
+	simEntry struct {
+		id        string
+		createdAt time.Time
+		processor *sim.Processor
+		mirror    *mirror.Monitor
+		stopCh    chan struct{} // simulation stopped channel (close channel)
+		stoppedAt time.Time     // set once the simulation is stopped (guarded by the Server lock)
+		ctxCancel context.CancelFunc
+	}
+)
+
+// WithAddress overrides the default HTTP server listen address.
+func WithAddress(address string) Option {
+	return func(s *Server) error {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return fmt.Errorf("address (%s): invalid: %w", address, err)
+		}
+		s.address = address
+
+		return nil
+	}
+}
+
+// WithConfig overrides the default base SimConfig (requests override it).
+func WithConfig(cfg config.SimConfig) Option {
+	return func(s *Server) error {
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("validating config: %w", err)
+		}
+		s.cfg = cfg
+
+		return nil
+	}
+}
+
+// WithAliensCount overrides the default number of Aliens (used if a request doesn't set it).
+func WithAliensCount(n uint) Option {
+	return func(s *Server) error {
+		if n == 0 {
+			return fmt.Errorf("aliens count: must be GT 0")
+		}
+		s.aliensCount = n
+
+		return nil
+	}
+}
+
+// WithMaxAliens overrides the default max number of Aliens per simulation (requests exceeding it are rejected).
+func WithMaxAliens(n uint) Option {
+	return func(s *Server) error {
+		if n == 0 {
+			return fmt.Errorf("max aliens: must be GT 0")
+		}
+		s.maxAliens = n
+
+		return nil
+	}
+}
+
+// WithMaxSims overrides the default max number of running simulations.
+func WithMaxSims(n int) Option {
+	return func(s *Server) error {
+		if n <= 0 {
+			return fmt.Errorf("max sims: must be GT 0")
+		}
+		s.maxSims = n
+
+		return nil
+	}
+}
+
+// WithStoppedSimTTL overrides the default period a stopped simulation is kept for (its result is available).
+func WithStoppedSimTTL(ttl time.Duration) Option {
+	return func(s *Server) error {
+		if ttl <= 0 {
+			return fmt.Errorf("stopped sim TTL: must be GT 0")
+		}
+		s.stoppedTTL = ttl
+
+		return nil
+	}
+}
+
+// WithMaxStoppedSims overrides the default max number of stopped simulations kept (the oldest stopped are evicted first).
+func WithMaxStoppedSims(n int) Option {
+	return func(s *Server) error {
+		if n < 0 {
+			return fmt.Errorf("max stopped sims: must be GTE 0")
+		}
+		s.maxStopped = n
+
+		return nil
+	}
+}
+
+// New creates a new Server instance.
+func New(opts ...Option) (*Server, error) {
+	s := Server{
+		address:     "127.0.0.1:8080",
+		cfg:         config.DefaultSimConfig(),
+		aliensCount: 25,
+		maxAliens:   10000,
+		maxSims:     16,
+		stoppedTTL:  10 * time.Minute,
+		maxStopped:  64,
+		sims:        make(map[string]*simEntry),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.aliensCount > s.maxAliens {
+		return nil, fmt.Errorf("aliens count (%d): must be LTE max aliens (%d)", s.aliensCount, s.maxAliens)
+	}
+
+	return &s, nil
+}
+
+// Run serves the API until the {ctx} is canceled (all simulations are stopped on exit).
+func (s *Server) Run(ctx context.Context) error {
+	ctx, logger := logging.GetCtxLogger(ctx)
+	logger = logger.With().Str(logging.ServiceKey, serviceName).Logger()
+	ctx = logging.SetCtxLogger(ctx, logger)
+
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", s.address, err)
+	}
+
+	server := &http.Server{
+		Handler:           s.router(ctx),
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	serveErrCh := make(chan error, 1)
+	go func() {
+		serveErrCh <- server.Serve(listener)
+	}()
+	logger.Info().Msgf("Serving API on http://%s", listener.Addr())
+
+	evictTicker := time.NewTicker(stoppedSimsEvictRate)
+	defer evictTicker.Stop()
+
+	for working := true; working; {
+		select {
+		case err := <-serveErrCh:
+			s.stopAll()
+			return fmt.Errorf("serving: %w", err)
+		case <-evictTicker.C:
+			for _, id := range s.evictStoppedSims(time.Now()) {
+				logger.Info().Msgf("Simulation %s evicted (stopped)", id)
+			}
+		case <-ctx.Done():
+			working = false
+		}
+	}
+
+	// SSE streams are closed by sims stop
+	s.stopAll()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("shutting down: %w", err)
+	}
+
+	return nil
+}
+
+// createSim builds and starts a new simulation.
+func (s *Server) createSim(ctx context.Context, req CreateSimRequest) (*simEntry, error) {
+	// Inputs build
+	cityMap, err := model.NewCityMapFromReader(strings.NewReader(req.Map))
+	if err != nil {
+		return nil, fmt.Errorf("map: parsing: %w", err)
+	}
+
+	cfg, err := s.cfg.Override(req.Config)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	aliensCount := s.aliensCount
+	if req.Aliens > 0 {
+		if req.Aliens > s.maxAliens {
+			return nil, fmt.Errorf("aliens (%d): must be LTE %d", req.Aliens, s.maxAliens)
+		}
+		aliensCount = req.Aliens
+	}
+
+	seed := time.Now().UnixNano()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	aliens := model.GenAliensFromConfig(rand.New(rand.NewSource(seed)), cfg.Alien, aliensCount) //nolint:gosec
+
+	// Registration (ID is reserved before the start)
+	s.simsLock.Lock()
+	if s.runningSims() >= s.maxSims {
+		s.simsLock.Unlock()
+		return nil, errTooManySims
+	}
+	s.simsSeq++
+	e := &simEntry{
+		id:        strconv.FormatUint(s.simsSeq, 10),
+		createdAt: time.Now(),
+		mirror:    mirror.New(cityMap, aliens),
+	}
+
+	processor, err := sim.New(
+		sim.WithConfig(cfg),
+		sim.WithSeed(seed),
+		sim.WithCityMap(cityMap),
+		sim.WithAliens(aliens),
+		sim.WithMonitor(e.mirror),
+	)
+	if err != nil {
+		s.simsLock.Unlock()
+		return nil, fmt.Errorf("building simulation: %w", err)
+	}
+	e.processor = processor
+
+	// Simulation logs are too noisy for a number of concurrent sims
+	_, logger := logging.GetCtxLogger(ctx)
+	simCtx := logging.SetCtxLogger(context.Background(), logger.With().Str("simID", e.id).Logger().Level(zerolog.WarnLevel))
+	simCtx, e.ctxCancel = context.WithCancel(simCtx)
+	e.stopCh = processor.Start(simCtx)
+
+	s.sims[e.id] = e
+	s.simsLock.Unlock()
+
+	// Stop time is tracked for the eviction
+	go func() {
+		<-e.stopCh
+		s.simsLock.Lock()
+		e.stoppedAt = time.Now()
+		s.simsLock.Unlock()
+	}()
+
+	logger.Info().Msgf("Simulation %s started (seed: %d, cities: %d, aliens: %d)", e.id, seed, len(cityMap), len(aliens))
+
+	return e, nil
+}
+
+// getSim returns a registered simulation.
+func (s *Server) getSim(id string) (*simEntry, bool) {
+	s.simsLock.RLock()
+	defer s.simsLock.RUnlock()
+
+	e, ok := s.sims[id]
+
+	return e, ok
+}
+
+// listSims returns all registered simulations (sorted by creation).
+func (s *Server) listSims() []SimInfo {
+	s.simsLock.RLock()
+	defer s.simsLock.RUnlock()
+
+	infos := make([]SimInfo, 0, len(s.sims))
+	for _, e := range s.sims {
+		infos = append(infos, e.info(e.mirror.Snapshot()))
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].CreatedAt.Before(infos[j].CreatedAt) })
+
+	return infos
+}
+
+// deleteSim stops and unregisters a simulation.
+func (s *Server) deleteSim(id string) bool {
+	s.simsLock.Lock()
+	e, ok := s.sims[id]
+	delete(s.sims, id)
+	s.simsLock.Unlock()
+
+	if ok {
+		e.ctxCancel()
+		<-e.stopCh
+	}
+
+	return ok
+}
+
+// stopAll stops all simulations and waits for them.
+func (s *Server) stopAll() {
+	s.simsLock.RLock()
+	defer s.simsLock.RUnlock()
+
+	for _, e := range s.sims {
+		e.ctxCancel()
+		<-e.stopCh
+	}
+}
+
+// evictStoppedSims unregisters stopped simulations according to the retention policy and returns their IDs:
+//   * simulations stopped for more than the TTL are removed;
+//   * the oldest stopped simulations are removed if there are more than the max number of them;
+// Running simulations are never evicted.
+func (s *Server) evictStoppedSims(now time.Time) []string {
+	s.simsLock.Lock()
+	defer s.simsLock.Unlock()
+
+	stopped := make([]*simEntry, 0, len(s.sims))
+	for _, e := range s.sims {
+		if !e.stoppedAt.IsZero() {
+			stopped = append(stopped, e)
+		}
+	}
+	sort.Slice(stopped, func(i, j int) bool { return stopped[i].stoppedAt.Before(stopped[j].stoppedAt) })
+
+	var evictedIDs []string
+	for idx, e := range stopped {
+		if now.Sub(e.stoppedAt) < s.stoppedTTL && len(stopped)-idx <= s.maxStopped {
+			break
+		}
+
+		e.ctxCancel()
+		delete(s.sims, e.id)
+		evictedIDs = append(evictedIDs, e.id)
+	}
+
+	return evictedIDs
+}
+
+// runningSims returns the number of not stopped simulations.
+// Contract: caller holds the lock.
+func (s *Server) runningSims() int {
+	cnt := 0
+	for _, e := range s.sims {
+		if !e.isStopped() {
+			cnt++
+		}
+	}
+
+	return cnt
+}
+
+// isStopped checks if the simulation is stopped.
+func (e *simEntry) isStopped() bool {
+	select {
+	case <-e.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// info builds the simulation short info.
+func (e *simEntry) info(snapshot mirror.Snapshot) SimInfo {
+	return SimInfo{
+		ID:        e.id,
+		Seed:      e.processor.Seed(),
+		CreatedAt: e.createdAt,
+		Phase:     snapshot.Status.Phase,
+		Stopped:   e.isStopped(),
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+)
+
+type (
+	// CreateSimRequest defines a new simulation request.
+	CreateSimRequest struct {
+		// Map in the .aimap format
+		Map string `json:"map"`
+
+		// Number of Aliens to generate (optional, server default if not set; LTE the server max)
+		Aliens uint `json:"aliens"`
+
+		// Random source seed (optional, time based if not set)
+		Seed *int64 `json:"seed"`
+
+		// Simulation config overrides (key: config key, for example "alien.maxSteps")
+		Config map[string]string `json:"config"`
+	}
+
+	// SimInfo defines a simulation short info.
+	SimInfo struct {
+		ID        string         `json:"id"`
+		Seed      int64          `json:"seed"`
+		CreatedAt time.Time      `json:"createdAt"`
+		Phase     model.SimPhase `json:"phase"`
+		Stopped   bool           `json:"stopped"`
+	}
+
+	// SimSnapshot defines a simulation live state.
+	SimSnapshot struct {
+		SimInfo
+		mirror.Snapshot
+	}
+
+	// SimResult defines a simulation outcome (model.SimResult).
+	SimResult struct {
+		ID               string        `json:"id"`
+		Seed             int64         `json:"seed"`
+		Status           mirror.Status `json:"status"`
+		CitiesDestroyed  []string      `json:"citiesDestroyed"`
+		CitiesLeft       []string      `json:"citiesLeft"`
+		AliensTotal      int           `json:"aliensTotal"`
+		AliensNotLanded  int           `json:"aliensNotLanded"`
+		AliensEvacuated  int           `json:"aliensEvacuated"`
+		AliensDestroyed  int           `json:"aliensDestroyed"`
+		AliensSurvived   int           `json:"aliensSurvived"`
+		SurvivorAlienIDs []string      `json:"survivorAlienIds"`
+	}
+
+	// ErrorResponse defines an error response.
+	ErrorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+// NewSimResult converts model.SimResult.
+func NewSimResult(id string, r model.SimResult) SimResult {
+	return SimResult{
+		ID:               id,
+		Seed:             r.Seed,
+		Status:           mirror.NewStatus(r.Status),
+		CitiesDestroyed:  r.CitiesDestroyed,
+		CitiesLeft:       r.CitiesLeft,
+		AliensTotal:      r.AliensTotal,
+		AliensNotLanded:  r.AliensNotLanded,
+		AliensEvacuated:  r.AliensEvacuated,
+		AliensDestroyed:  r.AliensDestroyed,
+		AliensSurvived:   r.AliensSurvived,
+		SurvivorAlienIDs: r.SurvivorAlienIDs,
+	}
+}
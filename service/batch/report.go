@@ -0,0 +1,317 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+// Report output formats.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+type (
+	// Report keeps the aggregated statistics of batch runs.
+	Report struct {
+		// Number of runs aggregated
+		Runs int `json:"runs"`
+
+		// City destruction probabilities (sorted by probability desc)
+		Cities []CityStats `json:"cities"`
+
+		// Time until the simulation stops (simulated time)
+		StopTime DurationStats `json:"stopTime"`
+
+		// Aliens outcome shares (relative to the overall number of Aliens)
+		Aliens AlienStats `json:"aliens"`
+
+		// Distribution of survivors count (sorted by survivors asc)
+		Survivors []SurvivorsBucket `json:"survivors"`
+
+		// Stop reasons distribution (key: reason)
+		StopReasons map[string]int `json:"stopReasons"`
+	}
+
+	// CityStats keeps a single City destruction stats.
+	CityStats struct {
+		Name        string  `json:"name"`
+		Destroyed   int     `json:"destroyed"`
+		Probability float64 `json:"probability"`
+	}
+
+	// DurationStats keeps a duration distribution stats.
+	DurationStats struct {
+		Mean time.Duration `json:"mean"`
+		Min  time.Duration `json:"min"`
+		P50  time.Duration `json:"p50"`
+		P90  time.Duration `json:"p90"`
+		P99  time.Duration `json:"p99"`
+		Max  time.Duration `json:"max"`
+	}
+
+	// AlienStats keeps Aliens outcome shares.
+	AlienStats struct {
+		Total          int     `json:"total"`
+		NotLandedShare float64 `json:"notLandedShare"`
+		EvacuatedShare float64 `json:"evacuatedShare"`
+		DestroyedShare float64 `json:"destroyedShare"`
+		SurvivedShare  float64 `json:"survivedShare"`
+	}
+
+	// SurvivorsBucket keeps the number of runs ended with a specific number of survivors.
+	SurvivorsBucket struct {
+		Survivors int     `json:"survivors"`
+		Runs      int     `json:"runs"`
+		Share     float64 `json:"share"`
+	}
+)
+
+// NewReport aggregates batch run results for the {cityMap}.
+func NewReport(cityMap model.CityMap, results []model.SimResult) Report {
+	r := Report{
+		Runs:        len(results),
+		StopReasons: make(map[string]int),
+	}
+	if len(results) == 0 {
+		return r
+	}
+	runsCnt := float64(len(results))
+
+	// Cities
+	cityDestroyed := make(map[string]int, len(cityMap))
+	for cityID := range cityMap {
+		cityDestroyed[cityID] = 0
+	}
+
+	// Aliens, survivors and stop time
+	survivors := make(map[int]int)
+	stopTimes := make([]time.Duration, 0, len(results))
+	var stopTimeSum time.Duration
+	var notLanded, evacuated, destroyed, survived int
+	for _, result := range results {
+		for _, cityID := range result.CitiesDestroyed {
+			cityDestroyed[cityID]++
+		}
+
+		r.Aliens.Total += result.AliensTotal
+		notLanded += result.AliensNotLanded
+		evacuated += result.AliensEvacuated
+		destroyed += result.AliensDestroyed
+		survived += result.AliensSurvived
+		survivors[result.AliensSurvived]++
+
+		stopTimes = append(stopTimes, result.Status.SimElapsed)
+		stopTimeSum += result.Status.SimElapsed
+
+		r.StopReasons[result.Status.StopReason]++
+	}
+
+	for cityID, cnt := range cityDestroyed {
+		r.Cities = append(r.Cities, CityStats{
+			Name:        cityID,
+			Destroyed:   cnt,
+			Probability: float64(cnt) / runsCnt,
+		})
+	}
+	sort.Slice(r.Cities, func(i, j int) bool {
+		if r.Cities[i].Probability != r.Cities[j].Probability {
+			return r.Cities[i].Probability > r.Cities[j].Probability
+		}
+		return r.Cities[i].Name < r.Cities[j].Name
+	})
+
+	if r.Aliens.Total > 0 {
+		aliensCnt := float64(r.Aliens.Total)
+		r.Aliens.NotLandedShare = float64(notLanded) / aliensCnt
+		r.Aliens.EvacuatedShare = float64(evacuated) / aliensCnt
+		r.Aliens.DestroyedShare = float64(destroyed) / aliensCnt
+		r.Aliens.SurvivedShare = float64(survived) / aliensCnt
+	}
+
+	for cnt, runs := range survivors {
+		r.Survivors = append(r.Survivors, SurvivorsBucket{
+			Survivors: cnt,
+			Runs:      runs,
+			Share:     float64(runs) / runsCnt,
+		})
+	}
+	sort.Slice(r.Survivors, func(i, j int) bool {
+		return r.Survivors[i].Survivors < r.Survivors[j].Survivors
+	})
+
+	sort.Slice(stopTimes, func(i, j int) bool { return stopTimes[i] < stopTimes[j] })
+	r.StopTime = DurationStats{
+		Mean: stopTimeSum / time.Duration(len(stopTimes)),
+		Min:  stopTimes[0],
+		P50:  percentile(stopTimes, 50),
+		P90:  percentile(stopTimes, 90),
+		P99:  percentile(stopTimes, 99),
+		Max:  stopTimes[len(stopTimes)-1],
+	}
+
+	return r
+}
+
+// ReadReportJSON reads a Report previously written in the JSON format.
+func ReadReportJSON(r io.Reader) (Report, error) {
+	var report Report
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return Report{}, fmt.Errorf("decoding JSON: %w", err)
+	}
+
+	return report, nil
+}
+
+// ValidateFormat checks if the Report output {format} is supported.
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatTable, FormatJSON, FormatCSV:
+		return nil
+	}
+
+	return fmt.Errorf("unknown format (%s): table / json / csv is expected", format)
+}
+
+// Write writes the Report in the specified format.
+func (r Report) Write(w io.Writer, format string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatJSON:
+		return r.writeJSON(w)
+	case FormatCSV:
+		return r.writeCSV(w)
+	default:
+		return r.writeTable(w)
+	}
+}
+
+// writeTable writes the Report as human-readable tables.
+func (r Report) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Runs:\t%d\n\n", r.Runs)
+
+	fmt.Fprintf(tw, "Stop time\tmean\tmin\tp50\tp90\tp99\tmax\n")
+	fmt.Fprintf(tw, "\t%v\t%v\t%v\t%v\t%v\t%v\n\n",
+		r.StopTime.Mean, r.StopTime.Min, r.StopTime.P50, r.StopTime.P90, r.StopTime.P99, r.StopTime.Max,
+	)
+
+	fmt.Fprintf(tw, "Aliens\ttotal\tnot landed\tevacuated\tdestroyed\tsurvived\n")
+	fmt.Fprintf(tw, "\t%d\t%s\t%s\t%s\t%s\n\n",
+		r.Aliens.Total,
+		formatPct(r.Aliens.NotLandedShare), formatPct(r.Aliens.EvacuatedShare),
+		formatPct(r.Aliens.DestroyedShare), formatPct(r.Aliens.SurvivedShare),
+	)
+
+	fmt.Fprintf(tw, "Survivors\truns\tshare\n")
+	for _, bucket := range r.Survivors {
+		fmt.Fprintf(tw, "%d\t%d\t%s\n", bucket.Survivors, bucket.Runs, formatPct(bucket.Share))
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintf(tw, "Stop reason\truns\n")
+	for _, reason := range r.sortedStopReasons() {
+		fmt.Fprintf(tw, "%s\t%d\n", reason, r.StopReasons[reason])
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintf(tw, "City\tdestroyed\tprobability\n")
+	for _, city := range r.Cities {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", city.Name, city.Destroyed, formatPct(city.Probability))
+	}
+
+	return tw.Flush()
+}
+
+// writeJSON writes the Report as an indented JSON object.
+func (r Report) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// writeCSV writes the Report as a flat "section, key, value, runs" table.
+// The "runs" column is set for rows the text report also counts runs for (survivors buckets and destroyed Cities).
+func (r Report) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	formatFloat := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', 4, 64)
+	}
+
+	rows := [][]string{
+		{"section", "key", "value", "runs"},
+		{"runs", "", strconv.Itoa(r.Runs), ""},
+		{"stopTime", "mean", r.StopTime.Mean.String(), ""},
+		{"stopTime", "min", r.StopTime.Min.String(), ""},
+		{"stopTime", "p50", r.StopTime.P50.String(), ""},
+		{"stopTime", "p90", r.StopTime.P90.String(), ""},
+		{"stopTime", "p99", r.StopTime.P99.String(), ""},
+		{"stopTime", "max", r.StopTime.Max.String(), ""},
+		{"aliens", "total", strconv.Itoa(r.Aliens.Total), ""},
+		{"aliens", "notLandedShare", formatFloat(r.Aliens.NotLandedShare), ""},
+		{"aliens", "evacuatedShare", formatFloat(r.Aliens.EvacuatedShare), ""},
+		{"aliens", "destroyedShare", formatFloat(r.Aliens.DestroyedShare), ""},
+		{"aliens", "survivedShare", formatFloat(r.Aliens.SurvivedShare), ""},
+	}
+	for _, bucket := range r.Survivors {
+		rows = append(rows, []string{"survivors", strconv.Itoa(bucket.Survivors), formatFloat(bucket.Share), strconv.Itoa(bucket.Runs)})
+	}
+	for _, reason := range r.sortedStopReasons() {
+		rows = append(rows, []string{"stopReason", reason, strconv.Itoa(r.StopReasons[reason]), ""})
+	}
+	for _, city := range r.Cities {
+		rows = append(rows, []string{"cityDestroyed", city.Name, formatFloat(city.Probability), strconv.Itoa(city.Destroyed)})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+
+	return nil
+}
+
+// sortedStopReasons returns stop reasons sorted by the number of runs desc.
+func (r Report) sortedStopReasons() []string {
+	reasons := make([]string, 0, len(r.StopReasons))
+	for reason := range r.StopReasons {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if r.StopReasons[reasons[i]] != r.StopReasons[reasons[j]] {
+			return r.StopReasons[reasons[i]] > r.StopReasons[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	return reasons
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []time.Duration, pct int) time.Duration {
+	rank := (pct*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+// formatPct formats a share as a percentage.
+func formatPct(share float64) string {
+	return fmt.Sprintf("%.1f%%", share*100.0)
+}
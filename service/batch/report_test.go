@@ -0,0 +1,250 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+func TestNewReport(t *testing.T) {
+	type testCase struct {
+		name     string
+		results  []model.SimResult
+		expected Report
+	}
+
+	cityMap := model.CityMap{
+		"A": model.City{Name: "A", EastRoad: "B"},
+		"B": model.City{Name: "B", WestRoad: "A", EastRoad: "C"},
+		"C": model.City{Name: "C", WestRoad: "B"},
+	}
+
+	newResult := func(simElapsed time.Duration, reason string, survived int, citiesDestroyed ...string) model.SimResult {
+		return model.SimResult{
+			Status: model.SimStatus{
+				SimElapsed: simElapsed,
+				StopReason: reason,
+			},
+			CitiesDestroyed: citiesDestroyed,
+			AliensTotal:     4,
+			AliensNotLanded: 1,
+			AliensEvacuated: 1,
+			AliensDestroyed: 2 - survived,
+			AliensSurvived:  survived,
+		}
+	}
+
+	testCases := []testCase{
+		{
+			name: "No results",
+			expected: Report{
+				StopReasons: map[string]int{},
+			},
+		},
+		{
+			name: "Single run",
+			results: []model.SimResult{
+				newResult(10*time.Second, "aliens left: 0", 0, "B"),
+			},
+			expected: Report{
+				Runs: 1,
+				Cities: []CityStats{
+					{Name: "B", Destroyed: 1, Probability: 1.0},
+					{Name: "A", Destroyed: 0, Probability: 0.0},
+					{Name: "C", Destroyed: 0, Probability: 0.0},
+				},
+				StopTime: DurationStats{
+					Mean: 10 * time.Second,
+					Min:  10 * time.Second,
+					P50:  10 * time.Second,
+					P90:  10 * time.Second,
+					P99:  10 * time.Second,
+					Max:  10 * time.Second,
+				},
+				Aliens: AlienStats{
+					Total:          4,
+					NotLandedShare: 0.25,
+					EvacuatedShare: 0.25,
+					DestroyedShare: 0.5,
+					SurvivedShare:  0.0,
+				},
+				Survivors: []SurvivorsBucket{
+					{Survivors: 0, Runs: 1, Share: 1.0},
+				},
+				StopReasons: map[string]int{"aliens left: 0": 1},
+			},
+		},
+		{
+			name: "Multiple runs",
+			results: []model.SimResult{
+				newResult(40*time.Second, "aliens left: 1", 1, "A"),
+				newResult(10*time.Second, "aliens left: 0", 0, "A", "C"),
+				newResult(20*time.Second, "aliens left: 1", 1),
+				newResult(30*time.Second, "aliens left: 0", 0, "C", "A"),
+			},
+			expected: Report{
+				Runs: 4,
+				Cities: []CityStats{
+					{Name: "A", Destroyed: 3, Probability: 0.75},
+					{Name: "C", Destroyed: 2, Probability: 0.5},
+					{Name: "B", Destroyed: 0, Probability: 0.0},
+				},
+				StopTime: DurationStats{
+					Mean: 25 * time.Second,
+					Min:  10 * time.Second,
+					P50:  20 * time.Second,
+					P90:  40 * time.Second,
+					P99:  40 * time.Second,
+					Max:  40 * time.Second,
+				},
+				Aliens: AlienStats{
+					Total:          16,
+					NotLandedShare: 0.25,
+					EvacuatedShare: 0.25,
+					DestroyedShare: 0.375,
+					SurvivedShare:  0.125,
+				},
+				Survivors: []SurvivorsBucket{
+					{Survivors: 0, Runs: 2, Share: 0.5},
+					{Survivors: 1, Runs: 2, Share: 0.5},
+				},
+				StopReasons: map[string]int{"aliens left: 0": 2, "aliens left: 1": 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := NewReport(cityMap, tc.results)
+			if !reflect.DeepEqual(report, tc.expected) {
+				t.Errorf("report:\n  got:      %+v\n  expected: %+v", report, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	type testCase struct {
+		name        string
+		format      string
+		check       func(t *testing.T, out string)
+		errExpected bool
+	}
+
+	cityMap := model.CityMap{
+		"A": model.City{Name: "A", EastRoad: "B"},
+		"B": model.City{Name: "B", WestRoad: "A"},
+	}
+	results := []model.SimResult{
+		{
+			Status:          model.SimStatus{SimElapsed: time.Second, StopReason: "all cities destroyed"},
+			CitiesDestroyed: []string{"A", "B"},
+			AliensTotal:     2,
+			AliensDestroyed: 2,
+		},
+		{
+			Status:          model.SimStatus{SimElapsed: 3 * time.Second, StopReason: "aliens left: 1"},
+			CitiesDestroyed: []string{"B"},
+			AliensTotal:     2,
+			AliensSurvived:  1,
+			AliensDestroyed: 1,
+		},
+	}
+	report := NewReport(cityMap, results)
+
+	testCases := []testCase{
+		{
+			name:   "OK: table",
+			format: FormatTable,
+			check: func(t *testing.T, out string) {
+				for _, line := range []string{"Runs:", "Stop time", "Survivors", "Stop reason", "City"} {
+					if !strings.Contains(out, line) {
+						t.Errorf("section %q: not found", line)
+					}
+				}
+			},
+		},
+		{
+			name:   "OK: JSON round-trip",
+			format: FormatJSON,
+			check: func(t *testing.T, out string) {
+				readReport, err := ReadReportJSON(strings.NewReader(out))
+				if err != nil {
+					t.Fatalf("reading JSON: %v", err)
+				}
+				if !reflect.DeepEqual(readReport, report) {
+					t.Errorf("report:\n  got:      %+v\n  expected: %+v", readReport, report)
+				}
+			},
+		},
+		{
+			name:   "OK: CSV",
+			format: FormatCSV,
+			check: func(t *testing.T, out string) {
+				rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+				if err != nil {
+					t.Fatalf("reading CSV: %v", err)
+				}
+				expectedRows := map[string]bool{
+					"section,key,value,runs":       true,
+					"runs,,2,":                     true,
+					"stopTime,mean,2s,":            true,
+					"aliens,survivedShare,0.2500,": true,
+					"cityDestroyed,B,1.0000,2":     true,
+					"cityDestroyed,A,0.5000,1":     true,
+					"stopReason,aliens left: 1,1,": true,
+					"survivors,0,0.5000,1":         true,
+					"survivors,1,0.5000,1":         true,
+				}
+				for _, row := range rows {
+					delete(expectedRows, strings.Join(row, ","))
+				}
+				for row := range expectedRows {
+					t.Errorf("row %q: not found", row)
+				}
+			},
+		},
+		{
+			name:        "Fail: unknown format",
+			format:      "xml",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: format is case-sensitive",
+			format:      "JSON",
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := report.Write(&buf, tc.format)
+			if tc.errExpected {
+				if err == nil {
+					t.Fatalf("error expected")
+				}
+				if validateErr := ValidateFormat(tc.format); validateErr == nil {
+					t.Errorf("ValidateFormat: error expected")
+				}
+				if buf.Len() != 0 {
+					t.Errorf("output: must be empty on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := ValidateFormat(tc.format); err != nil {
+				t.Errorf("ValidateFormat: unexpected error: %v", err)
+			}
+
+			tc.check(t, buf.String())
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/config"
+	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/monitor/noop"
+	"github.com/itiky/alienInvasion/service/sim"
+	"github.com/rs/zerolog"
+)
+
+const serviceName = "BatchRunner"
+
+type (
+	// Runner runs a number of headless simulations of one map / config with different seeds.
+	Runner struct {
+		// Params
+		cfg         config.SimConfig
+		cityMap     model.CityMap
+		aliensCount uint  // number of Aliens generated for every run
+		runs        uint  // number of runs
+		parallel    uint  // number of runs executed in parallel
+		baseSeed    int64 // seed of the first run (incremented for the following ones)
+
+		// Progress callback
+		onRunDone func(idx int, result model.SimResult)
+	}
+
+	// Option defines the New constructor options.
+	Option func(r *Runner) error
+)
+
+// WithConfig is the Runner constructor option that overrides the default SimConfig param.
+func WithConfig(cfg config.SimConfig) Option {
+	return func(r *Runner) error {
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("validating config: %w", err)
+		}
+		r.cfg = cfg
+
+		return nil
+	}
+}
+
+// WithCityMap is the Runner constructor option that sets the CityMap param.
+func WithCityMap(cm model.CityMap) Option {
+	return func(r *Runner) error {
+		if err := cm.Validate(); err != nil {
+			return fmt.Errorf("validating city map: %w", err)
+		}
+		r.cityMap = cm
+
+		return nil
+	}
+}
+
+// WithAliensCount is the Runner constructor option that sets the number of Aliens generated for every run.
+func WithAliensCount(n uint) Option {
+	return func(r *Runner) error {
+		if n == 0 {
+			return fmt.Errorf("aliens count: must be GT 0")
+		}
+		r.aliensCount = n
+
+		return nil
+	}
+}
+
+// WithRuns is the Runner constructor option that sets the number of runs.
+func WithRuns(runs, parallel uint) Option {
+	return func(r *Runner) error {
+		if runs == 0 {
+			return fmt.Errorf("runs: must be GT 0")
+		}
+		if parallel == 0 {
+			return fmt.Errorf("parallel: must be GT 0")
+		}
+		r.runs, r.parallel = runs, parallel
+
+		return nil
+	}
+}
+
+// WithBaseSeed is the Runner constructor option that sets the first run seed (run N uses baseSeed + N).
+func WithBaseSeed(seed int64) Option {
+	return func(r *Runner) error {
+		r.baseSeed = seed
+
+		return nil
+	}
+}
+
+// WithProgress is the Runner constructor option that sets a callback triggered when a run is done.
+// Callback is called from different routines.
+func WithProgress(fn func(idx int, result model.SimResult)) Option {
+	return func(r *Runner) error {
+		r.onRunDone = fn
+
+		return nil
+	}
+}
+
+// New creates a new Runner instance.
+func New(opts ...Option) (*Runner, error) {
+	r := Runner{
+		cfg:         config.DefaultSimConfig(),
+		aliensCount: 25,
+		runs:        1,
+		parallel:    1,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&r); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(r.cityMap) == 0 {
+		return nil, fmt.Errorf("city map is not defined (empty)")
+	}
+
+	return &r, nil
+}
+
+// Run executes all runs and returns results ordered by run index.
+// Canceled {ctx} interrupts ongoing runs and skips pending ones (interrupted runs are not included).
+func (r *Runner) Run(ctx context.Context) ([]model.SimResult, error) {
+	// Simulation logs are too noisy for hundreds of runs
+	ctx, logger := logging.GetCtxLogger(ctx)
+	logger = logger.With().Str(logging.ServiceKey, serviceName).Logger()
+	simCtx := logging.SetCtxLogger(ctx, logger.Level(zerolog.WarnLevel))
+
+	results := make([]*model.SimResult, r.runs)
+	errs := make([]error, r.runs)
+
+	idxCh := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := uint(0); i < r.parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range idxCh {
+				result, err := r.runOne(simCtx, idx)
+				if err != nil {
+					errs[idx] = err
+					continue
+				}
+				if result == nil {
+					continue
+				}
+
+				results[idx] = result
+				if r.onRunDone != nil {
+					r.onRunDone(idx, *result)
+				}
+			}
+		}()
+	}
+
+	for idx := 0; idx < int(r.runs); idx++ {
+		if ctx.Err() != nil {
+			break
+		}
+		idxCh <- idx
+	}
+	close(idxCh)
+	wg.Wait()
+
+	resultsDone := make([]model.SimResult, 0, len(results))
+	for idx, result := range results {
+		if err := errs[idx]; err != nil {
+			return nil, fmt.Errorf("run (%d): %w", idx, err)
+		}
+		if result != nil {
+			resultsDone = append(resultsDone, *result)
+		}
+	}
+
+	return resultsDone, nil
+}
+
+// runOne executes a single run (nil result is returned if the run has been interrupted).
+func (r *Runner) runOne(ctx context.Context, idx int) (*model.SimResult, error) {
+	return RunSim(ctx, r.cfg, r.cityMap, r.aliensCount, r.baseSeed+int64(idx))
+}
+
+// RunSim executes a single headless simulation with Aliens generated using the {seed}.
+// Nil result is returned if the run has been interrupted.
+func RunSim(ctx context.Context, cfg config.SimConfig, cityMap model.CityMap, aliensCount uint, seed int64) (*model.SimResult, error) {
+	aliens := model.GenAliensFromConfig(rand.New(rand.NewSource(seed)), cfg.Alien, aliensCount) //nolint:gosec
+
+	simSvc, err := sim.New(
+		sim.WithConfig(cfg),
+		sim.WithSeed(seed),
+		sim.WithCityMap(cityMap),
+		sim.WithAliens(aliens),
+		sim.WithMonitor(noop.New()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("building simulation service: %w", err)
+	}
+
+	<-simSvc.Start(ctx)
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+
+	result, _ := simSvc.Result()
+
+	return &result, nil
+}
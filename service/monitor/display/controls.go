@@ -0,0 +1,297 @@
+package display
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/service/monitor/display/types"
+)
+
+// controlRequestsBufferSize defines the number of control requests waiting to be sent (extra input is dropped).
+const controlRequestsBufferSize = 16
+
+var _ ebiten.Game = (*game)(nil)
+
+type (
+	// Controller defines the simulation lifecycle controls driven by the window input.
+	Controller interface {
+		// Pause freezes a running simulation.
+		Pause() error
+
+		// Resume unfreezes a paused simulation.
+		Resume() error
+
+		// Step runs a paused simulation for a single step and pauses it again.
+		Step() error
+
+		// SetSpeed changes the simulation speed multiplier (1.0 is the real time).
+		SetSpeed(speed float64) error
+
+		// Restart stops the current simulation and starts a new one with a new seed.
+		Restart() error
+	}
+
+	// game wraps the Canvas handling the window keyboard / mouse input:
+	//   * Space: pause / resume;
+	//   * +, -: simulation speed up / down;
+	//   * N: single step (paused simulation);
+	//   * R: restart with a new seed;
+	//   * Q: quit;
+	//   * mouse wheel: zoom in / out;
+	//   * mouse drag, arrows: move the map view;
+	//   * mouse click: select an Alien or a City;
+	//   * F: follow the selected Alien;
+	//   * 0: fit the map to the window;
+	//   * Esc: clear the selection;
+	//   * mouse wheel over the events log, PgUp, PgDn: scroll the log;
+	//   * End: scroll the log to the latest messages;
+	//   * L: switch the log filter (all, sim, city, alien);
+	//   * H: switch the heatmap overlay (off, fights, aliens passed, destruction probability);
+	// Controls bar buttons do the same on the left mouse click.
+	// Controller calls are sent by a separate routine, so the rendering is never blocked.
+	game struct {
+		*types.Canvas
+
+		ctl        Controller        // nil if controls are disabled (only quit is handled)
+		speedIdx   int               // speedPresets index (accessed by the control requests routine only)
+		requestsCh chan func() error // control requests queue
+
+		dragging               bool // map view drag is in progress
+		dragMoved              bool // cursor has moved enough to be a drag (not a click)
+		dragX, dragY           int  // last cursor position
+		dragStartX, dragStartY int  // drag start cursor position
+	}
+)
+
+// logPageSize defines the number of events log messages scrolled by PgUp / PgDn.
+const logPageSize = 5
+
+// speedPresets defines the simulation speed multipliers switched by the +/- controls.
+var speedPresets = []float64{0.25, 0.5, 1.0, 2.0, 4.0, 8.0}
+
+// keyBindings defines the control IDs for keyboard keys.
+var keyBindings = map[ebiten.Key]string{
+	ebiten.KeySpace:          types.ControlPause,
+	ebiten.KeyN:              types.ControlStep,
+	ebiten.KeyMinus:          types.ControlSlower,
+	ebiten.KeyNumpadSubtract: types.ControlSlower,
+	ebiten.KeyEqual:          types.ControlFaster,
+	ebiten.KeyNumpadAdd:      types.ControlFaster,
+	ebiten.KeyR:              types.ControlRestart,
+	ebiten.KeyQ:              types.ControlQuit,
+}
+
+// newGame creates a new game instance.
+func newGame(canvas *types.Canvas, ctl Controller) *game {
+	return &game{
+		Canvas:     canvas,
+		ctl:        ctl,
+		speedIdx:   2, // 1.0
+		requestsCh: make(chan func() error, controlRequestsBufferSize),
+	}
+}
+
+// Update implements the ebiten.Game interface.
+func (g *game) Update() error {
+	if err := g.Canvas.Update(); err != nil {
+		return err
+	}
+
+	for _, controlID := range g.pollInput() {
+		if controlID == types.ControlQuit {
+			return types.ErrWindowClosed
+		}
+		g.handleControl(controlID)
+	}
+	g.handleViewInput()
+	g.handleLogInput()
+
+	return nil
+}
+
+// handleLogInput handles the events log input.
+func (g *game) handleLogInput() {
+	const (
+		wheelScrollStep = 1 // messages per wheel notch
+	)
+
+	x, y := ebiten.CursorPosition()
+	if _, dy := ebiten.Wheel(); dy != 0 && g.InLog(x, y) {
+		g.ScrollLog(int(math.Round(dy)) * wheelScrollStep)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyPageUp) {
+		g.ScrollLog(logPageSize)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyPageDown) {
+		g.ScrollLog(-logPageSize)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnd) {
+		g.ScrollLogToEnd()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		g.CycleLogFilter()
+	}
+}
+
+// handleViewInput handles the map view (camera) input.
+func (g *game) handleViewInput() {
+	const (
+		zoomStep      = 1.1 // zoom factor per wheel notch
+		keyPanStep    = 15  // screen pixels per tick
+		dragThreshold = 4   // screen pixels to tell a drag from a click
+	)
+
+	x, y := ebiten.CursorPosition()
+
+	// Zoom
+	if _, dy := ebiten.Wheel(); dy != 0 && g.InViewport(x, y) {
+		g.ZoomAt(x, y, math.Pow(zoomStep, dy))
+	}
+
+	// Drag / click
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		if g.InViewport(x, y) {
+			g.dragging, g.dragMoved = true, false
+			g.dragX, g.dragY, g.dragStartX, g.dragStartY = x, y, x, y
+		}
+	case g.dragging && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft):
+		if absInt(x-g.dragStartX)+absInt(y-g.dragStartY) > dragThreshold {
+			g.dragMoved = true
+		}
+		if g.dragMoved {
+			g.Pan(x-g.dragX, y-g.dragY)
+		}
+		g.dragX, g.dragY = x, y
+	case g.dragging:
+		g.dragging = false
+		if !g.dragMoved {
+			g.SelectAt(g.dragStartX, g.dragStartY)
+		}
+	}
+
+	// Keys
+	dx, dy := 0, 0
+	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+		dx += keyPanStep
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+		dx -= keyPanStep
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+		dy += keyPanStep
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+		dy -= keyPanStep
+	}
+	if dx != 0 || dy != 0 {
+		g.Pan(dx, dy)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.Key0) || inpututil.IsKeyJustPressed(ebiten.KeyNumpad0) {
+		g.FitToView()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) && !g.ToggleFollow() {
+		g.PrintMsg("Follow: select an Alien first")
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.ClearSelection()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyH) {
+		g.PrintMsg("Heatmap: " + g.CycleHeatmap())
+	}
+}
+
+// runControls sends queued control requests to the Controller until the queue is closed.
+func (g *game) runControls() {
+	for request := range g.requestsCh {
+		if err := request(); err != nil {
+			g.PrintMsg("Control failed: " + err.Error())
+		}
+	}
+}
+
+// pollInput returns control IDs triggered by keys pressed and buttons clicked since the last Update.
+func (g *game) pollInput() []string {
+	var controlIDs []string
+
+	for key, controlID := range keyBindings {
+		if inpututil.IsKeyJustPressed(key) {
+			controlIDs = append(controlIDs, controlID)
+		}
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if controlID, ok := g.ControlAt(ebiten.CursorPosition()); ok {
+			controlIDs = append(controlIDs, controlID)
+		}
+	}
+
+	return controlIDs
+}
+
+// handleControl queues a Controller request for the control ID.
+func (g *game) handleControl(controlID string) {
+	if g.ctl == nil {
+		return
+	}
+
+	var request func() error
+	switch controlID {
+	case types.ControlPause:
+		switch g.Phase() {
+		case model.SimPhasePaused:
+			request = g.ctl.Resume
+		case model.SimPhaseStopped:
+			return
+		default:
+			request = g.ctl.Pause
+		}
+	case types.ControlStep:
+		if g.Phase() != model.SimPhasePaused {
+			g.PrintMsg("Step: pause the simulation first")
+			return
+		}
+		request = g.ctl.Step
+	case types.ControlSlower, types.ControlFaster:
+		step := -1
+		if controlID == types.ControlFaster {
+			step = 1
+		}
+
+		// The preset index is switched only once the Controller has accepted the speed
+		request = func() error {
+			idx := g.speedIdx + step
+			if idx < 0 || idx >= len(speedPresets) {
+				return nil
+			}
+			if err := g.ctl.SetSpeed(speedPresets[idx]); err != nil {
+				return err
+			}
+			g.speedIdx = idx
+
+			return nil
+		}
+	case types.ControlRestart:
+		request = g.ctl.Restart
+	default:
+		return
+	}
+
+	select {
+	case g.requestsCh <- request:
+	default:
+		g.PrintMsg("Control skipped: too many requests")
+	}
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+
+	return a
+}
@@ -0,0 +1,205 @@
+package display
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/itiky/alienInvasion/service/monitor/display/types"
+)
+
+var _ ebiten.Game = (*editorGame)(nil)
+
+// editorDoubleClickTicks defines the max number of ticks between two clicks of a double click.
+const editorDoubleClickTicks = 20
+
+type (
+	// editorGame wraps the Canvas in the map editor mode handling the window keyboard / mouse input:
+	//   * mouse click on an empty grid cell: add a City;
+	//   * mouse drag from a City to its grid neighbour: add a two-way road;
+	//   * mouse right click: remove a City (with its roads) or a road;
+	//   * mouse double click on a City: rename (Enter: apply, Esc: cancel);
+	//   * S: save the map (a valid one only);
+	//   * A: move cities to the grid positions the saved map would be loaded with;
+	//   * Q: quit;
+	//   * mouse wheel: zoom in / out;
+	//   * mouse drag from an empty cell, arrows: move the map view;
+	//   * 0: fit the map to the window;
+	//   * mouse wheel over the events log, PgUp, PgDn, End: scroll the log;
+	// Keys are ignored while a City name is typed.
+	editorGame struct {
+		*types.Canvas
+
+		tick int // ticks counter (double click detection)
+
+		dragging               bool   // mouse drag is in progress
+		dragMoved              bool   // cursor has moved enough to be a drag (not a click)
+		dragX, dragY           int    // last cursor position
+		dragStartX, dragStartY int    // drag start cursor position
+		roadFromID             string // drag started on a City: a road is being created
+
+		lastClickTick   int    // last click tick
+		lastClickCityID string // last click City (empty if the click was not on a City)
+	}
+)
+
+// newEditorGame creates a new editorGame instance.
+func newEditorGame(canvas *types.Canvas) *editorGame {
+	return &editorGame{
+		Canvas: canvas,
+	}
+}
+
+// Update implements the ebiten.Game interface.
+func (g *editorGame) Update() error {
+	if err := g.Canvas.Update(); err != nil {
+		return err
+	}
+	g.tick++
+
+	if g.EditorRenaming() {
+		g.handleRenameInput()
+	} else {
+		if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+			return types.ErrWindowClosed
+		}
+		g.handleEditorKeys()
+	}
+	g.handleEditorMouse()
+
+	x, y := ebiten.CursorPosition()
+	g.EditorSetPointer(x, y, g.roadFromID)
+
+	return nil
+}
+
+// handleEditorKeys handles the editor keyboard input (rename is not in progress).
+func (g *editorGame) handleEditorKeys() {
+	const (
+		keyPanStep = 15 // screen pixels per tick
+	)
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		g.EditorSave()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyA) {
+		g.EditorApplyLayout()
+	}
+
+	dx, dy := 0, 0
+	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+		dx += keyPanStep
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+		dx -= keyPanStep
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+		dy += keyPanStep
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+		dy -= keyPanStep
+	}
+	if dx != 0 || dy != 0 {
+		g.Pan(dx, dy)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.Key0) || inpututil.IsKeyJustPressed(ebiten.KeyNumpad0) {
+		g.FitToView()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyPageUp) {
+		g.ScrollLog(logPageSize)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyPageDown) {
+		g.ScrollLog(-logPageSize)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnd) {
+		g.ScrollLogToEnd()
+	}
+}
+
+// handleRenameInput handles the City name typing.
+func (g *editorGame) handleRenameInput() {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		g.EditorCommitRename()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		g.EditorCancelRename()
+	default:
+		backspace := inpututil.IsKeyJustPressed(ebiten.KeyBackspace)
+		if chars := ebiten.AppendInputChars(nil); len(chars) > 0 || backspace {
+			g.EditorRenameInput(chars, backspace)
+		}
+	}
+}
+
+// handleEditorMouse handles the editor mouse input: clicks, drags, zoom and the events log scroll.
+func (g *editorGame) handleEditorMouse() {
+	const (
+		zoomStep        = 1.1 // zoom factor per wheel notch
+		wheelScrollStep = 1   // log messages per wheel notch
+		dragThreshold   = 4   // screen pixels to tell a drag from a click
+	)
+
+	x, y := ebiten.CursorPosition()
+
+	// Zoom / log scroll
+	if _, dy := ebiten.Wheel(); dy != 0 {
+		switch {
+		case g.InViewport(x, y):
+			g.ZoomAt(x, y, math.Pow(zoomStep, dy))
+		case g.InLog(x, y):
+			g.ScrollLog(int(math.Round(dy)) * wheelScrollStep)
+		}
+	}
+
+	// Remove
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) && g.InViewport(x, y) {
+		g.EditorDeleteAt(x, y)
+		return
+	}
+
+	// Drag / click
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		if g.InViewport(x, y) {
+			g.dragging, g.dragMoved = true, false
+			g.dragX, g.dragY, g.dragStartX, g.dragStartY = x, y, x, y
+			g.roadFromID, _ = g.EditorCityAt(x, y)
+		}
+	case g.dragging && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft):
+		if absInt(x-g.dragStartX)+absInt(y-g.dragStartY) > dragThreshold {
+			g.dragMoved = true
+		}
+		if g.dragMoved && g.roadFromID == "" {
+			g.Pan(x-g.dragX, y-g.dragY)
+		}
+		g.dragX, g.dragY = x, y
+	case g.dragging:
+		g.dragging = false
+		roadFromID := g.roadFromID
+		g.roadFromID = ""
+
+		switch {
+		case g.dragMoved && roadFromID != "":
+			g.EditorConnect(roadFromID, x, y)
+		case !g.dragMoved:
+			g.handleClick(g.dragStartX, g.dragStartY)
+		}
+	}
+}
+
+// handleClick adds a City on an empty cell click and starts a rename on a City double click.
+func (g *editorGame) handleClick(x, y int) {
+	cityID, onCity := g.EditorCityAt(x, y)
+	doubleClick := onCity && cityID == g.lastClickCityID && g.tick-g.lastClickTick <= editorDoubleClickTicks
+	g.lastClickTick, g.lastClickCityID = g.tick, cityID
+
+	switch {
+	case doubleClick:
+		g.lastClickCityID = ""
+		g.EditorStartRename(cityID)
+	case !onCity:
+		g.EditorAddCity(x, y)
+	}
+}
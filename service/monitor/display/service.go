@@ -8,6 +8,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/itiky/alienInvasion/model"
 	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/monitor/display/theme"
 	"github.com/itiky/alienInvasion/service/monitor/display/types"
 	"github.com/rs/zerolog"
 )
@@ -16,8 +17,14 @@ const serviceName = "CanvasMonitor"
 
 type (
 	// Monitor defines a service to visualize the simulation.
+	// World events are queued by the Canvas and applied on ebiten's routine, so the simulation never waits for rendering.
 	Monitor struct {
-		canvas *types.Canvas // Sprites storage
+		canvas       *types.Canvas      // Sprites storage
+		ctl          Controller         // simulation controls (optional)
+		cityMap      model.CityMap      // initial map (Reset)
+		destroyProbs map[string]float64 // heatmap City destruction probabilities (optional)
+		theme        *theme.Theme       // look params (optional)
+		editorPath   string             // map editor output file path (empty if the editor mode is disabled)
 
 		screenWidth, screenHeight int // Screen size
 	}
@@ -42,9 +49,59 @@ func WithScreenSize(width, height int) Option {
 	}
 }
 
+// WithController enables the window controls driving the simulation lifecycle.
+func WithController(ctl Controller) Option {
+	return func(m *Monitor) error {
+		if ctl == nil {
+			return fmt.Errorf("controller: nil")
+		}
+		m.ctl = ctl
+
+		return nil
+	}
+}
+
+// WithDestructionProbabilities enables the destruction probability heatmap mode (key: CityID, a batch report data).
+func WithDestructionProbabilities(probs map[string]float64) Option {
+	return func(m *Monitor) error {
+		if len(probs) == 0 {
+			return fmt.Errorf("destruction probabilities: empty")
+		}
+		m.destroyProbs = probs
+
+		return nil
+	}
+}
+
+// WithTheme overrides the default display theme (see the theme.Load function).
+func WithTheme(t theme.Theme) Option {
+	return func(m *Monitor) error {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("theme: %w", err)
+		}
+		m.theme = &t
+
+		return nil
+	}
+}
+
+// WithEditor enables the map editor mode: the map is edited within the window and saved to the .aimap file.
+// The editor mode is meant for a map without Aliens and the simulation (controls are not available).
+func WithEditor(filePath string) Option {
+	return func(m *Monitor) error {
+		if filePath == "" {
+			return fmt.Errorf("editor file path: empty")
+		}
+		m.editorPath = filePath
+
+		return nil
+	}
+}
+
 // New creates a new Monitor instance.
 func New(cityMap model.CityMap, aliens []model.Alien, opts ...Option) (*Monitor, error) {
 	m := Monitor{
+		cityMap:      cityMap,
 		screenWidth:  1000,
 		screenHeight: 800,
 	}
@@ -55,7 +112,24 @@ func New(cityMap model.CityMap, aliens []model.Alien, opts ...Option) (*Monitor,
 		}
 	}
 
-	canvas, err := types.NewCanvas(cityMap, aliens)
+	var canvasOpts []types.CanvasOption
+	if m.ctl != nil {
+		canvasOpts = append(canvasOpts, types.WithControls())
+	}
+	if m.theme != nil {
+		canvasOpts = append(canvasOpts, types.WithTheme(*m.theme))
+	}
+	if m.destroyProbs != nil {
+		canvasOpts = append(canvasOpts, types.WithDestructionProbabilities(m.destroyProbs))
+	}
+	if m.editorPath != "" {
+		if m.ctl != nil {
+			return nil, fmt.Errorf("editor: controller is not supported")
+		}
+		canvasOpts = append(canvasOpts, types.WithEditor(m.editorPath))
+	}
+
+	canvas, err := types.NewCanvas(cityMap, aliens, canvasOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("canvas build: %w", err)
 	}
@@ -68,12 +142,24 @@ func New(cityMap model.CityMap, aliens []model.Alien, opts ...Option) (*Monitor,
 // Uses the runtime.LockOSThread call and must be started from the main routine.
 // Contract: not canceled by the {ctx}, so used have to close the window.
 func (m *Monitor) Run(ctx context.Context) {
-	ebiten.SetWindowTitle("Alien invasion simulation")
-	ebiten.SetWindowSize(1024, 800)
+	ebiten.SetWindowSize(m.windowSize())
 	ebiten.SetWindowResizable(true)
 	ebiten.SetWindowClosingHandled(true)
 
-	if err := ebiten.RunGame(m.canvas); err != nil {
+	var g ebiten.Game
+	if m.canvas.EditorEnabled() {
+		ebiten.SetWindowTitle("Alien invasion map editor")
+		g = newEditorGame(m.canvas)
+	} else {
+		ebiten.SetWindowTitle("Alien invasion simulation")
+
+		simGame := newGame(m.canvas, m.ctl)
+		go simGame.runControls()
+		defer close(simGame.requestsCh)
+		g = simGame
+	}
+
+	if err := ebiten.RunGame(g); err != nil {
 		if errors.Is(err, types.ErrWindowClosed) {
 			return
 		}
@@ -81,6 +167,25 @@ func (m *Monitor) Run(ctx context.Context) {
 	}
 }
 
+// windowSize returns the initial window size: the one that fits the whole map limited by the screen size option.
+func (m *Monitor) windowSize() (int, int) {
+	width, height := m.canvas.Size()
+	if width > m.screenWidth {
+		width = m.screenWidth
+	}
+	if height > m.screenHeight {
+		height = m.screenHeight
+	}
+
+	return width, height
+}
+
+// Reset drops the current World state (initial map with new Aliens) for a restarted simulation.
+// Contract: the previous simulation is stopped, so no events are emitted for it anymore.
+func (m *Monitor) Reset(aliens []model.Alien) {
+	m.canvas.Reset(m.cityMap, aliens)
+}
+
 // log returns logger with object related fields set.
 func (m *Monitor) log(ctx context.Context) *zerolog.Logger {
 	_, logger := logging.GetCtxLogger(ctx)
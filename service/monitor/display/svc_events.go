@@ -1,6 +1,8 @@
 package display
 
 import (
+	"time"
+
 	"github.com/itiky/alienInvasion/model"
 	"github.com/itiky/alienInvasion/service/monitor"
 )
@@ -13,8 +15,23 @@ func (m *Monitor) CityUpdated(city model.City) {
 }
 
 // CityFightStarted implements the WorldEventsListener interface.
-func (m *Monitor) CityFightStarted(cityID string) {
-	m.canvas.SetCityOnFight(cityID)
+func (m *Monitor) CityFightStarted(cityID string, alienIDs []string, duration time.Duration) {
+	m.canvas.SetCityOnFight(cityID, alienIDs, time.Now().Add(duration), false)
+}
+
+// CityFightProlonged implements the WorldEventsListener interface.
+func (m *Monitor) CityFightProlonged(cityID string, alienIDs []string, duration time.Duration) {
+	m.canvas.SetCityOnFight(cityID, alienIDs, time.Now().Add(duration), true)
+}
+
+// CityFightEnded implements the WorldEventsListener interface.
+func (m *Monitor) CityFightEnded(cityID string, _ []string, _ time.Duration) {
+	m.canvas.EndCityFight(cityID)
+}
+
+// CityFightDeadlineUpdated implements the WorldEventsListener interface.
+func (m *Monitor) CityFightDeadlineUpdated(cityID string, deadline time.Time) {
+	m.canvas.UpdateCityFightDeadline(cityID, deadline)
 }
 
 // CityDestroyed implements the WorldEventsListener interface.
@@ -22,19 +39,46 @@ func (m *Monitor) CityDestroyed(cityID string, alienIDs []string) {
 	m.canvas.DestroyCity(cityID, alienIDs)
 }
 
+// AlienLanded implements the WorldEventsListener interface.
+func (m *Monitor) AlienLanded(alienID, cityID string) {
+	m.canvas.RelocateAlien(alienID, cityID)
+}
+
+// AlienLandingFailed implements the WorldEventsListener interface.
+func (m *Monitor) AlienLandingFailed(alienID, cityID string) {
+	m.canvas.DestroyAlien(alienID, "landing failed: "+cityID+" not found")
+}
+
 // AlienRelocated implements the WorldEventsListener interface.
 func (m *Monitor) AlienRelocated(alienID, newCityID string) {
 	m.canvas.RelocateAlien(alienID, newCityID)
 }
 
+// AlienMoveRefused implements the WorldEventsListener interface.
+func (m *Monitor) AlienMoveRefused(alienID, cityID, targetCityID string) {
+	m.canvas.RefuseAlienMove(alienID, cityID, targetCityID)
+}
+
+// AlienTrapped implements the WorldEventsListener interface.
+func (m *Monitor) AlienTrapped(alienID, cityID string) {
+	m.canvas.TrapAlien(alienID, cityID)
+}
+
 // AlienDismissed implements the WorldEventsListener interface.
 func (m *Monitor) AlienDismissed(alienID, reason string) {
 	m.canvas.DestroyAlien(alienID, reason)
 }
 
+// SimPhaseChanged implements the WorldEventsListener interface.
+func (m *Monitor) SimPhaseChanged(phase model.SimPhase) {
+	m.canvas.SetPhase(phase)
+	m.canvas.PrintMsg("Simulation phase: " + string(phase))
+}
+
 // SimStatus implements the WorldEventsListener interface.
-func (m *Monitor) SimStatus(aliens, cities int, simStopped bool) {
-	if simStopped {
-		m.canvas.PrintMsg("Simulation stopped")
+func (m *Monitor) SimStatus(status model.SimStatus) {
+	m.canvas.UpdateStatus(status)
+	if status.Stopped {
+		m.canvas.PrintMsg("Simulation stopped: " + status.StopReason)
 	}
 }
@@ -0,0 +1,306 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itiky/alienInvasion/service/monitor/display/resource"
+)
+
+const (
+	// ManifestFileName defines the theme manifest file name within an asset directory.
+	ManifestFileName = "theme.json"
+
+	// embeddedPrefix defines the manifest file path prefix referring to an embedded resource.
+	embeddedPrefix = "embedded:"
+)
+
+// embeddedResources defines the built-in resources referred by the "embedded:{name}" manifest paths.
+var embeddedResources = map[string][]byte{
+	"Alien.png":            resource.AlienImage,
+	"Battle.png":           resource.BattleImage,
+	"City.png":             resource.CityImage,
+	"Planet.png":           resource.PlanetImage,
+	"Road.png":             resource.RoadImage,
+	"mplus-1p-regular.ttf": resource.DefaultFont,
+}
+
+type (
+	// Theme keeps the display look params: sprite images, font, sizes, colors and the animation speed.
+	Theme struct {
+		// Sprite images (PNG data), the background is optional (nil if not set)
+		CityImage       []byte
+		RoadImage       []byte
+		BattleImage     []byte
+		AlienImage      []byte
+		BackgroundImage []byte
+
+		// Font (TTF / OTF data) and its sizes
+		Font             []byte
+		CityNameFontSize int
+		PanelFontSize    int
+
+		// Sprite sizes
+		CitySize   int // City tile width / height
+		CityOffset int // gap between City tiles
+		RoadSize   int // road width / height
+		BattleSize int // Battle sprite width / height
+		AlienSize  int // Alien sprite width / height
+
+		// Aliens moving animation duration in ticks (used if an Alien speed is unknown, moves last for the Alien step duration otherwise)
+		AlienMoveSpeed int
+
+		// Colors
+		CityNameColor   color.Color // City names
+		TextColor       color.Color // status, HUD, controls and inspect panels text
+		PanelColor      color.Color // status / controls panel background
+		BackgroundColor color.Color // map view background (below the background image)
+	}
+
+	// manifest defines the theme.json file format.
+	// Empty values keep defaults, file paths are relative to the asset directory ("embedded:{name}" refers to a built-in resource).
+	manifest struct {
+		CityImage       string `json:"cityImage"`
+		RoadImage       string `json:"roadImage"`
+		BattleImage     string `json:"battleImage"`
+		AlienImage      string `json:"alienImage"`
+		BackgroundImage string `json:"backgroundImage"`
+
+		Font             string `json:"font"`
+		CityNameFontSize int    `json:"cityNameFontSize"`
+		PanelFontSize    int    `json:"panelFontSize"`
+
+		CitySize   int `json:"citySize"`
+		CityOffset int `json:"cityOffset"`
+		RoadSize   int `json:"roadSize"`
+		BattleSize int `json:"battleSize"`
+		AlienSize  int `json:"alienSize"`
+
+		AlienMoveSpeed int `json:"alienMoveSpeed"`
+
+		Colors struct {
+			CityName   string `json:"cityName"`
+			Text       string `json:"text"`
+			Panel      string `json:"panel"`
+			Background string `json:"background"`
+		} `json:"colors"`
+	}
+)
+
+// Default returns the built-in theme (embedded resources).
+func Default() Theme {
+	return Theme{
+		CityImage:   resource.CityImage,
+		RoadImage:   resource.RoadImage,
+		BattleImage: resource.BattleImage,
+		AlienImage:  resource.AlienImage,
+
+		Font:             resource.DefaultFont,
+		CityNameFontSize: 24,
+		PanelFontSize:    18,
+
+		CitySize:   100,
+		CityOffset: 50,
+		RoadSize:   50,
+		BattleSize: 100,
+		AlienSize:  50,
+
+		AlienMoveSpeed: 30,
+
+		CityNameColor:   color.White,
+		TextColor:       color.White,
+		PanelColor:      color.RGBA{R: 0x1A, G: 0x1A, B: 0x1F, A: 0xFF},
+		BackgroundColor: color.Black,
+	}
+}
+
+// Load reads the asset directory manifest and returns the Default theme with the manifest overrides applied.
+func Load(dirPath string) (Theme, error) {
+	manifestPath := filepath.Join(dirPath, ManifestFileName)
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return Theme{}, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Theme{}, fmt.Errorf("decoding manifest (%s): %w", manifestPath, err)
+	}
+
+	t := Default()
+	if err := t.apply(dirPath, m); err != nil {
+		return Theme{}, fmt.Errorf("manifest (%s): %w", manifestPath, err)
+	}
+
+	return t, nil
+}
+
+// Validate performs the Theme values validation.
+func (t Theme) Validate() error {
+	if len(t.CityImage) == 0 {
+		return fmt.Errorf("cityImage: empty")
+	}
+	if len(t.RoadImage) == 0 {
+		return fmt.Errorf("roadImage: empty")
+	}
+	if len(t.BattleImage) == 0 {
+		return fmt.Errorf("battleImage: empty")
+	}
+	if len(t.AlienImage) == 0 {
+		return fmt.Errorf("alienImage: empty")
+	}
+	if len(t.Font) == 0 {
+		return fmt.Errorf("font: empty")
+	}
+
+	if t.CityNameFontSize <= 0 {
+		return fmt.Errorf("cityNameFontSize: must be GT 0")
+	}
+	if t.PanelFontSize <= 0 {
+		return fmt.Errorf("panelFontSize: must be GT 0")
+	}
+	if t.CitySize <= 0 {
+		return fmt.Errorf("citySize: must be GT 0")
+	}
+	if t.CityOffset <= 0 {
+		return fmt.Errorf("cityOffset: must be GT 0")
+	}
+	if t.RoadSize <= 0 {
+		return fmt.Errorf("roadSize: must be GT 0")
+	}
+	if t.BattleSize <= 0 {
+		return fmt.Errorf("battleSize: must be GT 0")
+	}
+	if t.AlienSize <= 0 {
+		return fmt.Errorf("alienSize: must be GT 0")
+	}
+	if t.AlienMoveSpeed <= 0 {
+		return fmt.Errorf("alienMoveSpeed: must be GT 0")
+	}
+
+	if t.CityNameColor == nil || t.TextColor == nil || t.PanelColor == nil || t.BackgroundColor == nil {
+		return fmt.Errorf("colors: nil")
+	}
+
+	return nil
+}
+
+// apply overrides Theme values with non-empty manifest ones.
+func (t *Theme) apply(dirPath string, m manifest) error {
+	// Files
+	files := []struct {
+		name   string
+		path   string
+		target *[]byte
+	}{
+		{name: "cityImage", path: m.CityImage, target: &t.CityImage},
+		{name: "roadImage", path: m.RoadImage, target: &t.RoadImage},
+		{name: "battleImage", path: m.BattleImage, target: &t.BattleImage},
+		{name: "alienImage", path: m.AlienImage, target: &t.AlienImage},
+		{name: "backgroundImage", path: m.BackgroundImage, target: &t.BackgroundImage},
+		{name: "font", path: m.Font, target: &t.Font},
+	}
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+
+		data, err := readAsset(dirPath, file.path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file.name, err)
+		}
+		*file.target = data
+	}
+
+	// Sizes
+	sizes := []struct {
+		value  int
+		target *int
+	}{
+		{value: m.CityNameFontSize, target: &t.CityNameFontSize},
+		{value: m.PanelFontSize, target: &t.PanelFontSize},
+		{value: m.CitySize, target: &t.CitySize},
+		{value: m.CityOffset, target: &t.CityOffset},
+		{value: m.RoadSize, target: &t.RoadSize},
+		{value: m.BattleSize, target: &t.BattleSize},
+		{value: m.AlienSize, target: &t.AlienSize},
+		{value: m.AlienMoveSpeed, target: &t.AlienMoveSpeed},
+	}
+	for _, size := range sizes {
+		if size.value != 0 {
+			*size.target = size.value
+		}
+	}
+
+	// Colors
+	colors := []struct {
+		name   string
+		value  string
+		target *color.Color
+	}{
+		{name: "colors.cityName", value: m.Colors.CityName, target: &t.CityNameColor},
+		{name: "colors.text", value: m.Colors.Text, target: &t.TextColor},
+		{name: "colors.panel", value: m.Colors.Panel, target: &t.PanelColor},
+		{name: "colors.background", value: m.Colors.Background, target: &t.BackgroundColor},
+	}
+	for _, c := range colors {
+		if c.value == "" {
+			continue
+		}
+
+		value, err := ParseColor(c.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.name, err)
+		}
+		*c.target = value
+	}
+
+	return t.Validate()
+}
+
+// ParseColor parses a "#RRGGBB" or "#RRGGBBAA" hex color.
+func ParseColor(s string) (color.Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 && len(hex) != 8 {
+		return nil, fmt.Errorf("color (%s): #RRGGBB or #RRGGBBAA is expected", s)
+	}
+	if len(hex) == 6 {
+		hex += "FF"
+	}
+
+	var r, g, b, a uint8
+	if _, err := fmt.Sscanf(hex, "%02x%02x%02x%02x", &r, &g, &b, &a); err != nil {
+		return nil, fmt.Errorf("color (%s): %w", s, err)
+	}
+
+	return color.NRGBA{R: r, G: g, B: b, A: a}, nil
+}
+
+// readAsset reads an asset file relative to the directory or an embedded resource ("embedded:{name}" path).
+func readAsset(dirPath, assetPath string) ([]byte, error) {
+	if strings.HasPrefix(assetPath, embeddedPrefix) {
+		name := strings.TrimPrefix(assetPath, embeddedPrefix)
+		data, ok := embeddedResources[name]
+		if !ok {
+			return nil, fmt.Errorf("embedded resource (%s): not found", name)
+		}
+
+		return data, nil
+	}
+
+	if !filepath.IsAbs(assetPath) {
+		assetPath = filepath.Join(dirPath, assetPath)
+	}
+
+	data, err := os.ReadFile(assetPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,259 @@
+package theme
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/itiky/alienInvasion/service/monitor/display/resource"
+)
+
+func TestParseColor(t *testing.T) {
+	type testCase struct {
+		name        string
+		value       string
+		expected    color.Color
+		errExpected bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "OK: #RRGGBB is opaque",
+			value:    "#FFE082",
+			expected: color.NRGBA{R: 0xFF, G: 0xE0, B: 0x82, A: 0xFF},
+		},
+		{
+			name:     "OK: #RRGGBBAA",
+			value:    "#10182880",
+			expected: color.NRGBA{R: 0x10, G: 0x18, B: 0x28, A: 0x80},
+		},
+		{
+			name:     "OK: lower case without #",
+			value:    "05070d",
+			expected: color.NRGBA{R: 0x05, G: 0x07, B: 0x0D, A: 0xFF},
+		},
+		{
+			name:        "Fail: empty",
+			value:       "",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: short form",
+			value:       "#FFF",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: invalid length",
+			value:       "#FFE0821",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: non-hex digits",
+			value:       "#GGE082",
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := ParseColor(tc.value)
+			if tc.errExpected {
+				if err == nil {
+					t.Fatalf("error expected, got: %v", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if value != tc.expected {
+				t.Errorf("color: got %v, expected %v", value, tc.expected)
+			}
+		})
+	}
+}
+
+func TestThemeValidate(t *testing.T) {
+	type testCase struct {
+		name        string
+		modify      func(t *Theme)
+		errExpected bool
+	}
+
+	testCases := []testCase{
+		{
+			name:   "OK: default",
+			modify: func(t *Theme) {},
+		},
+		{
+			name:   "OK: background image set",
+			modify: func(t *Theme) { t.BackgroundImage = resource.PlanetImage },
+		},
+		{
+			name:        "Fail: empty sprite image",
+			modify:      func(t *Theme) { t.AlienImage = nil },
+			errExpected: true,
+		},
+		{
+			name:        "Fail: empty font",
+			modify:      func(t *Theme) { t.Font = []byte{} },
+			errExpected: true,
+		},
+		{
+			name:        "Fail: zero font size",
+			modify:      func(t *Theme) { t.PanelFontSize = 0 },
+			errExpected: true,
+		},
+		{
+			name:        "Fail: negative sprite size",
+			modify:      func(t *Theme) { t.CitySize = -1 },
+			errExpected: true,
+		},
+		{
+			name:        "Fail: zero alien move speed",
+			modify:      func(t *Theme) { t.AlienMoveSpeed = 0 },
+			errExpected: true,
+		},
+		{
+			name:        "Fail: nil color",
+			modify:      func(t *Theme) { t.BackgroundColor = nil },
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			th := Default()
+			tc.modify(&th)
+
+			err := th.Validate()
+			if tc.errExpected {
+				if err == nil {
+					t.Fatalf("error expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	type testCase struct {
+		name        string
+		manifest    string            // theme.json content (the file is not created if empty)
+		files       map[string]string // asset files created within the directory
+		expected    func() Theme
+		errExpected bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "OK: empty manifest keeps defaults",
+			manifest: `{}`,
+			expected: Default,
+		},
+		{
+			name: "OK: overrides",
+			manifest: `{
+				"alienImage": "sprites/alien.png",
+				"backgroundImage": "embedded:Planet.png",
+				"cityNameFontSize": 22,
+				"alienSize": 60,
+				"alienMoveSpeed": 45,
+				"colors": {"text": "#E0E0E0", "background": "#05070D80"}
+			}`,
+			files: map[string]string{"sprites/alien.png": "alien"},
+			expected: func() Theme {
+				th := Default()
+				th.AlienImage = []byte("alien")
+				th.BackgroundImage = resource.PlanetImage
+				th.CityNameFontSize = 22
+				th.AlienSize = 60
+				th.AlienMoveSpeed = 45
+				th.TextColor = color.NRGBA{R: 0xE0, G: 0xE0, B: 0xE0, A: 0xFF}
+				th.BackgroundColor = color.NRGBA{R: 0x05, G: 0x07, B: 0x0D, A: 0x80}
+				return th
+			},
+		},
+		{
+			name:        "Fail: no manifest",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: invalid JSON",
+			manifest:    `{"citySize": "big"}`,
+			errExpected: true,
+		},
+		{
+			name:        "Fail: asset file not found",
+			manifest:    `{"cityImage": "city.png"}`,
+			errExpected: true,
+		},
+		{
+			name:        "Fail: unknown embedded resource",
+			manifest:    `{"cityImage": "embedded:Moon.png"}`,
+			errExpected: true,
+		},
+		{
+			name:        "Fail: invalid color",
+			manifest:    `{"colors": {"panel": "blue"}}`,
+			errExpected: true,
+		},
+		{
+			name:        "Fail: invalid size",
+			manifest:    `{"roadSize": -10}`,
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dirPath := t.TempDir()
+			if tc.manifest != "" {
+				if err := os.WriteFile(filepath.Join(dirPath, ManifestFileName), []byte(tc.manifest), 0o644); err != nil {
+					t.Fatalf("writing manifest: %v", err)
+				}
+			}
+			for path, data := range tc.files {
+				path = filepath.Join(dirPath, path)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatalf("creating asset dir: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+					t.Fatalf("writing asset: %v", err)
+				}
+			}
+
+			th, err := Load(dirPath)
+			if tc.errExpected {
+				if err == nil {
+					t.Fatalf("error expected, got: %+v", th)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if expected := tc.expected(); !reflect.DeepEqual(th, expected) {
+				t.Errorf("theme: got %+v, expected %+v", th, expected)
+			}
+		})
+	}
+}
+
+func TestLoadShippedThemes(t *testing.T) {
+	for _, dirPath := range []string{"../../../../build/theme_planet"} {
+		t.Run(filepath.Base(dirPath), func(t *testing.T) {
+			if _, err := Load(dirPath); err != nil {
+				t.Fatalf("loading: %v", err)
+			}
+		})
+	}
+}
@@ -3,37 +3,64 @@ package types
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/itiky/alienInvasion/model"
 )
 
 const (
-	alienSpriteStateIdle    = iota // skip draw
-	alienSpriteStateLocated        // static draw
-	alienSpriteStateMoving         // movement animation
+	alienSpriteStateIdle      = iota // skip draw
+	alienSpriteStateLocated          // static draw
+	alienSpriteStateMoving           // movement animation
+	alienSpriteStateDismissed        // dismiss animation (removed once it is over)
+)
+
+// Alien sprite visual encoding params.
+const (
+	alienPowerScaleMin = 0.75 // sprite scale for the weakest Alien
+	alienPowerScaleMax = 1.25 // sprite scale for the strongest Alien
+	alienTintK         = 0.6  // power tint strength
+
+	alienStepsBarHeight = 5.0 // remaining steps bar height
+	alienStepsBarOffset = 2.0 // remaining steps bar gap below the sprite
+
+	alienDismissTicks     = 45  // dismiss animation duration
+	alienEvacuateLiftRate = 1.5 // evacuation animation lift per tick
+	alienFanOutTicks      = 10  // fan out position change animation duration
 )
 
 type (
 	// alienSprite keeps an Alien sprite data alongside movement animation state.
 	alienSprite struct {
 		// State
-		name          string  // AlienID
-		movementState int     // Movement state [idle, located, moving]
-		x, y          float64 // Current abs coordinates
+		name          string      // AlienID
+		params        model.Alien // Alien params (power, speed, max steps)
+		movementState int         // Movement state [idle, located, moving]
+		x, y          float64     // Current abs coordinates
+		cityID        string      // current City (empty if not landed)
+		path          []string    // visited Cities (in order)
+		stepsUsed     uint        // number of moves (refused ones included)
+		powerLevel    float64     // Alien power relative to others [0.0, 1.0]
+
+		// Dismiss state values
+		dismissReason    string // dismiss reason (defines the animation)
+		dismissTicksLeft int    // number of animation ticks left
 
 		// Moving state values
+		xIdx, yIdx       int     // target City sprite matrix coordinates
+		xOffset, yOffset float64 // target offset from the City center (fan out)
 		xTarget, yTarget float64 // target abx coordinates
 		xV, yV           float64 // movement speed
-		moveSteps        int     // animation spped coef
+		moveSteps        int     // animation duration (ticks) used if the Alien speed is unknown
 		moveStepsLeft    int     // number of move steps left
 
 		// Alien sprite params
 		aImage           *ebiten.Image // image source
 		aScaleX, aScaleY float64       // image scaling coefs
 		aWidth, aHeight  float64       // image actual size (after scaling)
-		aColor           color.RGBA    // image color adjustments
+		aColor           color.RGBA    // image color adjustments (power tint)
+		pixel            *ebiten.Image // 1x1 image used to draw the remaining steps bar
 
 		// City sprite params
 		cTileWidth     float64 // city sprite width
@@ -97,7 +124,19 @@ func withCitySize(offsetXY, width, height int) alienSpriteOption {
 	}
 }
 
-// withAlienMoveSpeed overrides the default animation speed.
+// withAlienPixel sets a 1x1 white image used to draw the remaining steps bar.
+func withAlienPixel(pixel *ebiten.Image) alienSpriteOption {
+	return func(s *alienSprite) error {
+		if pixel == nil {
+			return fmt.Errorf("alien pixel image: nil")
+		}
+		s.pixel = pixel
+
+		return nil
+	}
+}
+
+// withAlienMoveSpeed overrides the default animation duration (ticks) used if the Alien speed is unknown.
 func withAlienMoveSpeed(speed int) alienSpriteOption {
 	return func(s *alienSprite) error {
 		if speed < 0 {
@@ -111,28 +150,16 @@ func withAlienMoveSpeed(speed int) alienSpriteOption {
 }
 
 // newAlienSprite creates an alienSprite instance.
-func newAlienSprite(alienID string, opts ...alienSpriteOption) (*alienSprite, error) {
+func newAlienSprite(alien model.Alien, opts ...alienSpriteOption) (*alienSprite, error) {
 	// Build
 	s := alienSprite{
-		name:      alienID,
+		name:      alien.Name,
+		params:    alien,
 		aScaleX:   1.0,
 		aScaleY:   1.0,
 		moveSteps: 30,
 	}
 
-	// Random Alien color
-	randBytes := make([]byte, 3)
-	if _, err := rand.Read(randBytes); err != nil { // nolint: gosec
-		return nil, fmt.Errorf("reading random bytes: %w", err)
-	}
-
-	s.aColor = color.RGBA{
-		R: randBytes[0],
-		G: randBytes[1],
-		B: randBytes[2],
-		A: 0x0,
-	}
-
 	for _, opt := range opts {
 		if err := opt(&s); err != nil {
 			return nil, err
@@ -143,41 +170,136 @@ func newAlienSprite(alienID string, opts ...alienSpriteOption) (*alienSprite, er
 	if s.aImage == nil {
 		return nil, fmt.Errorf("alien image: nil")
 	}
+	if s.pixel == nil {
+		return nil, fmt.Errorf("alien pixel image: nil")
+	}
 
 	return &s, nil
 }
 
-// SetMoveTarget sets a new movement animation target.
-func (s *alienSprite) SetMoveTarget(xIdx, yIdx int) {
-	//xIdxTarget, yIdxTarget := float64(xIdx), float64(yIdx)
+// SetPowerLevel scales and tints the sprite by the Alien power relative to others (0.0 is the weakest, 1.0 is the strongest).
+// Contract: called once before the first SetMoveTarget call.
+func (s *alienSprite) SetPowerLevel(level float64) {
+	s.powerLevel = level
 
-	s.xTarget = float64(xIdx)*s.cTileWidth + s.cCenterOffsetX - s.aWidth/2.0
-	s.yTarget = float64(yIdx)*s.cTileHeight + s.cCenterOffsetY - s.aHeight/2.0
+	scale := alienPowerScaleMin + (alienPowerScaleMax-alienPowerScaleMin)*level
+	s.aScaleX, s.aScaleY = s.aScaleX*scale, s.aScaleY*scale
+	s.aWidth, s.aHeight = s.aWidth*scale, s.aHeight*scale
 
-	//s.xTarget = (xIdxTarget+1.0)*s.cCenterOffsetX + xIdxTarget*(s.aWidth/2.0)
-	//s.yTarget = (yIdxTarget+1.0)*s.cCenterOffsetY + yIdxTarget*(s.aHeight/2.0)
+	r, g, b := heatmapColor(level)
+	s.aColor = color.RGBA{
+		R: uint8(r * alienTintK * 0xFF),
+		G: uint8(g * alienTintK * 0xFF),
+		B: uint8(b * alienTintK * 0xFF),
+		A: 0x0,
+	}
+}
 
-	s.movementState = alienSpriteStateMoving
+// SetMoveTarget sets a new movement animation target (the City center, fan out offset is reset).
+// Animation lasts for the Alien step duration adjusted by the {simSpeed} multiplier, so the sprite arrives
+// when the next move can happen.
+func (s *alienSprite) SetMoveTarget(xIdx, yIdx int, simSpeed float64) {
+	s.xIdx, s.yIdx = xIdx, yIdx
+	s.xOffset, s.yOffset = 0, 0
 
-	s.moveStepsLeft = s.moveSteps
-	s.xV = (s.xTarget - s.x) / float64(s.moveStepsLeft)
-	s.yV = (s.yTarget - s.y) / float64(s.moveStepsLeft)
+	s.startMove(s.moveTicks(simSpeed))
 }
 
-// Draw implements the ebiten.Game interface.
-func (s *alienSprite) Draw(screen *ebiten.Image) {
-	switch s.movementState {
-	case alienSpriteStateIdle:
+// SetFanOutOffset shifts the movement target from the City center (Aliens sharing a City don't overlap).
+// A located sprite moves to the new position with a short animation, a moving one keeps its arrival time.
+func (s *alienSprite) SetFanOutOffset(dx, dy float64) {
+	if dx == s.xOffset && dy == s.yOffset {
 		return
+	}
+	s.xOffset, s.yOffset = dx, dy
+
+	switch s.movementState {
+	case alienSpriteStateMoving:
+		s.startMove(s.moveStepsLeft)
 	case alienSpriteStateLocated:
+		s.startMove(alienFanOutTicks)
+	}
+}
+
+// SetCity updates the Alien location (path is extended, a move counts as a step if the Alien has landed before).
+func (s *alienSprite) SetCity(cityID string) {
+	if s.cityID != "" {
+		s.stepsUsed++
+	}
+	s.cityID = cityID
+	s.path = append(s.path, cityID)
+}
+
+// MoveRefused counts a refused move as a used step.
+func (s *alienSprite) MoveRefused() {
+	s.stepsUsed++
+}
+
+// Dismiss starts the dismiss animation: an evacuated Alien lifts off, others fade out.
+// An Alien that hasn't landed is dismissed immediately.
+func (s *alienSprite) Dismiss(reason string) {
+	s.dismissReason = reason
+	if s.movementState == alienSpriteStateIdle {
+		return
+	}
+
+	s.movementState = alienSpriteStateDismissed
+	s.dismissTicksLeft = alienDismissTicks
+}
+
+// Update updates the movement / dismiss animation state (called once per tick).
+func (s *alienSprite) Update() {
+	switch s.movementState {
 	case alienSpriteStateMoving:
 		s.updateMovingState()
+	case alienSpriteStateDismissed:
+		s.dismissTicksLeft--
+		if s.dismissReason == model.AlienDismissReasonEvacuated {
+			s.y -= alienEvacuateLiftRate
+		}
+	}
+}
+
+// Visible checks if the sprite should be drawn (an Alien has landed).
+func (s *alienSprite) Visible() bool {
+	return s.movementState != alienSpriteStateIdle
+}
+
+// Active checks if an Alien is on the map (landed and not dismissed).
+func (s *alienSprite) Active() bool {
+	return s.Visible() && s.dismissReason == ""
+}
+
+// Removable checks if an Alien is dismissed and its animation is over.
+func (s *alienSprite) Removable() bool {
+	if s.dismissReason == "" {
+		return false
+	}
+
+	return s.movementState != alienSpriteStateDismissed || s.dismissTicksLeft <= 0
+}
+
+// Bounds returns the sprite abs coordinates rectangle.
+func (s *alienSprite) Bounds() (x, y, width, height float64) {
+	return s.x, s.y, s.aWidth, s.aHeight
+}
+
+// Draw draws the sprite applying the {view} (camera) transformation.
+func (s *alienSprite) Draw(screen *ebiten.Image, view ebiten.GeoM) {
+	if !s.Visible() {
+		return
 	}
 
 	drawOpts := &ebiten.DrawImageOptions{
 		Filter: ebiten.FilterLinear,
 	}
 
+	// Dismiss animation: fading out (a destroyed Alien is burnt red)
+	alpha := 1.0
+	if s.movementState == alienSpriteStateDismissed {
+		alpha = float64(s.dismissTicksLeft) / alienDismissTicks
+	}
+
 	// Alien image
 	{
 		r := float64(s.aColor.R) / 0xFF
@@ -188,10 +310,72 @@ func (s *alienSprite) Draw(screen *ebiten.Image) {
 		drawOpts.GeoM.Reset()
 		drawOpts.GeoM.Scale(s.aScaleX, s.aScaleY)
 		drawOpts.GeoM.Translate(s.x, s.y)
+		drawOpts.GeoM.Concat(view)
 		drawOpts.ColorM.Reset()
 		drawOpts.ColorM.Translate(r, g, b, a)
+		if s.dismissReason == model.AlienDismissReasonDestroyed {
+			drawOpts.ColorM.Scale(1.0, 0.3, 0.3, 1.0)
+		}
+		drawOpts.ColorM.Scale(1.0, 1.0, 1.0, alpha)
 		screen.DrawImage(s.aImage, drawOpts)
 	}
+
+	// Remaining steps bar
+	if s.params.MaxSteps > 0 && s.movementState != alienSpriteStateDismissed {
+		left := 0.0
+		if s.stepsUsed < s.params.MaxSteps {
+			left = float64(s.params.MaxSteps-s.stepsUsed) / float64(s.params.MaxSteps)
+		}
+		y := s.y + s.aHeight + alienStepsBarOffset
+
+		s.drawBar(screen, view, s.x, y, s.aWidth, 0.15, 0.15, 0.15)
+		r, g, _ := heatmapColor(1.0 - left)
+		s.drawBar(screen, view, s.x, y, s.aWidth*left, r, g, 0.0)
+	}
+}
+
+// drawBar fills a steps bar rectangle.
+func (s *alienSprite) drawBar(screen *ebiten.Image, view ebiten.GeoM, x, y, width float64, r, g, b float64) {
+	if width <= 0 {
+		return
+	}
+
+	drawOpts := &ebiten.DrawImageOptions{}
+	drawOpts.GeoM.Scale(width, alienStepsBarHeight)
+	drawOpts.GeoM.Translate(x, y)
+	drawOpts.GeoM.Concat(view)
+	drawOpts.ColorM.Scale(r, g, b, 1.0)
+	screen.DrawImage(s.pixel, drawOpts)
+}
+
+// moveTicks returns the move animation duration in ticks.
+func (s *alienSprite) moveTicks(simSpeed float64) int {
+	if s.params.Speed <= 0 {
+		return s.moveSteps
+	}
+	if simSpeed <= 0 {
+		simSpeed = 1.0
+	}
+
+	stepDur := time.Duration(float64(s.params.Speed) / simSpeed)
+
+	return int(stepDur.Seconds() * float64(ebiten.MaxTPS()))
+}
+
+// startMove starts the movement animation to the current target.
+func (s *alienSprite) startMove(ticks int) {
+	if ticks < 1 {
+		ticks = 1
+	}
+
+	s.xTarget = float64(s.xIdx)*s.cTileWidth + s.cCenterOffsetX + s.xOffset - s.aWidth/2.0
+	s.yTarget = float64(s.yIdx)*s.cTileHeight + s.cCenterOffsetY + s.yOffset - s.aHeight/2.0
+
+	s.movementState = alienSpriteStateMoving
+
+	s.moveStepsLeft = ticks
+	s.xV = (s.xTarget - s.x) / float64(s.moveStepsLeft)
+	s.yV = (s.yTarget - s.y) / float64(s.moveStepsLeft)
 }
 
 // updateMovingState updates current coordinates for moving animation state.
@@ -207,14 +391,32 @@ func (s *alienSprite) updateMovingState() {
 }
 
 // newAlienSprites creates a new alienSprites sprites set.
+// Sprites are scaled and tinted by the Alien power relative to the weakest / strongest one.
 func newAlienSprites(aliens []model.Alien, alienSpriteOpts []alienSpriteOption) (alienSprites, error) {
 	sprites := make(alienSprites, len(aliens))
 
+	var powerMin, powerMax uint
+	for i, alien := range aliens {
+		if i == 0 || alien.Power < powerMin {
+			powerMin = alien.Power
+		}
+		if alien.Power > powerMax {
+			powerMax = alien.Power
+		}
+	}
+
 	for _, alien := range aliens {
-		sprite, err := newAlienSprite(alien.Name, alienSpriteOpts...)
+		sprite, err := newAlienSprite(alien, alienSpriteOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("creating alienSprite (%s): %w", alien.Name, err)
 		}
+
+		powerLevel := 0.5
+		if powerMax > powerMin {
+			powerLevel = float64(alien.Power-powerMin) / float64(powerMax-powerMin)
+		}
+		sprite.SetPowerLevel(powerLevel)
+
 		sprites[alien.Name] = sprite
 	}
 
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Camera zoom limits.
+const (
+	cameraZoomMin = 0.02
+	cameraZoomMax = 4.0
+)
+
+// camera keeps the map viewport state: sprites are drawn in world (unscaled) coordinates, which are converted
+// to screen ones by the camera transformation.
+type camera struct {
+	x, y          float64 // world coordinates of the viewport top-left corner
+	zoom          float64 // world to screen scale
+	width, height int     // viewport screen size
+}
+
+// newCamera creates a camera with the 1.0 zoom.
+func newCamera() camera {
+	return camera{zoom: 1.0}
+}
+
+// SetViewport updates the viewport screen size.
+func (c *camera) SetViewport(width, height int) {
+	c.width, c.height = width, height
+}
+
+// GeoM returns the world to screen transformation.
+func (c *camera) GeoM() ebiten.GeoM {
+	m := ebiten.GeoM{}
+	m.Translate(-c.x, -c.y)
+	m.Scale(c.zoom, c.zoom)
+
+	return m
+}
+
+// ScreenToWorld converts screen coordinates to world ones.
+func (c *camera) ScreenToWorld(x, y int) (float64, float64) {
+	return c.x + float64(x)/c.zoom, c.y + float64(y)/c.zoom
+}
+
+// InViewport checks if screen coordinates are within the viewport.
+func (c *camera) InViewport(x, y int) bool {
+	return x >= 0 && y >= 0 && x < c.width && y < c.height
+}
+
+// Visible checks if a world rectangle intersects the viewport.
+func (c *camera) Visible(x, y, width, height float64) bool {
+	xMax, yMax := c.x+float64(c.width)/c.zoom, c.y+float64(c.height)/c.zoom
+
+	return x+width >= c.x && y+height >= c.y && x <= xMax && y <= yMax
+}
+
+// Pan moves the viewport by screen pixels.
+func (c *camera) Pan(dx, dy int) {
+	c.x -= float64(dx) / c.zoom
+	c.y -= float64(dy) / c.zoom
+}
+
+// ZoomAt multiplies the zoom keeping the world point under the screen coordinates in place.
+func (c *camera) ZoomAt(x, y int, factor float64) {
+	wx, wy := c.ScreenToWorld(x, y)
+
+	c.zoom = math.Max(cameraZoomMin, math.Min(cameraZoomMax, c.zoom*factor))
+	c.x = wx - float64(x)/c.zoom
+	c.y = wy - float64(y)/c.zoom
+}
+
+// CenterOn moves the viewport center to world coordinates.
+func (c *camera) CenterOn(x, y float64) {
+	c.x = x - float64(c.width)/2.0/c.zoom
+	c.y = y - float64(c.height)/2.0/c.zoom
+}
+
+// Fit sets the zoom and position to show the whole world rectangle.
+func (c *camera) Fit(world image.Rectangle) {
+	if world.Empty() || c.width <= 0 || c.height <= 0 {
+		return
+	}
+
+	zoom := math.Min(float64(c.width)/float64(world.Dx()), float64(c.height)/float64(world.Dy()))
+	c.zoom = math.Max(cameraZoomMin, math.Min(cameraZoomMax, zoom))
+	c.CenterOn(float64(world.Min.X+world.Max.X)/2.0, float64(world.Min.Y+world.Max.Y)/2.0)
+}
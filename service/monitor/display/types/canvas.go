@@ -6,70 +6,146 @@ import (
 	"image"
 	"image/color"
 	_ "image/png" // PNG image format registration
+	"math"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/itiky/alienInvasion/model"
-	"github.com/itiky/alienInvasion/service/monitor/display/resource"
+	"github.com/itiky/alienInvasion/service/monitor/display/theme"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
 )
 
 var _ ebiten.Game = (*Canvas)(nil)
 
-// Canvas keeps all Aliens and City sprites data and updates on external events.
-type Canvas struct {
-	citiesLock sync.RWMutex // cities map lock
-	cities     citySprites  // Cities set
+type (
+	// Canvas keeps all Aliens and City sprites data and updates on external events.
+	// External events are queued and applied by the Update call, so sprites are only accessed by ebiten's routine.
+	Canvas struct {
+		eventsLock sync.Mutex    // events queue lock
+		events     []canvasEvent // events queue (applied on Update)
+
+		cities   citySprites     // Cities set
+		aliens   alienSprites    // Aliens set
+		status   *statusSprite   // Status window (events log)
+		hud      *hudSprite      // simulation status HUD
+		controls *controlsSprite // Controls bar (nil if disabled)
+		inspect  *inspectSprite  // selected City / Alien details panel
+		heatmap  *heatmapSprite  // heatmap overlay
+		editor   *mapEditor      // map editor state (nil if disabled)
+
+		theme      theme.Theme   // look params
+		background *ebiten.Image // map view background image (optional)
+
+		phase     model.SimPhase  // current simulation phase
+		pausedAt  time.Time       // simulation pause time (fight countdowns are frozen while paused)
+		simStatus model.SimStatus // last reported simulation status
+
+		cam             camera          // map view
+		world           image.Rectangle // initial map abs coordinates rectangle (fit to view)
+		fitted          bool            // initial fit to view is done
+		selectedAlienID string          // selected Alien (empty if none)
+		selectedCityID  string          // selected City (empty if none)
+		follow          bool            // map view follows the selected Alien
+		pixel           *ebiten.Image   // 1x1 image used to fill rectangles
+
+		citySpriteOpts  []citySpriteOption  // Reset params
+		alienSpriteOpts []alienSpriteOption // Reset params
+
+		withControls              bool               // Controls bar enabled flag
+		destroyProbs              map[string]float64 // heatmap City destruction probabilities (optional)
+		screenWidth, screenHeight int                // Window size (preferred before the first Layout call)
+	}
+
+	// CanvasOption defines the NewCanvas constructor option.
+	CanvasOption func(c *Canvas) error
 
-	aliensLock sync.RWMutex // aliens map lock
-	aliens     alienSprites // Aliens set
+	// canvasEvent defines a queued external event handler.
+	canvasEvent func(c *Canvas)
+)
 
-	status *statusSprite // Status window
+// WithControls enables the simulation Controls bar (buttons are handled by the ControlAt call).
+func WithControls() CanvasOption {
+	return func(c *Canvas) error {
+		c.withControls = true
 
-	screenWidth, screenHeight int // Window size
+		return nil
+	}
 }
 
-func NewCanvas(cityMap model.CityMap, aliens []model.Alien) (*Canvas, error) {
-	// Sprite default params
-	const (
-		defCanvasWidth, defCanvasHeight = 800, 600
+// WithTheme overrides the default theme (sprites, font, sizes, colors and the animation speed).
+func WithTheme(t theme.Theme) CanvasOption {
+	return func(c *Canvas) error {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("theme: %w", err)
+		}
+		c.theme = t
 
-		cityWidth, cityHeight = 100, 100
-		cityOffsetXY          = 50
-		cityNameOffsetY       = 25
+		return nil
+	}
+}
 
-		roadWidth, roadHeight = 50, 50
+// WithDestructionProbabilities sets City destruction probabilities (a batch report) for the heatmap overlay.
+func WithDestructionProbabilities(probs map[string]float64) CanvasOption {
+	return func(c *Canvas) error {
+		if len(probs) == 0 {
+			return fmt.Errorf("destruction probabilities: empty")
+		}
+		c.destroyProbs = probs
 
-		battleWidth, battleHeight = 100, 100
+		return nil
+	}
+}
 
-		alienWidth, alienHeight = 50, 50
-		alienMoveSpeed          = 30
+// NewCanvas creates a new Canvas instance with all sprites placed.
+func NewCanvas(cityMap model.CityMap, aliens []model.Alien, opts ...CanvasOption) (*Canvas, error) {
+	// Sprite default params
+	// (sizes, fonts, colors and images are defined by the theme)
+	const (
+		defCanvasWidth, defCanvasHeight = 800, 600
 
-		cityNameFontSize = 24
-		statusFontSize   = 18
-		fontDPI          = 72
+		fontDPI = 72
 
 		statusMsgOffsetXY = 5
 		statusMsgBufSize  = 5
-		statusMsgMaxLen   = 50
+		statusMsgMaxLen   = 70
+		statusLogSize     = 500
+
+		hudSamplesMax = 90
+
+		inspectWidth      = 360
+		inspectLineLenMax = 34
 	)
 
 	// Build
 	c := Canvas{
+		theme:        theme.Default(),
+		cam:          newCamera(),
 		screenWidth:  defCanvasWidth,
 		screenHeight: defCanvasHeight,
 	}
 
+	for _, opt := range opts {
+		if err := opt(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	th := c.theme
+	cityNameFontSize, statusFontSize := th.CityNameFontSize, th.PanelFontSize
+	cityNameOffsetY := cityNameFontSize + 1
+
 	// Crate fonts
-	fontData, err := opentype.Parse(resource.DefaultFont)
+	fontData, err := opentype.Parse(th.Font)
 	if err != nil {
-		return nil, fmt.Errorf("decoding DefaultFont: %w", err)
+		return nil, fmt.Errorf("decoding Font: %w", err)
 	}
 
 	cityNameFontFace, err := opentype.NewFace(fontData, &opentype.FaceOptions{
-		Size:    cityNameFontSize,
+		Size:    float64(cityNameFontSize),
 		DPI:     fontDPI,
 		Hinting: font.HintingFull,
 	})
@@ -77,186 +153,622 @@ func NewCanvas(cityMap model.CityMap, aliens []model.Alien) (*Canvas, error) {
 		return nil, fmt.Errorf("creating CityName font face: %w", err)
 	}
 
-	statusFontFace, err := opentype.NewFace(fontData, &opentype.FaceOptions{
-		Size:    cityNameFontSize,
+	// Status, HUD, heatmap, inspect and controls panels font
+	panelFontFace, err := opentype.NewFace(fontData, &opentype.FaceOptions{
+		Size:    float64(statusFontSize),
 		DPI:     fontDPI,
 		Hinting: font.HintingFull,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("creating status font face: %w", err)
+		return nil, fmt.Errorf("creating panel font face: %w", err)
 	}
 
 	// Decode images
-	cityImage, _, err := image.Decode(bytes.NewReader(resource.CityImage))
+	cityEbitenImage, err := decodeImage(th.CityImage)
 	if err != nil {
 		return nil, fmt.Errorf("decoding CityImage: %w", err)
 	}
-	cityEbitenImage := ebiten.NewImageFromImage(cityImage)
 
-	roadImage, _, err := image.Decode(bytes.NewReader(resource.RoadImage))
+	roadEbitenImage, err := decodeImage(th.RoadImage)
 	if err != nil {
 		return nil, fmt.Errorf("decoding RoadImage: %w", err)
 	}
-	roadEbitenImage := ebiten.NewImageFromImage(roadImage)
 
-	battleImage, _, err := image.Decode(bytes.NewReader(resource.BattleImage))
+	battleEbitenImage, err := decodeImage(th.BattleImage)
 	if err != nil {
-		return nil, fmt.Errorf("decoding RoadImage: %w", err)
+		return nil, fmt.Errorf("decoding BattleImage: %w", err)
 	}
-	battleEbitenImage := ebiten.NewImageFromImage(battleImage)
 
-	alienImage, _, err := image.Decode(bytes.NewReader(resource.AlienImage))
+	alienEbitenImage, err := decodeImage(th.AlienImage)
 	if err != nil {
 		return nil, fmt.Errorf("decoding AlienImage: %w", err)
 	}
-	alienEbitenImage := ebiten.NewImageFromImage(alienImage)
+
+	if th.BackgroundImage != nil {
+		backgroundEbitenImage, err := decodeImage(th.BackgroundImage)
+		if err != nil {
+			return nil, fmt.Errorf("decoding BackgroundImage: %w", err)
+		}
+		c.background = backgroundEbitenImage
+	}
+
+	c.pixel = ebiten.NewImage(1, 1)
+	c.pixel.Fill(color.White)
 
 	// Create sprites
-	citySprites, err := newCitySprites(cityMap,
-		[]citySpriteOption{
-			withCityImage(cityEbitenImage),
-			withCityScaling(cityWidth, cityHeight),
-			withCityTopLeftOffset(cityOffsetXY),
-			withCityNameFont(cityNameFontFace, color.White, cityNameOffsetY, cityNameFontSize),
-			withRoadImage(roadEbitenImage),
-			withRoadScaling(roadWidth, roadHeight),
-			withBattleImage(battleEbitenImage),
-			withBattleScaling(battleWidth, battleHeight),
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating cities sprite map: %w", err)
-	}
-	c.cities = citySprites
-	citiesWidth, citiesHeight := c.cities.Size()
-
-	alienSprites, err := newAlienSprites(aliens,
-		[]alienSpriteOption{
-			withAlienImage(alienEbitenImage),
-			withAlienScaling(alienWidth, alienHeight),
-			withCitySize(cityOffsetXY, cityWidth, cityHeight),
-			withAlienMoveSpeed(alienMoveSpeed),
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating aliens sprite map: %w", err)
+	c.citySpriteOpts = []citySpriteOption{
+		withCityImage(cityEbitenImage),
+		withCityScaling(th.CitySize, th.CitySize),
+		withCityTopLeftOffset(th.CityOffset),
+		withCityNameFont(cityNameFontFace, th.CityNameColor, cityNameOffsetY, cityNameFontSize),
+		withRoadImage(roadEbitenImage),
+		withRoadScaling(th.RoadSize, th.RoadSize),
+		withBattleImage(battleEbitenImage),
+		withBattleScaling(th.BattleSize, th.BattleSize),
+		withCityPixel(c.pixel),
+	}
+	c.alienSpriteOpts = []alienSpriteOption{
+		withAlienImage(alienEbitenImage),
+		withAlienScaling(th.AlienSize, th.AlienSize),
+		withCitySize(th.CityOffset, th.CitySize, th.CitySize),
+		withAlienMoveSpeed(th.AlienMoveSpeed),
+		withAlienPixel(c.pixel),
 	}
-	c.aliens = alienSprites
+
+	if err := c.buildSprites(cityMap, aliens); err != nil {
+		return nil, err
+	}
+	citiesWidth, citiesHeight := c.world.Dx(), c.world.Dy()
 
 	statusSprite, err := newStatusSprite(
 		withStatusWindowLocation(0, citiesHeight),
 		withStatusMsgBuffer(statusMsgBufSize, statusMsgMaxLen),
-		withStatusNameFont(statusFontFace, color.White, statusFontSize, statusMsgOffsetXY),
+		withStatusLogSize(statusLogSize),
+		withStatusNameFont(panelFontFace, th.TextColor, statusFontSize, statusMsgOffsetXY),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("creating status sprite: %w", err)
 	}
 	c.status = statusSprite
 
+	hudSprite, err := newHUDSprite(
+		withHUDFont(panelFontFace, th.TextColor, statusFontSize),
+		withHUDSamples(hudSamplesMax),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating HUD sprite: %w", err)
+	}
+	c.hud = hudSprite
+
+	heatmapSprite, err := newHeatmapSprite(
+		withHeatmapFont(panelFontFace, th.TextColor),
+		withHeatmapDestroyProbs(c.destroyProbs),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating heatmap sprite: %w", err)
+	}
+	heatmapSprite.Reset(c.cities)
+	c.heatmap = heatmapSprite
+
+	inspectSprite, err := newInspectSprite(
+		withInspectFont(panelFontFace, th.TextColor, statusFontSize),
+		withInspectSize(inspectWidth, inspectLineLenMax),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating inspect sprite: %w", err)
+	}
+	c.inspect = inspectSprite
+
 	// Adjust the screen size
 	c.screenWidth, c.screenHeight = citiesWidth, citiesHeight+c.status.Height()
 
+	if c.editor != nil {
+		c.editor.cityMap = make(model.CityMap, len(cityMap))
+		for cityID, city := range cityMap {
+			c.editor.cityMap[cityID] = city
+		}
+		c.editorRefresh()
+
+		// An empty / small map still gets a room to draw
+		if c.screenWidth < defCanvasWidth {
+			c.screenWidth = defCanvasWidth
+		}
+		if c.screenHeight < defCanvasHeight {
+			c.screenHeight = defCanvasHeight
+		}
+	}
+
+	if c.withControls {
+		controlsSprite, err := newControlsSprite(
+			withControlsLocation(0, c.screenHeight),
+			withControlsFont(panelFontFace, th.TextColor, statusFontSize),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("creating controls sprite: %w", err)
+		}
+		c.controls = controlsSprite
+
+		if w := c.controls.Width(); w > c.screenWidth {
+			c.screenWidth = w
+		}
+		c.screenHeight += c.controls.Height()
+	}
+
 	return &c, nil
 }
 
+// Size returns the screen size that fits the whole map with the 1.0 zoom.
+func (c *Canvas) Size() (width, height int) {
+	return c.screenWidth, c.screenHeight
+}
+
 // Update implements the ebiten.Game interface.
+// Queued events are applied here (ebiten's routine), so sprites are never accessed concurrently.
 func (c *Canvas) Update() error {
 	if ebiten.IsWindowBeingClosed() {
 		return ErrWindowClosed
 	}
 
+	for _, event := range c.popEvents() {
+		event(c)
+	}
+
+	for _, sprite := range c.cities {
+		sprite.Update()
+	}
+	for alienID, sprite := range c.aliens {
+		if c.phase != model.SimPhasePaused {
+			sprite.Update()
+		}
+		if sprite.Removable() {
+			delete(c.aliens, alienID)
+		}
+	}
+	c.updateFollow()
+	c.inspect.SetLines(c.inspectLines())
+
+	if c.controls != nil {
+		info := fmt.Sprintf("Speed: x%.2f | Phase: %s | Zoom: x%.2f", c.simStatus.Speed, c.phase, c.cam.zoom)
+		if c.follow {
+			info += " | Following: " + c.selectedAlienID
+		}
+		c.controls.SetState(c.phase == model.SimPhasePaused, info)
+	}
+
 	return nil
 }
 
 // Draw implements the ebiten.Game interface.
+// Only sprites within the map view are drawn, City names are skipped if the zoom is too small to read them.
 func (c *Canvas) Draw(screen *ebiten.Image) {
-	c.citiesLock.RLock()
-	defer c.citiesLock.RUnlock()
+	const (
+		namesZoomMin = 0.3
+	)
 
-	c.aliensLock.RLock()
-	defer c.aliensLock.RUnlock()
+	view := c.cam.GeoM()
+	withNames := c.cam.zoom >= namesZoomMin
+	now := c.now()
 
-	for _, sprite := range c.cities {
-		sprite.Draw(screen)
+	c.drawBackground(screen)
+
+	for cityID, sprite := range c.cities {
+		if !c.cam.Visible(sprite.Bounds()) {
+			continue
+		}
+		if cityID == c.selectedCityID {
+			c.drawCitySelection(screen, view, sprite)
+		}
+		sprite.Draw(screen, view, withNames, now)
+	}
+	c.heatmap.Draw(screen, view)
+	if c.editor != nil {
+		c.drawEditor(screen, view)
 	}
 
-	for _, sprite := range c.aliens {
-		sprite.Draw(screen)
+	for alienID, sprite := range c.aliens {
+		if !sprite.Visible() || !c.cam.Visible(sprite.Bounds()) {
+			continue
+		}
+		if alienID == c.selectedAlienID {
+			c.drawAlienSelection(screen, view, sprite)
+		}
+		sprite.Draw(screen, view)
 	}
+	if c.editor == nil {
+		c.hud.Draw(screen)
+	}
+	c.inspect.Draw(screen)
 
+	// Status / controls panel below the map view
+	c.fillRectColor(screen, 0, float64(c.cam.height), float64(c.screenWidth), float64(c.screenHeight-c.cam.height), c.theme.PanelColor)
 	c.status.Draw(screen)
+
+	if c.controls != nil {
+		c.controls.Draw(screen)
+	}
 }
 
 // Layout implements the ebiten.Game interface.
+// Screen size follows the window size (the map view is scaled by the camera), status and controls are placed below the map view.
 func (c *Canvas) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return c.screenWidth, c.screenHeight
+	const (
+		overlayMargin = 10
+	)
+
+	if outsideWidth == c.screenWidth && outsideHeight == c.screenHeight && c.fitted {
+		return outsideWidth, outsideHeight
+	}
+	c.screenWidth, c.screenHeight = outsideWidth, outsideHeight
+
+	panelHeight := c.status.Height()
+	if c.controls != nil {
+		panelHeight += c.controls.Height()
+	}
+
+	viewHeight := outsideHeight - panelHeight
+	if viewHeight < 1 {
+		viewHeight = 1
+	}
+	c.cam.SetViewport(outsideWidth, viewHeight)
+	c.hud.SetLocation(overlayMargin, overlayMargin)
+	c.heatmap.SetLocation(overlayMargin, viewHeight-overlayMargin)
+	c.inspect.SetLocation(outsideWidth-c.inspect.Width()-overlayMargin, overlayMargin)
+
+	c.status.SetLocation(0, viewHeight)
+	if c.controls != nil {
+		c.controls.SetLocation(0, viewHeight+c.status.Height())
+	}
+
+	if !c.fitted {
+		c.cam.Fit(c.world)
+		c.fitted = true
+	}
+
+	return outsideWidth, outsideHeight
 }
 
-// UpdateCity updates a City (road connection have changed).
+// UpdateCity queues a City update (road connection have changed).
 func (c *Canvas) UpdateCity(city model.City) {
-	c.citiesLock.Lock()
-	defer c.citiesLock.Unlock()
+	c.pushEvent(func(c *Canvas) {
+		sprite, ok := c.cities[city.Name]
+		if !ok {
+			return
+		}
+		sprite.UpdateCityData(city)
+		sprite.AddHistory("roads updated")
+	})
+}
 
-	sprite, ok := c.cities[city.Name]
-	if !ok {
-		return
+// SetCityOnFight queues a flag set to display "City on fight" sprite with the fight params.
+func (c *Canvas) SetCityOnFight(cityID string, alienIDs []string, deadline time.Time, prolonged bool) {
+	alienIDs = append([]string(nil), alienIDs...)
+
+	c.pushEvent(func(c *Canvas) {
+		sprite, ok := c.cities[cityID]
+		if !ok {
+			return
+		}
+		power := uint(0)
+		for _, alienID := range alienIDs {
+			if alienSprite, ok := c.aliens[alienID]; ok {
+				power += alienSprite.params.Power
+			}
+		}
+		sprite.SetOnFight(alienIDs, power, deadline, c.now())
+
+		action := "started"
+		if prolonged {
+			action = "prolonged"
+		} else {
+			c.heatmap.AddFight(cityID)
+		}
+		sprite.AddHistory(fmt.Sprintf("fight %s [%s]", action, strings.Join(alienIDs, ",")))
+		c.logMsg(msgKindCity, fmt.Sprintf("City %s fight %s [%s]", cityID, action, strings.Join(alienIDs, ",")))
+	})
+}
+
+// UpdateCityFightDeadline queues a City fight estimated end time update.
+func (c *Canvas) UpdateCityFightDeadline(cityID string, deadline time.Time) {
+	c.pushEvent(func(c *Canvas) {
+		if sprite, ok := c.cities[cityID]; ok {
+			sprite.UpdateFightDeadline(deadline, c.now())
+		}
+	})
+}
+
+// EndCityFight queues a flag drop to stop displaying "City on fight" sprite.
+func (c *Canvas) EndCityFight(cityID string) {
+	c.pushEvent(func(c *Canvas) {
+		sprite, ok := c.cities[cityID]
+		if !ok {
+			return
+		}
+		sprite.SetFightEnded()
+		sprite.AddHistory("fight ended")
+		c.logMsg(msgKindCity, "City "+cityID+" fight ended")
+	})
+}
+
+// DestroyCity queues a City destruction (the City is kept as ruins).
+func (c *Canvas) DestroyCity(cityID string, alienIDs []string) {
+	msg := fmt.Sprintf("City %s destroyed by [%s]", cityID, strings.Join(alienIDs, ","))
+
+	c.pushEvent(func(c *Canvas) {
+		if sprite, ok := c.cities[cityID]; ok {
+			sprite.SetDestroyed()
+			sprite.AddHistory(fmt.Sprintf("destroyed by [%s]", strings.Join(alienIDs, ",")))
+		}
+		c.logMsg(msgKindCity, msg)
+	})
+}
+
+// RelocateAlien queues a new movement animation target set for an Alien (landing or a move).
+func (c *Canvas) RelocateAlien(alienID, cityID string) {
+	c.pushEvent(func(c *Canvas) {
+		alienSprite, ok := c.aliens[alienID]
+		if !ok {
+			return
+		}
+
+		citySprite, ok := c.cities[cityID]
+		if !ok {
+			return
+		}
+
+		oldCityID := alienSprite.cityID
+		if oldCitySprite, ok := c.cities[oldCityID]; ok {
+			oldCitySprite.RemoveAlien(alienID)
+			oldCitySprite.AddHistory(fmt.Sprintf("%s left to %s", alienID, cityID))
+			citySprite.AddHistory(fmt.Sprintf("%s arrived from %s", alienID, oldCityID))
+			c.logMsg(msgKindAlien, fmt.Sprintf("Alien %s moved %s -> %s", alienID, oldCityID, cityID))
+			c.heatmap.AddTravel(oldCityID, cityID)
+		} else {
+			citySprite.AddHistory(fmt.Sprintf("%s landed", alienID))
+			c.logMsg(msgKindAlien, fmt.Sprintf("Alien %s landed at %s", alienID, cityID))
+		}
+		c.heatmap.AddPass(cityID)
+		citySprite.AddAlien(alienID)
+		alienSprite.SetCity(cityID)
+
+		alienSprite.SetMoveTarget(citySprite.xIdx, citySprite.yIdx, c.simStatus.Speed)
+		c.fanOutAliens(cityID)
+		c.fanOutAliens(oldCityID)
+	})
+}
+
+// RefuseAlienMove queues an Alien refused move (counted as a used step).
+func (c *Canvas) RefuseAlienMove(alienID, cityID, targetCityID string) {
+	c.pushEvent(func(c *Canvas) {
+		if sprite, ok := c.aliens[alienID]; ok {
+			sprite.MoveRefused()
+		}
+		if sprite, ok := c.cities[cityID]; ok {
+			sprite.AddHistory(fmt.Sprintf("%s can't leave to %s (fight)", alienID, targetCityID))
+		}
+	})
+}
+
+// TrapAlien queues an Alien trapped report.
+func (c *Canvas) TrapAlien(alienID, cityID string) {
+	msg := "Alien " + alienID + " trapped at " + cityID
+
+	c.pushEvent(func(c *Canvas) {
+		if sprite, ok := c.cities[cityID]; ok {
+			sprite.AddHistory(alienID + " trapped")
+		}
+		c.logMsg(msgKindAlien, msg)
+	})
+}
+
+// DestroyAlien queues an Alien removal from the Canvas (the sprite is removed once its dismiss animation is over).
+func (c *Canvas) DestroyAlien(alienID, reason string) {
+	msg := fmt.Sprintf("Alien %s removed (%s)", alienID, reason)
+
+	c.pushEvent(func(c *Canvas) {
+		if alienSprite, ok := c.aliens[alienID]; ok {
+			if citySprite, ok := c.cities[alienSprite.cityID]; ok {
+				citySprite.RemoveAlien(alienID)
+				citySprite.AddHistory(fmt.Sprintf("%s removed (%s)", alienID, reason))
+				c.fanOutAliens(alienSprite.cityID)
+			}
+		}
+
+		if sprite, ok := c.aliens[alienID]; ok {
+			sprite.Dismiss(reason)
+		}
+		if alienID == c.selectedAlienID {
+			c.selectedAlienID, c.follow = "", false
+		}
+		c.logMsg(msgKindAlien, msg)
+	})
+}
+
+// SetPhase queues the simulation phase update.
+func (c *Canvas) SetPhase(phase model.SimPhase) {
+	c.pushEvent(func(c *Canvas) {
+		c.setPhase(phase)
+	})
+}
+
+// UpdateStatus queues the simulation status update.
+func (c *Canvas) UpdateStatus(status model.SimStatus) {
+	c.pushEvent(func(c *Canvas) {
+		c.simStatus = status
+		c.setPhase(status.Phase)
+		c.hud.AddStatus(status)
+	})
+}
+
+// Reset queues all Cities and Aliens sprites rebuild (simulation restart).
+func (c *Canvas) Reset(cityMap model.CityMap, aliens []model.Alien) {
+	c.pushEvent(func(c *Canvas) {
+		if err := c.buildSprites(cityMap, aliens); err != nil {
+			c.logMsg(msgKindSim, "Reset failed: "+err.Error())
+			return
+		}
+		c.phase, c.pausedAt, c.simStatus = "", time.Time{}, model.SimStatus{}
+		c.selectedAlienID, c.selectedCityID, c.follow = "", "", false
+		c.hud.Reset()
+		c.heatmap.Reset(c.cities)
+		c.logMsg(msgKindSim, "Simulation restarted")
+	})
+}
+
+// setPhase updates the simulation phase tracking the pause time.
+func (c *Canvas) setPhase(phase model.SimPhase) {
+	if phase == model.SimPhasePaused && c.phase != model.SimPhasePaused {
+		c.pausedAt = time.Now()
 	}
-	sprite.UpdateCityData(city)
+	c.phase = phase
 }
 
-// SetCityOnFight sets a flag to display "City on fight" sprite.
-func (c *Canvas) SetCityOnFight(cityID string) {
-	c.citiesLock.Lock()
-	defer c.citiesLock.Unlock()
+// now returns the current time for fight countdowns (the pause time if the simulation is paused).
+func (c *Canvas) now() time.Time {
+	if c.phase == model.SimPhasePaused {
+		return c.pausedAt
+	}
 
-	sprite, ok := c.cities[cityID]
-	if !ok {
-		return
+	return time.Now()
+}
+
+// Phase returns the current simulation phase.
+// Contract: called by ebiten's routine (Update).
+func (c *Canvas) Phase() model.SimPhase {
+	return c.phase
+}
+
+// ControlAt returns the Controls bar button ID at the screen coordinates.
+// Contract: called by ebiten's routine (Update).
+func (c *Canvas) ControlAt(x, y int) (string, bool) {
+	if c.controls == nil {
+		return "", false
 	}
-	sprite.SetOnFight()
+
+	return c.controls.ButtonAt(x, y)
 }
 
-// DestroyCity removes a City from the Canvas.
-func (c *Canvas) DestroyCity(cityID string, alienIDs []string) {
-	c.citiesLock.Lock()
-	defer c.citiesLock.Unlock()
+// PrintMsg queues a simulation message for the events log.
+func (c *Canvas) PrintMsg(msg string) {
+	c.pushEvent(func(c *Canvas) {
+		c.logMsg(msgKindSim, msg)
+	})
+}
 
-	delete(c.cities, cityID)
+// CycleHeatmap switches the heatmap overlay to the next mode (off, fights, aliens passed, destruction probability)
+// and returns its name.
+// Contract: called by ebiten's routine (Update).
+func (c *Canvas) CycleHeatmap() string {
+	return c.heatmap.CycleMode()
+}
 
-	msg := fmt.Sprintf("City %s destroyed by [%s]", cityID, strings.Join(alienIDs, ","))
-	c.status.AddMsg(msg)
+// ScrollLog scrolls the events log back (positive delta) or forward (negative delta) by a number of messages.
+// Contract: called by ebiten's routine (Update).
+func (c *Canvas) ScrollLog(delta int) {
+	c.status.Scroll(delta)
 }
 
-// RelocateAlien sets a new movement animation target for an Alien.
-func (c *Canvas) RelocateAlien(alienID, cityID string) {
-	c.aliensLock.Lock()
-	defer c.aliensLock.Unlock()
+// ScrollLogToEnd moves the events log view to the latest messages.
+// Contract: called by ebiten's routine (Update).
+func (c *Canvas) ScrollLogToEnd() {
+	c.status.ScrollToEnd()
+}
 
-	alienSprite, ok := c.aliens[alienID]
-	if !ok {
-		return
+// CycleLogFilter switches the events log to the next message kind filter (all, sim, city, alien).
+// Contract: called by ebiten's routine (Update).
+func (c *Canvas) CycleLogFilter() {
+	c.status.CycleFilter()
+}
+
+// InLog checks if screen coordinates are within the events log.
+// Contract: called by ebiten's routine (Update).
+func (c *Canvas) InLog(x, y int) bool {
+	return c.status.Contains(x, y)
+}
+
+// logMsg adds a message to the events log prefixed with the simulated time.
+func (c *Canvas) logMsg(kind, msg string) {
+	c.status.AddMsg(kind, fmt.Sprintf("[%s] %s", c.simStatus.SimElapsed.Round(100*time.Millisecond), msg))
+}
+
+// buildSprites creates Cities and Aliens sprites.
+func (c *Canvas) buildSprites(cityMap model.CityMap, aliens []model.Alien) error {
+	citySprites, err := newCitySprites(cityMap, c.citySpriteOpts)
+	if err != nil {
+		return fmt.Errorf("creating cities sprite map: %w", err)
 	}
 
+	alienSprites, err := newAlienSprites(aliens, c.alienSpriteOpts)
+	if err != nil {
+		return fmt.Errorf("creating aliens sprite map: %w", err)
+	}
+
+	c.cities, c.aliens = citySprites, alienSprites
+
+	width, height := c.cities.Size()
+	c.world = image.Rect(0, 0, width, height)
+
+	return nil
+}
+
+// fanOutAliens places Aliens sharing a City around its center (a single Alien stays in the center).
+func (c *Canvas) fanOutAliens(cityID string) {
+	const (
+		radiusK = 0.3 // fan out radius relative to the City size
+	)
+
 	citySprite, ok := c.cities[cityID]
 	if !ok {
 		return
 	}
 
-	alienSprite.SetMoveTarget(citySprite.xIdx, citySprite.yIdx)
+	alienIDs := make([]string, 0, len(citySprite.alienIDs))
+	for alienID := range citySprite.alienIDs {
+		alienIDs = append(alienIDs, alienID)
+	}
+	sort.Strings(alienIDs)
+
+	radius := 0.0
+	if len(alienIDs) > 1 {
+		radius = math.Min(citySprite.cWidth, citySprite.cHeight) * radiusK
+	}
+
+	for i, alienID := range alienIDs {
+		alienSprite, ok := c.aliens[alienID]
+		if !ok {
+			continue
+		}
+
+		angle := 2.0 * math.Pi * float64(i) / float64(len(alienIDs))
+		alienSprite.SetFanOutOffset(radius*math.Cos(angle), radius*math.Sin(angle))
+	}
 }
 
-// DestroyAlien removes an Alien from the Canvas.
-func (c *Canvas) DestroyAlien(alienID, reason string) {
-	c.aliensLock.Lock()
-	defer c.aliensLock.Unlock()
+// decodeImage decodes an image (PNG data) into the ebiten one.
+func decodeImage(data []byte) (*ebiten.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return ebiten.NewImageFromImage(img), nil
+}
 
-	delete(c.aliens, alienID)
+// pushEvent adds an event to the queue.
+// Never blocks on rendering: the queue lock is only held for append / swap operations.
+func (c *Canvas) pushEvent(event canvasEvent) {
+	c.eventsLock.Lock()
+	defer c.eventsLock.Unlock()
 
-	msg := fmt.Sprintf("Alien %s removed (%s)", alienID, reason)
-	c.status.AddMsg(msg)
+	c.events = append(c.events, event)
 }
 
-// PrintMsg adds a message to the Status sprite.
-func (c *Canvas) PrintMsg(msg string) {
-	c.status.AddMsg(msg)
+// popEvents returns all queued events (in the order they were pushed) and resets the queue.
+func (c *Canvas) popEvents() []canvasEvent {
+	c.eventsLock.Lock()
+	defer c.eventsLock.Unlock()
+
+	events := c.events
+	c.events = nil
+
+	return events
 }
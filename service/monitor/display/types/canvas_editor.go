@@ -0,0 +1,562 @@
+package types
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/layout"
+)
+
+// Map editor controls.
+// Cities are placed onto the same grid the Canvas uses to draw a loaded map (the shared grid layout),
+// roads connect grid neighbours only, so the saved map loads as it was drawn.
+// Contract: called by ebiten's routine (Update).
+
+// editorWorldMarginCells defines the number of empty grid cells around the edited map (a room to add cities).
+const editorWorldMarginCells = 1
+
+// mapEditor keeps the map editor state.
+type mapEditor struct {
+	filePath     string        // .aimap file path to save the map to
+	cityMap      model.CityMap // edited map
+	nameSeq      int           // new City names sequence
+	validErr     error         // last CityMap validation result (nil if valid)
+	layoutMethod string        // grid layout method used for the saved map
+	loadsAsDrawn bool          // the saved map grid layout matches the edited one
+	unsaved      bool          // map has changed since the last save
+
+	renameID    string // City being renamed (empty if none)
+	renameInput []rune // new City name typed
+
+	hover          bool    // cursor is within the map view
+	hoverX, hoverY int     // hovered grid cell
+	pointerX       float64 // cursor world coordinates
+	pointerY       float64
+	roadFromID     string // road drag start City (empty if no drag is in progress)
+}
+
+// WithEditor enables the map editor mode: the map is changed by the Editor* calls and saved to the .aimap file.
+// The HUD is hidden, the details panel shows the map validation state and the editor help.
+func WithEditor(filePath string) CanvasOption {
+	return func(c *Canvas) error {
+		if filePath == "" {
+			return fmt.Errorf("editor file path: empty")
+		}
+		c.editor = &mapEditor{filePath: filePath}
+
+		return nil
+	}
+}
+
+// EditorEnabled checks if the map editor mode is on.
+func (c *Canvas) EditorEnabled() bool {
+	return c.editor != nil
+}
+
+// EditorCityAt returns the City under the screen coordinates.
+func (c *Canvas) EditorCityAt(x, y int) (string, bool) {
+	return c.cityAtWorld(c.cam.ScreenToWorld(x, y))
+}
+
+// EditorSetPointer updates the cursor position (hovered cell highlight) and the road drag start City (empty if none).
+func (c *Canvas) EditorSetPointer(x, y int, roadFromID string) {
+	e := c.editor
+
+	e.hover = c.cam.InViewport(x, y)
+	e.pointerX, e.pointerY = c.cam.ScreenToWorld(x, y)
+	e.hoverX, e.hoverY = c.editorCellAt(e.pointerX, e.pointerY)
+	e.roadFromID = roadFromID
+}
+
+// EditorAddCity adds a new City (generated name) into the empty grid cell under the screen coordinates.
+func (c *Canvas) EditorAddCity(x, y int) {
+	xIdx, yIdx := c.editorCellAt(c.cam.ScreenToWorld(x, y))
+	if cityID, ok := c.editorCityAtCell(xIdx, yIdx); ok {
+		c.logMsg(msgKindSim, "Cell is taken by "+cityID)
+		return
+	}
+
+	city := model.City{Name: c.editorNewCityName()}
+	sprite, err := newCitySprite(city, c.citySpriteOpts...)
+	if err != nil {
+		c.logMsg(msgKindSim, "Adding City failed: "+err.Error())
+		return
+	}
+	sprite.SetLocation(xIdx, yIdx)
+
+	c.cities[city.Name] = sprite
+	c.editor.cityMap[city.Name] = city
+
+	c.logMsg(msgKindCity, "City "+city.Name+" added")
+	c.editorChanged()
+}
+
+// EditorConnect creates a two-way road between the City and its grid neighbour under the screen coordinates.
+// Roads previously leaving both cities in that direction are removed.
+func (c *Canvas) EditorConnect(fromCityID string, x, y int) {
+	toCityID, ok := c.EditorCityAt(x, y)
+	if !ok || toCityID == fromCityID {
+		return
+	}
+
+	from, to := c.cities[fromCityID], c.cities[toCityID]
+	if from == nil || to == nil {
+		return
+	}
+
+	dir, ok := gridDirection(from.xIdx, from.yIdx, to.xIdx, to.yIdx)
+	if !ok {
+		c.logMsg(msgKindSim, fmt.Sprintf("Road %s - %s skipped: cities must be grid neighbours", fromCityID, toCityID))
+		return
+	}
+	if roadsOf(c.editor.cityMap[fromCityID])[dir] == toCityID {
+		return
+	}
+
+	c.editorUnlink(fromCityID, dir)
+	c.editorUnlink(toCityID, oppositeRoad(dir))
+
+	fromCity, toCity := c.editor.cityMap[fromCityID], c.editor.cityMap[toCityID]
+	setRoad(&fromCity, dir, toCityID)
+	setRoad(&toCity, oppositeRoad(dir), fromCityID)
+	c.editorStoreCity(fromCity)
+	c.editorStoreCity(toCity)
+
+	c.logMsg(msgKindCity, fmt.Sprintf("Road %s - %s added", fromCityID, toCityID))
+	c.editorChanged()
+}
+
+// EditorDeleteAt removes the City (with its roads) or the road under the screen coordinates.
+func (c *Canvas) EditorDeleteAt(x, y int) {
+	wx, wy := c.cam.ScreenToWorld(x, y)
+
+	if cityID, ok := c.cityAtWorld(wx, wy); ok {
+		for _, city := range c.editor.cityMap {
+			for dir, roadCityID := range roadsOf(city) {
+				if roadCityID == cityID {
+					c.editorUnlink(city.Name, dir)
+				}
+			}
+		}
+		for dir := range roadsOf(c.editor.cityMap[cityID]) {
+			c.editorUnlink(cityID, dir)
+		}
+
+		delete(c.editor.cityMap, cityID)
+		delete(c.cities, cityID)
+		if c.editor.renameID == cityID {
+			c.EditorCancelRename()
+		}
+
+		c.logMsg(msgKindCity, "City "+cityID+" removed")
+		c.editorChanged()
+		return
+	}
+
+	if cityID, dir, ok := c.editorRoadAt(wx, wy); ok {
+		targetID := roadsOf(c.editor.cityMap[cityID])[dir]
+		c.editorUnlink(cityID, dir)
+
+		c.logMsg(msgKindCity, fmt.Sprintf("Road %s - %s removed", cityID, targetID))
+		c.editorChanged()
+	}
+}
+
+// EditorStartRename starts the City rename (the new name is typed by the EditorRenameInput calls).
+func (c *Canvas) EditorStartRename(cityID string) {
+	if _, ok := c.editor.cityMap[cityID]; !ok {
+		return
+	}
+
+	c.editor.renameID, c.editor.renameInput = cityID, []rune(cityID)
+}
+
+// EditorRenaming checks if a City rename is in progress.
+func (c *Canvas) EditorRenaming() bool {
+	return c.editor.renameID != ""
+}
+
+// EditorRenameInput appends typed characters to the new City name, {backspace} removes the last one first.
+func (c *Canvas) EditorRenameInput(chars []rune, backspace bool) {
+	e := c.editor
+	if backspace && len(e.renameInput) > 0 {
+		e.renameInput = e.renameInput[:len(e.renameInput)-1]
+	}
+	e.renameInput = append(e.renameInput, chars...)
+}
+
+// EditorCommitRename renames the City updating all roads leading to it.
+// Names with spaces are refused (the .aimap format is space separated), other name rules are checked by the map validation.
+func (c *Canvas) EditorCommitRename() {
+	e := c.editor
+	oldID, newID := e.renameID, strings.TrimSpace(string(e.renameInput))
+	c.EditorCancelRename()
+
+	if newID == "" || newID == oldID {
+		return
+	}
+	if strings.ContainsAny(newID, " \t") {
+		c.logMsg(msgKindSim, fmt.Sprintf("Rename %s skipped: spaces are not supported by the map file format", oldID))
+		return
+	}
+	if _, ok := e.cityMap[newID]; ok {
+		c.logMsg(msgKindSim, fmt.Sprintf("Rename %s skipped: %s already exists", oldID, newID))
+		return
+	}
+
+	city, sprite := e.cityMap[oldID], c.cities[oldID]
+	delete(e.cityMap, oldID)
+	delete(c.cities, oldID)
+
+	city.Name = newID
+	e.cityMap[newID], c.cities[newID] = city, sprite
+	c.editorStoreCity(city)
+
+	for _, neighbour := range e.cityMap {
+		for dir, roadCityID := range roadsOf(neighbour) {
+			if roadCityID == oldID {
+				setRoad(&neighbour, dir, newID)
+				c.editorStoreCity(neighbour)
+			}
+		}
+	}
+
+	c.logMsg(msgKindCity, fmt.Sprintf("City %s renamed to %s", oldID, newID))
+	c.editorChanged()
+}
+
+// EditorCancelRename drops the City rename in progress.
+func (c *Canvas) EditorCancelRename() {
+	c.editor.renameID, c.editor.renameInput = "", nil
+}
+
+// EditorApplyLayout moves cities to the grid positions the saved map would be loaded with.
+func (c *Canvas) EditorApplyLayout() {
+	grid := layout.New(c.editor.cityMap)
+	for cityID, pos := range grid.Positions {
+		if sprite, ok := c.cities[cityID]; ok {
+			sprite.SetLocation(pos.X, pos.Y)
+		}
+	}
+
+	c.logMsg(msgKindSim, "Layout applied: "+grid.Method)
+	c.editorRefresh()
+}
+
+// EditorSave writes the map to the .aimap file.
+// An invalid map is not saved, as well as a map that would be loaded re-arranged (the load layout must be applied first).
+func (c *Canvas) EditorSave() {
+	e := c.editor
+	if e.validErr != nil {
+		c.logMsg(msgKindSim, "Save skipped: map is invalid: "+e.validErr.Error())
+		return
+	}
+	if !e.loadsAsDrawn {
+		c.logMsg(msgKindSim, "Save skipped: map loads re-arranged ("+e.layoutMethod+"), [A] to apply the load layout")
+		return
+	}
+
+	if err := e.cityMap.WriteFile(e.filePath); err != nil {
+		c.logMsg(msgKindSim, "Save failed: "+err.Error())
+		return
+	}
+	e.unsaved = false
+
+	c.logMsg(msgKindSim, fmt.Sprintf("Map saved: %s (%d cities)", e.filePath, len(e.cityMap)))
+}
+
+// editorLines returns the editor state and help for the details panel.
+func (c *Canvas) editorLines() []string {
+	e := c.editor
+
+	roadsCnt := 0
+	for _, city := range e.cityMap {
+		roadsCnt += len(city.AvailableRoads())
+	}
+
+	file := e.filePath
+	if e.unsaved {
+		file += " (unsaved)"
+	}
+
+	valid := "Valid: yes"
+	if e.validErr != nil {
+		valid = "Invalid: " + e.validErr.Error()
+	}
+
+	loads := "Layout: loads as drawn (" + e.layoutMethod + ")"
+	if !e.loadsAsDrawn {
+		loads = "Layout: loads re-arranged (" + e.layoutMethod + "), [A] to apply before saving"
+	}
+
+	lines := []string{
+		"Map editor: " + file,
+		fmt.Sprintf("Cities: %d, roads: %d", len(e.cityMap), roadsCnt/2),
+		valid,
+		loads,
+	}
+
+	if e.renameID != "" {
+		lines = append(lines,
+			"",
+			fmt.Sprintf("Rename %s: %s_", e.renameID, string(e.renameInput)),
+			"[Enter] apply, [Esc] cancel",
+		)
+	}
+
+	return append(lines,
+		"",
+		"Click an empty cell: add a City",
+		"Drag a City to a neighbour: road",
+		"Right click: remove a City / road",
+		"Double click a City: rename",
+		"[S] save, [A] apply the load layout",
+	)
+}
+
+// drawEditor draws the hovered empty cell highlight and the road being dragged.
+func (c *Canvas) drawEditor(screen *ebiten.Image, view ebiten.GeoM) {
+	const (
+		roadWidth = 10.0
+	)
+
+	e := c.editor
+	th := c.theme
+	pitchX, pitchY, offset := c.editorPitch()
+
+	if _, taken := c.editorCityAtCell(e.hoverX, e.hoverY); e.hover && e.roadFromID == "" && !taken {
+		drawOpts := &ebiten.DrawImageOptions{}
+		drawOpts.GeoM.Scale(float64(th.CitySize), float64(th.CitySize))
+		drawOpts.GeoM.Translate(float64(e.hoverX)*pitchX+offset, float64(e.hoverY)*pitchY+offset)
+		drawOpts.GeoM.Concat(view)
+		drawOpts.ColorM.Scale(0.4, 1.0, 0.4, 0.3)
+		screen.DrawImage(c.pixel, drawOpts)
+	}
+
+	if sprite, ok := c.cities[e.roadFromID]; ok {
+		ax, ay := sprite.cX+sprite.cWidth/2.0, sprite.cY+sprite.cHeight/2.0
+		bx, by := e.pointerX, e.pointerY
+
+		drawOpts := &ebiten.DrawImageOptions{}
+		drawOpts.GeoM.Scale(math.Hypot(bx-ax, by-ay), roadWidth)
+		drawOpts.GeoM.Translate(0, -roadWidth/2.0)
+		drawOpts.GeoM.Rotate(math.Atan2(by-ay, bx-ax))
+		drawOpts.GeoM.Translate(ax, ay)
+		drawOpts.GeoM.Concat(view)
+		drawOpts.ColorM.Scale(1.0, 0.85, 0.1, 0.8)
+		screen.DrawImage(c.pixel, drawOpts)
+	}
+}
+
+// editorChanged marks the map changed and refreshes the editor state.
+func (c *Canvas) editorChanged() {
+	c.editor.unsaved = true
+	c.editorRefresh()
+}
+
+// editorRefresh shifts cities to keep grid coordinates non-negative (the map view stays in place),
+// updates the world rectangle, validates the map and checks the layout it would be loaded with.
+func (c *Canvas) editorRefresh() {
+	e := c.editor
+	pitchX, pitchY, offset := c.editorPitch()
+
+	// Normalize grid coordinates
+	first, xMin, yMin, xMax, yMax := true, 0, 0, 0, 0
+	for _, sprite := range c.cities {
+		if first || sprite.xIdx < xMin {
+			xMin = sprite.xIdx
+		}
+		if first || sprite.yIdx < yMin {
+			yMin = sprite.yIdx
+		}
+		if first || sprite.xIdx > xMax {
+			xMax = sprite.xIdx
+		}
+		if first || sprite.yIdx > yMax {
+			yMax = sprite.yIdx
+		}
+		first = false
+	}
+	if xMin != 0 || yMin != 0 {
+		for _, sprite := range c.cities {
+			sprite.SetLocation(sprite.xIdx-xMin, sprite.yIdx-yMin)
+		}
+		c.cam.x -= float64(xMin) * pitchX
+		c.cam.y -= float64(yMin) * pitchY
+		xMax, yMax = xMax-xMin, yMax-yMin
+	}
+
+	// World with a margin to add cities around
+	cols, rows := xMax+1+editorWorldMarginCells, yMax+1+editorWorldMarginCells
+	c.world = image.Rect(
+		int(-editorWorldMarginCells*pitchX), int(-editorWorldMarginCells*pitchY),
+		int(float64(cols)*pitchX+offset), int(float64(rows)*pitchY+offset),
+	)
+
+	// Validation
+	e.validErr = e.cityMap.Validate()
+
+	grid := layout.New(e.cityMap)
+	e.layoutMethod, e.loadsAsDrawn = grid.Method, true
+	for cityID, pos := range grid.Positions {
+		if sprite, ok := c.cities[cityID]; !ok || sprite.xIdx != pos.X || sprite.yIdx != pos.Y {
+			e.loadsAsDrawn = false
+			break
+		}
+	}
+}
+
+// editorUnlink removes the City road in the direction (and the back road of the connected City if it leads back).
+func (c *Canvas) editorUnlink(cityID string, dir int) {
+	city, ok := c.editor.cityMap[cityID]
+	if !ok {
+		return
+	}
+
+	targetID := roadsOf(city)[dir]
+	if targetID == "" {
+		return
+	}
+	setRoad(&city, dir, "")
+	c.editorStoreCity(city)
+
+	target, ok := c.editor.cityMap[targetID]
+	if !ok {
+		return
+	}
+	for targetDir, roadCityID := range roadsOf(target) {
+		if roadCityID == cityID {
+			setRoad(&target, targetDir, "")
+		}
+	}
+	c.editorStoreCity(target)
+}
+
+// editorStoreCity updates the edited map City and its sprite data (no cut road stubs are kept).
+func (c *Canvas) editorStoreCity(city model.City) {
+	c.editor.cityMap[city.Name] = city
+	if sprite, ok := c.cities[city.Name]; ok {
+		sprite.City = city
+	}
+}
+
+// editorRoadAt returns the City and the road direction of a road drawn at the world coordinates
+// (a road occupies the gap between the City and its neighbour cell).
+func (c *Canvas) editorRoadAt(wx, wy float64) (string, int, bool) {
+	cityIDs := make([]string, 0, len(c.cities))
+	for cityID := range c.cities {
+		cityIDs = append(cityIDs, cityID)
+	}
+	sort.Strings(cityIDs)
+
+	for _, cityID := range cityIDs {
+		s := c.cities[cityID]
+		for dir, roadCityID := range s.roads() {
+			if roadCityID == "" {
+				continue
+			}
+
+			var x, y, width, height float64
+			switch dir {
+			case roadNorth:
+				x, y, width, height = s.cX, s.cY-s.cOffsetXY, s.cWidth, s.cOffsetXY
+			case roadEast:
+				x, y, width, height = s.cX+s.cWidth, s.cY, s.cOffsetXY, s.cHeight
+			case roadSouth:
+				x, y, width, height = s.cX, s.cY+s.cHeight, s.cWidth, s.cOffsetXY
+			case roadWest:
+				x, y, width, height = s.cX-s.cOffsetXY, s.cY, s.cOffsetXY, s.cHeight
+			}
+
+			if wx >= x && wy >= y && wx <= x+width && wy <= y+height {
+				return cityID, dir, true
+			}
+		}
+	}
+
+	return "", 0, false
+}
+
+// editorCellAt returns the grid cell of the world coordinates (a cell is a City image with the half of gaps around).
+func (c *Canvas) editorCellAt(wx, wy float64) (int, int) {
+	pitchX, pitchY, offset := c.editorPitch()
+
+	return int(math.Floor((wx - offset/2.0) / pitchX)), int(math.Floor((wy - offset/2.0) / pitchY))
+}
+
+// editorCityAtCell returns the City placed in the grid cell.
+func (c *Canvas) editorCityAtCell(xIdx, yIdx int) (string, bool) {
+	for cityID, sprite := range c.cities {
+		if sprite.xIdx == xIdx && sprite.yIdx == yIdx {
+			return cityID, true
+		}
+	}
+
+	return "", false
+}
+
+// editorPitch returns the grid cell size and the City top-left offset (citySprite.SetLocation grid params).
+func (c *Canvas) editorPitch() (pitchX, pitchY, offset float64) {
+	offset = float64(c.theme.CityOffset)
+	pitchX = float64(c.theme.CitySize) + offset
+	pitchY = pitchX
+
+	return
+}
+
+// editorNewCityName returns a new unique City name ("CityA", "CityB", ..., "CityAA", ...).
+func (c *Canvas) editorNewCityName() string {
+	for {
+		c.editor.nameSeq++
+
+		suffix := ""
+		for n := c.editor.nameSeq; n > 0; n = (n - 1) / 26 {
+			suffix = string(rune('A'+(n-1)%26)) + suffix
+		}
+
+		name := "City" + suffix
+		if _, ok := c.editor.cityMap[name]; !ok {
+			return name
+		}
+	}
+}
+
+// gridDirection returns the road direction from one grid cell to its neighbour (false if cells are not neighbours).
+func gridDirection(fromX, fromY, toX, toY int) (int, bool) {
+	switch dx, dy := toX-fromX, toY-fromY; {
+	case dx == 0 && dy == -1:
+		return roadNorth, true
+	case dx == 1 && dy == 0:
+		return roadEast, true
+	case dx == 0 && dy == 1:
+		return roadSouth, true
+	case dx == -1 && dy == 0:
+		return roadWest, true
+	}
+
+	return 0, false
+}
+
+// oppositeRoad returns the opposite road direction.
+func oppositeRoad(dir int) int {
+	return (dir + 2) % 4
+}
+
+// setRoad sets the City road in the direction.
+func setRoad(city *model.City, dir int, cityID string) {
+	switch dir {
+	case roadNorth:
+		city.NorthRoad = cityID
+	case roadEast:
+		city.EastRoad = cityID
+	case roadSouth:
+		city.SouthRoad = cityID
+	case roadWest:
+		city.WestRoad = cityID
+	}
+}
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// inspectLines returns the selected City / Alien details (empty if nothing is selected).
+// The map editor state is shown instead in the editor mode.
+func (c *Canvas) inspectLines() []string {
+	if c.editor != nil {
+		return c.editorLines()
+	}
+	if sprite, ok := c.aliens[c.selectedAlienID]; ok {
+		return alienInspectLines(sprite)
+	}
+	if sprite, ok := c.cities[c.selectedCityID]; ok {
+		return cityInspectLines(sprite, c.now())
+	}
+
+	return nil
+}
+
+// cityInspectLines returns a City details: roads, Aliens on tile, fight state and the events history.
+func cityInspectLines(s *citySprite, now time.Time) []string {
+	road := func(cityID string) string {
+		if cityID == "" {
+			return "-"
+		}
+		return cityID
+	}
+
+	alienIDs := make([]string, 0, len(s.alienIDs))
+	for alienID := range s.alienIDs {
+		alienIDs = append(alienIDs, alienID)
+	}
+	sort.Strings(alienIDs)
+
+	state := "alive"
+	if s.destroyed {
+		state = "ruins"
+	}
+
+	lines := []string{
+		"City: " + s.Name + " (" + state + ")",
+		fmt.Sprintf("Roads: N %s, E %s, S %s, W %s", road(s.NorthRoad), road(s.EastRoad), road(s.SouthRoad), road(s.WestRoad)),
+		fmt.Sprintf("Aliens (%d): %s", len(alienIDs), strings.Join(alienIDs, ", ")),
+	}
+
+	if s.hasFight {
+		timeLeft, _ := s.FightTimeLeft(now)
+		lines = append(lines,
+			fmt.Sprintf("Fight: %s left (lasts %s)", timeLeft.Round(100*time.Millisecond), now.Sub(s.fightStartedAt).Round(100*time.Millisecond)),
+			fmt.Sprintf("Fighting (power %d): %s", s.fightPower, strings.Join(s.fightAlienIDs, ", ")),
+		)
+	} else {
+		lines = append(lines, "Fight: none")
+	}
+
+	lines = append(lines, "History:")
+	for i := len(s.history) - 1; i >= 0; i-- {
+		lines = append(lines, " "+s.history[i])
+	}
+
+	return lines
+}
+
+// alienInspectLines returns an Alien details: params, steps used and the path so far.
+func alienInspectLines(s *alienSprite) []string {
+	cityID := s.cityID
+	if cityID == "" {
+		cityID = "not landed"
+	}
+
+	return []string{
+		"Alien: " + s.name,
+		fmt.Sprintf("Power: %d", s.params.Power),
+		fmt.Sprintf("Speed: %s per step", s.params.Speed),
+		fmt.Sprintf("Steps: %d / %d", s.stepsUsed, s.params.MaxSteps),
+		"City: " + cityID,
+		fmt.Sprintf("Path (%d): %s", len(s.path), strings.Join(s.path, " -> ")),
+	}
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestCanvasEventsQueue pushes events from producer goroutines while a consumer goroutine pops and applies them (as Update does).
+// Run with the -race flag to detect unsynchronized queue access.
+func TestCanvasEventsQueue(t *testing.T) {
+	type testCase struct {
+		name         string
+		producersCnt int
+		eventsCnt    int // per producer
+	}
+
+	testCases := []testCase{
+		{
+			name:         "Single producer",
+			producersCnt: 1,
+			eventsCnt:    1000,
+		},
+		{
+			name:         "Multiple producers",
+			producersCnt: 8,
+			eventsCnt:    1000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Canvas{}
+			appliedSeqs := make([][]int, tc.producersCnt) // accessed by the consumer only
+			appliedCnt := 0
+
+			// Producers
+			wg := sync.WaitGroup{}
+			for producerIdx := 0; producerIdx < tc.producersCnt; producerIdx++ {
+				wg.Add(1)
+				go func(producerIdx int) {
+					defer wg.Done()
+					for seq := 0; seq < tc.eventsCnt; seq++ {
+						producerIdx, seq := producerIdx, seq
+						c.pushEvent(func(c *Canvas) {
+							appliedSeqs[producerIdx] = append(appliedSeqs[producerIdx], seq)
+							appliedCnt++
+						})
+					}
+				}(producerIdx)
+			}
+
+			// Consumer (the Update loop)
+			expectedCnt := tc.producersCnt * tc.eventsCnt
+			consumerDoneCh := make(chan struct{})
+			go func() {
+				defer close(consumerDoneCh)
+				for appliedCnt < expectedCnt {
+					for _, event := range c.popEvents() {
+						event(c)
+					}
+				}
+			}()
+
+			wg.Wait()
+			<-consumerDoneCh
+
+			if events := c.popEvents(); len(events) != 0 {
+				t.Errorf("queue: %d events left", len(events))
+			}
+
+			// Every event is applied once in the per-producer push order
+			for producerIdx, seqs := range appliedSeqs {
+				if len(seqs) != tc.eventsCnt {
+					t.Errorf("producer %d: got %d events, expected %d", producerIdx, len(seqs), tc.eventsCnt)
+					continue
+				}
+				for i, seq := range seqs {
+					if seq != i {
+						t.Errorf("producer %d: event %d applied at position %d", producerIdx, seq, i)
+						break
+					}
+				}
+			}
+		})
+	}
+}
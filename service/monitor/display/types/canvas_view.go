@@ -0,0 +1,191 @@
+package types
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Map view controls.
+// Contract: called by ebiten's routine (Update).
+
+// InViewport checks if screen coordinates are within the map view.
+func (c *Canvas) InViewport(x, y int) bool {
+	return c.cam.InViewport(x, y)
+}
+
+// ZoomAt zooms the map view in / out keeping the map point under the screen coordinates in place.
+func (c *Canvas) ZoomAt(x, y int, factor float64) {
+	c.cam.ZoomAt(x, y, factor)
+}
+
+// Pan moves the map view by screen pixels (the follow mode is disabled).
+func (c *Canvas) Pan(dx, dy int) {
+	c.follow = false
+	c.cam.Pan(dx, dy)
+}
+
+// FitToView zooms the map view to show the whole map (the follow mode is disabled).
+func (c *Canvas) FitToView() {
+	c.follow = false
+	c.cam.Fit(c.world)
+}
+
+// SelectAt selects an Alien (the one closest to the point) or a City under the screen coordinates.
+// Selection is cleared if there is nothing under the point.
+func (c *Canvas) SelectAt(x, y int) bool {
+	wx, wy := c.cam.ScreenToWorld(x, y)
+
+	c.selectedCityID = ""
+
+	selectedID, selectedDist := "", math.Inf(1)
+	for alienID, sprite := range c.aliens {
+		if !sprite.Active() {
+			continue
+		}
+
+		sx, sy, sw, sh := sprite.Bounds()
+		if wx < sx || wy < sy || wx > sx+sw || wy > sy+sh {
+			continue
+		}
+
+		if dist := math.Hypot(sx+sw/2.0-wx, sy+sh/2.0-wy); dist < selectedDist {
+			selectedID, selectedDist = alienID, dist
+		}
+	}
+
+	if selectedID != c.selectedAlienID {
+		c.follow = false
+	}
+	c.selectedAlienID = selectedID
+	if selectedID != "" {
+		return true
+	}
+
+	if cityID, ok := c.cityAtWorld(wx, wy); ok {
+		c.selectedCityID = cityID
+		return true
+	}
+
+	return false
+}
+
+// cityAtWorld returns the City which image is under the world coordinates.
+func (c *Canvas) cityAtWorld(wx, wy float64) (string, bool) {
+	for cityID, sprite := range c.cities {
+		if wx >= sprite.cX && wy >= sprite.cY && wx <= sprite.cX+sprite.cWidth && wy <= sprite.cY+sprite.cHeight {
+			return cityID, true
+		}
+	}
+
+	return "", false
+}
+
+// ClearSelection drops the Alien / City selection (the follow mode is disabled).
+func (c *Canvas) ClearSelection() {
+	c.selectedAlienID, c.selectedCityID, c.follow = "", "", false
+}
+
+// ToggleFollow toggles the map view following the selected Alien.
+// Returns false if there is no Alien selected.
+func (c *Canvas) ToggleFollow() bool {
+	if c.selectedAlienID == "" {
+		c.follow = false
+		return false
+	}
+	c.follow = !c.follow
+
+	return true
+}
+
+// updateFollow centers the map view on the followed Alien.
+func (c *Canvas) updateFollow() {
+	if !c.follow {
+		return
+	}
+
+	sprite, ok := c.aliens[c.selectedAlienID]
+	if !ok || !sprite.Active() {
+		c.follow = false
+		return
+	}
+
+	x, y, width, height := sprite.Bounds()
+	c.cam.CenterOn(x+width/2.0, y+height/2.0)
+}
+
+// drawAlienSelection draws the selected Alien highlight.
+func (c *Canvas) drawAlienSelection(screen *ebiten.Image, view ebiten.GeoM, sprite *alienSprite) {
+	const (
+		borderXY = 6.0
+	)
+
+	x, y, width, height := sprite.Bounds()
+
+	drawOpts := &ebiten.DrawImageOptions{}
+	drawOpts.GeoM.Scale(width+2*borderXY, height+2*borderXY)
+	drawOpts.GeoM.Translate(x-borderXY, y-borderXY)
+	drawOpts.GeoM.Concat(view)
+	drawOpts.ColorM.Scale(1.0, 0.85, 0.1, 0.6)
+	screen.DrawImage(c.pixel, drawOpts)
+}
+
+// drawCitySelection draws the selected City highlight.
+func (c *Canvas) drawCitySelection(screen *ebiten.Image, view ebiten.GeoM, sprite *citySprite) {
+	const (
+		borderXY = 8.0
+	)
+
+	drawOpts := &ebiten.DrawImageOptions{}
+	drawOpts.GeoM.Scale(sprite.cWidth+2*borderXY, sprite.cHeight+2*borderXY)
+	drawOpts.GeoM.Translate(sprite.cX-borderXY, sprite.cY-borderXY)
+	drawOpts.GeoM.Concat(view)
+	drawOpts.ColorM.Scale(0.2, 0.7, 1.0, 0.6)
+	screen.DrawImage(c.pixel, drawOpts)
+}
+
+// drawBackground fills the map view with the theme background color and draws the background image (if set)
+// scaled to cover the map view (the aspect ratio is kept).
+func (c *Canvas) drawBackground(screen *ebiten.Image) {
+	c.fillRectColor(screen, 0, 0, float64(c.cam.width), float64(c.cam.height), c.theme.BackgroundColor)
+	if c.background == nil {
+		return
+	}
+
+	imgWidth, imgHeight := c.background.Size()
+	scale := math.Max(float64(c.cam.width)/float64(imgWidth), float64(c.cam.height)/float64(imgHeight))
+
+	viewport := image.Rect(0, 0, c.cam.width, c.cam.height)
+	drawOpts := &ebiten.DrawImageOptions{
+		Filter: ebiten.FilterLinear,
+	}
+	drawOpts.GeoM.Scale(scale, scale)
+	drawOpts.GeoM.Translate((float64(c.cam.width)-float64(imgWidth)*scale)/2.0, (float64(c.cam.height)-float64(imgHeight)*scale)/2.0)
+	screen.SubImage(viewport).(*ebiten.Image).DrawImage(c.background, drawOpts)
+}
+
+// fillRectColor fills a screen rectangle with a color.
+func (c *Canvas) fillRectColor(screen *ebiten.Image, x, y, width, height float64, clr color.Color) {
+	r, g, b, a := clr.RGBA()
+	if a == 0 {
+		return
+	}
+
+	// ColorM expects non-premultiplied values
+	c.fillRect(screen, x, y, width, height, float64(r)/float64(a), float64(g)/float64(a), float64(b)/float64(a), float64(a)/0xFFFF)
+}
+
+// fillRect fills a screen rectangle with a color.
+func (c *Canvas) fillRect(screen *ebiten.Image, x, y, width, height float64, r, g, b, a float64) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	drawOpts := &ebiten.DrawImageOptions{}
+	drawOpts.GeoM.Scale(width, height)
+	drawOpts.GeoM.Translate(x, y)
+	drawOpts.ColorM.Scale(r, g, b, a)
+	screen.DrawImage(c.pixel, drawOpts)
+}
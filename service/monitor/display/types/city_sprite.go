@@ -4,20 +4,61 @@ import (
 	"fmt"
 	"image/color"
 	"math"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/layout"
 	"golang.org/x/image/font"
 )
 
+// Road directions.
+const (
+	roadNorth = iota
+	roadEast
+	roadSouth
+	roadWest
+)
+
+// City sprite ruins params.
+const (
+	cityRoadStubK      = 0.4  // cut road stub length relative to the road length
+	cityRuinsValue     = 0.35 // ruins image brightness
+	cityDestroyTicks   = 60   // destroy animation duration
+	cityDestroyBlastK  = 1.5  // destroy animation blast max scale (relative to the Battle sprite size)
+	cityDestroyShakeXY = 4.0  // destroy animation City image shaking amplitude
+)
+
+// City sprite fight params.
+const (
+	cityFightRingSegments = 36   // countdown ring segments
+	cityFightRingRadiusK  = 0.45 // countdown ring radius relative to the City image size
+	cityFightRingSegmentK = 0.06 // countdown ring segment size relative to the City image size
+	cityFightFlashTicks   = 30   // fight prolonged flash duration
+	cityFightFlashAlpha   = 0.7  // fight prolonged flash max opacity
+	cityFightBadgeTextK   = 0.6  // badge text scale relative to the City name font
+	cityFightBadgePadding = 4.0  // badge text padding
+)
+
 type (
 	// citySprite keeps a City sprite data.
 	citySprite struct {
 		// State
-		model.City      // City data
-		hasFight   bool // City is on a fight flag (draws Fight sprite on top)
-		xIdx, yIdx int  // Sprite matrix coordinates (relative values that are converted to abx X and Y)
+		model.City                     // City data
+		hasFight       bool            // City is on a fight flag (draws Fight sprite on top)
+		fightAlienIDs  []string        // fight participants
+		fightStartedAt time.Time       // fight start time
+		fightDeadline  time.Time       // fight estimated end time
+		fightSpan      time.Duration   // fight countdown span (full ring), reset when the fight is prolonged
+		fightPower     uint            // fight participants total power
+		flashTicks     int             // fight prolonged flash ticks left
+		alienIDs       map[string]bool // Aliens on tile
+		history        []string        // last City events (oldest first)
+		destroyed      bool            // City is destroyed (drawn as ruins)
+		destroyTicks   int             // destroy animation ticks left
+		cutRoads       [4]bool         // roads removed due to neighbours destruction (drawn as stubs), key: road direction
+		xIdx, yIdx     int             // Sprite matrix coordinates (relative values that are converted to abx X and Y)
 
 		// City sprite params
 		cImage           *ebiten.Image // image source
@@ -41,6 +82,8 @@ type (
 		bImage           *ebiten.Image // image source
 		bScaleX, bScaleY float64       // image scaling coefs
 		bWidth, bHeight  float64       // image actual size (after scaling)
+
+		pixel *ebiten.Image // 1x1 image used to draw the fight countdown ring and badge
 	}
 
 	// citySpriteOption defines the newCitySprite constructor option.
@@ -186,13 +229,26 @@ func withBattleScaling(targetWidth, targetHeight int) citySpriteOption {
 	}
 }
 
+// withCityPixel sets a 1x1 white image used to draw the fight countdown ring and badge.
+func withCityPixel(pixel *ebiten.Image) citySpriteOption {
+	return func(s *citySprite) error {
+		if pixel == nil {
+			return fmt.Errorf("city pixel image: nil")
+		}
+		s.pixel = pixel
+
+		return nil
+	}
+}
+
 // newCitySprite creates a citySprite instance without coordinates.
 func newCitySprite(city model.City, opts ...citySpriteOption) (*citySprite, error) {
 	// Build
 	s := citySprite{
-		City:    city,
-		cScaleX: 1.0,
-		cScaleY: 1.0,
+		City:     city,
+		alienIDs: make(map[string]bool),
+		cScaleX:  1.0,
+		cScaleY:  1.0,
 	}
 
 	for _, opt := range opts {
@@ -214,6 +270,9 @@ func newCitySprite(city model.City, opts ...citySpriteOption) (*citySprite, erro
 	if s.cFontFace == nil {
 		return nil, fmt.Errorf("city fontFace: nil")
 	}
+	if s.pixel == nil {
+		return nil, fmt.Errorf("city pixel image: nil")
+	}
 
 	return &s, nil
 }
@@ -226,74 +285,177 @@ func (s *citySprite) SetLocation(xIdx, yIdx int) {
 	s.cY = float64(s.yIdx)*s.cHeight + float64(s.yIdx+1)*s.cOffsetXY
 }
 
-// UpdateCityData updates the City data.
+// UpdateCityData updates the City data marking removed roads as cut.
 func (s *citySprite) UpdateCityData(city model.City) {
+	prevRoads, roads := s.roads(), roadsOf(city)
+	for dir := range roads {
+		if prevRoads[dir] != "" && roads[dir] == "" {
+			s.cutRoads[dir] = true
+		}
+	}
+
 	s.City = city
 }
 
-// SetOnFight sets "City on fight" flag (enabled Fight sprite render).
-func (s *citySprite) SetOnFight() {
+// SetDestroyed marks the City destroyed starting the destroy animation (the City is drawn as ruins then).
+// Roads are kept to be drawn as cut stubs.
+func (s *citySprite) SetDestroyed() {
+	s.destroyed, s.destroyTicks = true, cityDestroyTicks
+	s.hasFight, s.fightAlienIDs, s.flashTicks = false, nil, 0
+	for dir, cityID := range s.roads() {
+		if cityID != "" {
+			s.cutRoads[dir] = true
+		}
+	}
+}
+
+// Update updates the destroy and the fight prolonged flash animations state (called once per tick).
+func (s *citySprite) Update() {
+	if s.destroyTicks > 0 {
+		s.destroyTicks--
+	}
+	if s.flashTicks > 0 {
+		s.flashTicks--
+	}
+}
+
+// SetOnFight sets "City on fight" flag (enabled Fight sprite render) with the fight params.
+// The countdown ring is refilled, an ongoing fight update (prolonged) starts the flash animation.
+func (s *citySprite) SetOnFight(alienIDs []string, power uint, deadline, now time.Time) {
+	if s.hasFight {
+		s.flashTicks = cityFightFlashTicks
+	} else {
+		s.fightStartedAt = now
+	}
 	s.hasFight = true
+	s.fightAlienIDs, s.fightPower = alienIDs, power
+	s.fightDeadline, s.fightSpan = deadline, deadline.Sub(now)
 }
 
-// Draw implements the ebiten.Game interface.
-func (s *citySprite) Draw(screen *ebiten.Image) {
-	const (
-		rotateCoefRads = 90.0 * math.Pi / 180.0
-	)
+// UpdateFightDeadline updates the fight estimated end time (the simulation was resumed or its speed has changed).
+// The countdown span is rescaled to keep the ring remaining fraction.
+func (s *citySprite) UpdateFightDeadline(deadline, now time.Time) {
+	if !s.hasFight {
+		return
+	}
 
-	drawOpts := &ebiten.DrawImageOptions{
-		Filter: ebiten.FilterLinear,
+	_, leftK := s.FightTimeLeft(now)
+	newLeft := deadline.Sub(now)
+	if leftK > 0.0 {
+		s.fightSpan = time.Duration(float64(newLeft) / leftK)
+	} else {
+		s.fightSpan = newLeft
 	}
+	s.fightDeadline = deadline
+}
 
-	// Roads
-	if s.HasNorthRoad() {
-		x := s.cX + s.cWidth/2.0 + s.rHeight/2.0
-		y := s.cY - s.rWidth
+// SetFightEnded drops "City on fight" flag.
+func (s *citySprite) SetFightEnded() {
+	s.hasFight = false
+	s.fightAlienIDs, s.fightPower, s.flashTicks = nil, 0, 0
+}
 
-		drawOpts.GeoM.Reset()
-		drawOpts.GeoM.Scale(s.rScaleX, s.rScaleY)
-		drawOpts.GeoM.Rotate(rotateCoefRads)
-		drawOpts.GeoM.Translate(x, y)
-		screen.DrawImage(s.rImage, drawOpts)
+// FightTimeLeft returns the ongoing fight time left and its fraction of the countdown span ([0.0, 1.0]).
+func (s *citySprite) FightTimeLeft(now time.Time) (time.Duration, float64) {
+	left := s.fightDeadline.Sub(now)
+	if left < 0 {
+		left = 0
+	}
+	if s.fightSpan <= 0 {
+		return left, 0.0
 	}
-	if s.HasEastRoad() {
-		x := s.cX + s.cWidth
-		y := s.cY + s.cHeight/2.0 - s.rHeight/2.0
 
-		drawOpts.GeoM.Reset()
-		drawOpts.GeoM.Scale(s.rScaleX, s.rScaleY)
-		drawOpts.GeoM.Translate(x, y)
-		screen.DrawImage(s.rImage, drawOpts)
+	return left, math.Min(1.0, float64(left)/float64(s.fightSpan))
+}
+
+// AddAlien marks an Alien being on tile.
+func (s *citySprite) AddAlien(alienID string) {
+	s.alienIDs[alienID] = true
+}
+
+// RemoveAlien unmarks an Alien being on tile.
+func (s *citySprite) RemoveAlien(alienID string) {
+	delete(s.alienIDs, alienID)
+}
+
+// AddHistory adds a City event to the history (the oldest ones are dropped).
+func (s *citySprite) AddHistory(msg string) {
+	const (
+		historySizeMax = 15
+	)
+
+	s.history = append(s.history, time.Now().Format("15:04:05")+" "+msg)
+	if len(s.history) > historySizeMax {
+		s.history = s.history[len(s.history)-historySizeMax:]
 	}
-	if s.HasSouthRoad() {
-		x := s.cX + s.cWidth/2.0 + s.rHeight/2.0
-		y := s.cY + s.cHeight
+}
 
-		drawOpts.GeoM.Reset()
-		drawOpts.GeoM.Scale(s.rScaleX, s.rScaleY)
-		drawOpts.GeoM.Rotate(rotateCoefRads)
-		drawOpts.GeoM.Translate(x, y)
-		screen.DrawImage(s.rImage, drawOpts)
+// Bounds returns the sprite abs coordinates rectangle (roads and name included).
+func (s *citySprite) Bounds() (x, y, width, height float64) {
+	x, y = s.cX-s.rWidth, s.cY-s.rWidth
+	width = s.cWidth + 2*s.rWidth
+	height = s.cHeight + 2*s.rWidth + float64(s.cFontOffsetY+s.cFontSize)
+
+	return
+}
+
+// Draw draws the sprite applying the {view} (camera) transformation, the City name is optional.
+// {now} defines the fight countdown time point (frozen while the simulation is paused).
+func (s *citySprite) Draw(screen *ebiten.Image, view ebiten.GeoM, withName bool, now time.Time) {
+	drawOpts := &ebiten.DrawImageOptions{
+		Filter: ebiten.FilterLinear,
 	}
-	if s.HasWestRoad() {
-		x := s.cX - s.rWidth
-		y := s.cY + s.cHeight/2.0 - s.rHeight/2.0
 
-		drawOpts.GeoM.Reset()
-		drawOpts.GeoM.Scale(s.rScaleX, s.rScaleY)
-		drawOpts.GeoM.Translate(x, y)
-		screen.DrawImage(s.rImage, drawOpts)
+	// Roads (alive ones and cut stubs)
+	for dir, cityID := range s.roads() {
+		if cityID != "" && !s.destroyed {
+			s.drawRoad(screen, view, dir, 1.0)
+		}
+	}
+	for dir, cut := range s.cutRoads {
+		if cut {
+			s.drawRoad(screen, view, dir, cityRoadStubK)
+		}
 	}
 
-	// City image
+	// City image (shaking during the destroy animation, darkened ruins)
 	{
+		x, y := s.cX, s.cY
+		if s.destroyTicks > 0 {
+			shift := cityDestroyShakeXY * float64(s.destroyTicks) / cityDestroyTicks
+			if s.destroyTicks%2 == 0 {
+				shift = -shift
+			}
+			x += shift
+		}
+
 		drawOpts.GeoM.Reset()
 		drawOpts.GeoM.Scale(s.cScaleX, s.cScaleY)
-		drawOpts.GeoM.Translate(s.cX, s.cY)
+		drawOpts.GeoM.Translate(x, y)
+		drawOpts.GeoM.Concat(view)
+		drawOpts.ColorM.Reset()
+		if s.destroyed {
+			drawOpts.ColorM.ChangeHSV(0.0, 0.2, cityRuinsValue)
+		}
 		screen.DrawImage(s.cImage, drawOpts)
 	}
 
+	// Destroy animation: expanding and fading blast
+	if s.destroyTicks > 0 {
+		progress := 1.0 - float64(s.destroyTicks)/cityDestroyTicks
+		scale := 0.5 + (cityDestroyBlastK-0.5)*progress
+		x := s.cX + s.cWidth/2.0 - s.bWidth*scale/2.0
+		y := s.cY + s.cHeight/2.0 - s.bHeight*scale/2.0
+
+		drawOpts.GeoM.Reset()
+		drawOpts.GeoM.Scale(s.bScaleX*scale, s.bScaleY*scale)
+		drawOpts.GeoM.Translate(x, y)
+		drawOpts.GeoM.Concat(view)
+		drawOpts.ColorM.Reset()
+		drawOpts.ColorM.Scale(1.0, 0.6, 0.2, 1.0-progress)
+		screen.DrawImage(s.bImage, drawOpts)
+	}
+
 	// Battle image
 	if s.hasFight {
 		x := s.cX + s.cWidth/2.0 - s.bWidth/2.0
@@ -302,103 +464,163 @@ func (s *citySprite) Draw(screen *ebiten.Image) {
 		drawOpts.GeoM.Reset()
 		drawOpts.GeoM.Scale(s.bScaleX, s.bScaleY)
 		drawOpts.GeoM.Translate(x, y)
+		drawOpts.GeoM.Concat(view)
+		drawOpts.ColorM.Reset()
 		screen.DrawImage(s.bImage, drawOpts)
+
+		s.drawFightRing(screen, view, now)
+		s.drawFightBadge(screen, view)
+	}
+
+	// Fight prolonged flash
+	if s.flashTicks > 0 {
+		s.fillRect(screen, view, s.cX, s.cY, s.cWidth, s.cHeight, 1.0, 1.0, 1.0, cityFightFlashAlpha*float64(s.flashTicks)/cityFightFlashTicks)
 	}
 
 	// City name
-	{
+	if withName {
 		x := s.cX
-		y := s.cY + s.cHeight
-		text.Draw(screen, s.Name, s.cFontFace, int(x), int(y)+s.cFontOffsetY, s.cFontColor)
+		y := s.cY + s.cHeight + float64(s.cFontOffsetY)
+
+		drawOpts.GeoM.Reset()
+		drawOpts.GeoM.Translate(x, y)
+		drawOpts.GeoM.Concat(view)
+		r, g, b, a := s.cFontColor.RGBA()
+		drawOpts.ColorM.Reset()
+		drawOpts.ColorM.Scale(float64(r)/0xFFFF, float64(g)/0xFFFF, float64(b)/0xFFFF, float64(a)/0xFFFF)
+		if s.destroyed {
+			drawOpts.ColorM.Scale(cityRuinsValue, cityRuinsValue, cityRuinsValue, 1.0)
+		}
+		text.DrawWithOptions(screen, s.Name, s.cFontFace, drawOpts)
 	}
 }
 
-// newCitySprites creates a new citySprites sprites set.
-// Constructor places all cities onto a sprites matrix (relative to other sprites tile matrix).
-func newCitySprites(cityMap model.CityMap, citySpriteOpts []citySpriteOption) (citySprites, error) {
-	sprites := make(citySprites, len(cityMap))
-	if len(cityMap) == 0 {
-		return sprites, nil
-	}
+// drawFightRing draws the fight countdown ring around the City image: the remaining time fraction is bright
+// (clockwise from the top, the heatmap color turns red as the deadline comes), the elapsed one is dimmed.
+func (s *citySprite) drawFightRing(screen *ebiten.Image, view ebiten.GeoM, now time.Time) {
+	_, leftK := s.FightTimeLeft(now)
+	leftSegments := int(math.Ceil(leftK * cityFightRingSegments))
 
-	// Add sprites without coordinates for now
-	var rootSprite *citySprite             // root sprite is used for starting xIdx and yIdx (0, 0)
-	spriteSet := make(map[string]struct{}) // set is used to track unprocessed sprites
-	for _, city := range cityMap {
-		sprite, err := newCitySprite(city, citySpriteOpts...)
-		if err != nil {
-			return nil, fmt.Errorf("creating citySprite (%s): %w", city.Name, err)
-		}
+	size := math.Min(s.cWidth, s.cHeight)
+	radius, segSize := size*cityFightRingRadiusK, size*cityFightRingSegmentK
+	centerX, centerY := s.cX+s.cWidth/2.0, s.cY+s.cHeight/2.0
+	r, g, b := heatmapColor(1.0 - leftK)
 
-		sprites[city.Name] = sprite
-		spriteSet[city.Name] = struct{}{}
+	drawOpts := &ebiten.DrawImageOptions{}
+	for i := 0; i < cityFightRingSegments; i++ {
+		angle := 2.0*math.Pi*float64(i)/cityFightRingSegments - math.Pi/2.0
 
-		if rootSprite == nil {
-			rootSprite = sprite
+		drawOpts.GeoM.Reset()
+		drawOpts.GeoM.Scale(segSize, segSize)
+		drawOpts.GeoM.Translate(-segSize/2.0, -segSize/2.0)
+		drawOpts.GeoM.Rotate(angle)
+		drawOpts.GeoM.Translate(centerX+radius*math.Cos(angle), centerY+radius*math.Sin(angle))
+		drawOpts.GeoM.Concat(view)
+		drawOpts.ColorM.Reset()
+		if i < leftSegments {
+			drawOpts.ColorM.Scale(r, g, b, 1.0)
+		} else {
+			drawOpts.ColorM.Scale(0.2, 0.2, 0.2, 0.6)
 		}
+		screen.DrawImage(s.pixel, drawOpts)
 	}
+}
 
-	// Distribute raw sprite matrix coordinates (raws can be negative)
-	xIdxMin, yIdxMin := math.MaxInt, math.MaxInt
+// drawFightBadge draws the fight participants number and their total power badge at the City image top-right corner.
+func (s *citySprite) drawFightBadge(screen *ebiten.Image, view ebiten.GeoM) {
+	badge := fmt.Sprintf("%d | P%d", len(s.fightAlienIDs), s.fightPower)
+	bounds := text.BoundString(s.cFontFace, badge)
 
-	var setCitySpriteNeighboursXYIdx func(sprite *citySprite) // anon func prototype
-	setCitySpriteNeighboursXYIdx = func(sprite *citySprite) {
-		// Skip if already handled
-		if _, ok := spriteSet[sprite.Name]; !ok {
-			return
-		}
+	width := float64(bounds.Dx())*cityFightBadgeTextK + 2*cityFightBadgePadding
+	height := float64(s.cFontSize)*cityFightBadgeTextK + 2*cityFightBadgePadding
+	x, y := s.cX+s.cWidth-width/2.0, s.cY-height/2.0
 
-		// Adjust XY mins
-		if sprite.xIdx < xIdxMin {
-			xIdxMin = sprite.xIdx
-		}
-		if sprite.yIdx < yIdxMin {
-			yIdxMin = sprite.yIdx
-		}
+	s.fillRect(screen, view, x, y, width, height, 0.6, 0.05, 0.05, 0.85)
 
-		// Remove from set (here to avoid stack overflow)
-		delete(spriteSet, sprite.Name)
+	drawOpts := &ebiten.DrawImageOptions{}
+	drawOpts.GeoM.Scale(cityFightBadgeTextK, cityFightBadgeTextK)
+	drawOpts.GeoM.Translate(x+cityFightBadgePadding-float64(bounds.Min.X)*cityFightBadgeTextK, y+height-cityFightBadgePadding)
+	drawOpts.GeoM.Concat(view)
+	text.DrawWithOptions(screen, badge, s.cFontFace, drawOpts)
+}
 
-		// Recursive calls for neighbours
-		if sprite.HasNorthRoad() {
-			sideSprite := sprites[sprite.NorthRoad]
-			sideSprite.SetLocation(sprite.xIdx, sprite.yIdx-1)
-			setCitySpriteNeighboursXYIdx(sideSprite)
-		}
-		if sprite.HasEastRoad() {
-			sideSprite := sprites[sprite.EastRoad]
-			sideSprite.SetLocation(sprite.xIdx+1, sprite.yIdx)
-			setCitySpriteNeighboursXYIdx(sideSprite)
-		}
-		if sprite.HasSouthRoad() {
-			sideSprite := sprites[sprite.SouthRoad]
-			sideSprite.SetLocation(sprite.xIdx, sprite.yIdx+1)
-			setCitySpriteNeighboursXYIdx(sideSprite)
-		}
-		if sprite.HasWestRoad() {
-			sideSprite := sprites[sprite.WestRoad]
-			sideSprite.SetLocation(sprite.xIdx-1, sprite.yIdx)
-			setCitySpriteNeighboursXYIdx(sideSprite)
-		}
-	}
+// fillRect fills a rectangle (abs coordinates) applying the {view} (camera) transformation.
+func (s *citySprite) fillRect(screen *ebiten.Image, view ebiten.GeoM, x, y, width, height float64, r, g, b, a float64) {
+	drawOpts := &ebiten.DrawImageOptions{}
+	drawOpts.GeoM.Scale(width, height)
+	drawOpts.GeoM.Translate(x, y)
+	drawOpts.GeoM.Concat(view)
+	drawOpts.ColorM.Scale(r, g, b, a)
+	screen.DrawImage(s.pixel, drawOpts)
+}
+
+// drawRoad draws a road (or its stub) in the direction, {lengthK} defines the length relative to the road one.
+// Cut stubs are darkened.
+func (s *citySprite) drawRoad(screen *ebiten.Image, view ebiten.GeoM, dir int, lengthK float64) {
+	const (
+		rotateCoefRads = 90.0 * math.Pi / 180.0
+	)
 
-	setCitySpriteNeighboursXYIdx(rootSprite)
+	var x, y float64
+	rotated := false
+	switch dir {
+	case roadNorth:
+		x, y = s.cX+s.cWidth/2.0+s.rHeight/2.0, s.cY-s.rWidth*lengthK
+		rotated = true
+	case roadEast:
+		x, y = s.cX+s.cWidth, s.cY+s.cHeight/2.0-s.rHeight/2.0
+	case roadSouth:
+		x, y = s.cX+s.cWidth/2.0+s.rHeight/2.0, s.cY+s.cHeight
+		rotated = true
+	case roadWest:
+		x, y = s.cX-s.rWidth*lengthK, s.cY+s.cHeight/2.0-s.rHeight/2.0
+	}
 
-	// Set sprite matrix coordinates for leftovers (without neighbours)
-	// Extra column on the left is created
-	yIdxLeftover := yIdxMin
-	if len(spriteSet) > 0 {
-		xIdxMin--
+	drawOpts := &ebiten.DrawImageOptions{
+		Filter: ebiten.FilterLinear,
+	}
+	drawOpts.GeoM.Scale(s.rScaleX*lengthK, s.rScaleY)
+	if rotated {
+		drawOpts.GeoM.Rotate(rotateCoefRads)
+	}
+	drawOpts.GeoM.Translate(x, y)
+	drawOpts.GeoM.Concat(view)
+	if lengthK < 1.0 {
+		drawOpts.ColorM.ChangeHSV(0.0, 0.3, cityRuinsValue)
 	}
-	for cityID := range spriteSet {
-		sprite := sprites[cityID]
-		sprite.SetLocation(xIdxMin, yIdxLeftover)
-		yIdxLeftover++
+	screen.DrawImage(s.rImage, drawOpts)
+}
+
+// roads returns the City roads by direction.
+func (s *citySprite) roads() [4]string {
+	return roadsOf(s.City)
+}
+
+// roadsOf returns City roads by direction.
+func roadsOf(city model.City) [4]string {
+	return [4]string{
+		roadNorth: city.NorthRoad,
+		roadEast:  city.EastRoad,
+		roadSouth: city.SouthRoad,
+		roadWest:  city.WestRoad,
 	}
+}
+
+// newCitySprites creates a new citySprites sprites set.
+// Constructor places all cities onto a sprites matrix using the shared grid layout (relative to other sprites tile matrix).
+func newCitySprites(cityMap model.CityMap, citySpriteOpts []citySpriteOption) (citySprites, error) {
+	sprites := make(citySprites, len(cityMap))
+	grid := layout.New(cityMap)
+
+	for _, city := range cityMap {
+		sprite, err := newCitySprite(city, citySpriteOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating citySprite (%s): %w", city.Name, err)
+		}
 
-	// Adjust sprite matrix coordinates to remove negative ones
-	for _, sprite := range sprites {
-		sprite.SetLocation(sprite.xIdx-xIdxMin, sprite.yIdx-yIdxMin)
+		pos := grid.Positions[city.Name]
+		sprite.SetLocation(pos.X, pos.Y)
+		sprites[city.Name] = sprite
 	}
 
 	return sprites, nil
@@ -0,0 +1,199 @@
+package types
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Control buttons IDs.
+const (
+	ControlPause   = "pause" // pause / resume toggle
+	ControlStep    = "step"
+	ControlSlower  = "slower"
+	ControlFaster  = "faster"
+	ControlRestart = "restart"
+	ControlQuit    = "quit"
+)
+
+type (
+	// controlsSprite keeps the simulation controls bar data (buttons and the speed / phase info line).
+	controlsSprite struct {
+		sX, sY int // top-left location
+
+		tFontFace  font.Face   // font
+		tFontColor color.Color // text color
+		tFontSize  int         // font size
+
+		buttons []controlButton
+		info    string // info line
+		paused  bool   // pause button shows the "resume" label
+
+		pixel *ebiten.Image // 1x1 image used to fill buttons background
+	}
+
+	// controlButton keeps a single control button data.
+	controlButton struct {
+		id          string
+		label       string
+		pausedLabel string          // label used while the simulation is paused (optional)
+		rect        image.Rectangle // abs coordinates
+	}
+
+	controlsSpriteOption func(s *controlsSprite) error
+)
+
+// controlsButtonPadding defines the button label padding.
+const controlsButtonPadding = 8
+
+// withControlsLocation sets Controls bar location.
+func withControlsLocation(x, y int) controlsSpriteOption {
+	return func(s *controlsSprite) error {
+		if x < 0 {
+			return fmt.Errorf("controls bar X: must be GTE 0")
+		}
+		if y < 0 {
+			return fmt.Errorf("controls bar Y: must be GTE 0")
+		}
+
+		s.sX, s.sY = x, y
+
+		return nil
+	}
+}
+
+// withControlsFont sets Controls bar text params.
+func withControlsFont(fFace font.Face, fColor color.Color, fSize int) controlsSpriteOption {
+	return func(s *controlsSprite) error {
+		if fFace == nil {
+			return fmt.Errorf("controls fontFace: nil")
+		}
+		if fColor == nil {
+			return fmt.Errorf("controls fontColor: nil")
+		}
+		if fSize <= 0 {
+			return fmt.Errorf("controls fontSize: must be GT 0")
+		}
+
+		s.tFontFace = fFace
+		s.tFontColor = fColor
+		s.tFontSize = fSize
+
+		return nil
+	}
+}
+
+// newControlsSprite creates a controlsSprite instance with buttons placed in a row.
+func newControlsSprite(opts ...controlsSpriteOption) (*controlsSprite, error) {
+	// Build
+	s := controlsSprite{
+		buttons: []controlButton{
+			{id: ControlPause, label: "Pause [Space]", pausedLabel: "Resume [Space]"},
+			{id: ControlStep, label: "Step [N]"},
+			{id: ControlSlower, label: "Slower [-]"},
+			{id: ControlFaster, label: "Faster [+]"},
+			{id: ControlRestart, label: "Restart [R]"},
+			{id: ControlQuit, label: "Quit [Q]"},
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate
+	if s.tFontFace == nil {
+		return nil, fmt.Errorf("controls fontFace: nil")
+	}
+
+	s.placeButtons()
+
+	s.pixel = ebiten.NewImage(1, 1)
+	s.pixel.Fill(color.White)
+
+	return &s, nil
+}
+
+// SetLocation moves the Controls bar.
+func (s *controlsSprite) SetLocation(x, y int) {
+	s.sX, s.sY = x, y
+	s.placeButtons()
+}
+
+// SetState updates the pause button label and the info line.
+func (s *controlsSprite) SetState(paused bool, info string) {
+	s.paused, s.info = paused, info
+}
+
+// ButtonAt returns the control ID of a button under the abs coordinates.
+func (s *controlsSprite) ButtonAt(x, y int) (string, bool) {
+	p := image.Pt(x, y)
+	for _, b := range s.buttons {
+		if p.In(b.rect) {
+			return b.id, true
+		}
+	}
+
+	return "", false
+}
+
+// placeButtons places buttons in a row (the widest label defines the button width).
+func (s *controlsSprite) placeButtons() {
+	x := s.sX + controlsButtonPadding
+	for i := range s.buttons {
+		b := &s.buttons[i]
+
+		width := text.BoundString(s.tFontFace, b.label).Dx()
+		if w := text.BoundString(s.tFontFace, b.pausedLabel).Dx(); w > width {
+			width = w
+		}
+		width += 2 * controlsButtonPadding
+
+		b.rect = image.Rect(x, s.sY+controlsButtonPadding, x+width, s.sY+controlsButtonPadding+s.tFontSize+controlsButtonPadding)
+		x += width + controlsButtonPadding
+	}
+}
+
+// Width returns the Controls bar width.
+func (s *controlsSprite) Width() int {
+	if len(s.buttons) == 0 {
+		return 0
+	}
+
+	return s.buttons[len(s.buttons)-1].rect.Max.X - s.sX + controlsButtonPadding
+}
+
+// Height returns the Controls bar height (buttons row and the info line).
+func (s *controlsSprite) Height() int {
+	return 2*s.tFontSize + 4*controlsButtonPadding
+}
+
+// Draw implements the ebiten.Game interface.
+func (s *controlsSprite) Draw(screen *ebiten.Image) {
+	drawOpts := &ebiten.DrawImageOptions{}
+
+	for _, b := range s.buttons {
+		label := b.label
+		if s.paused && b.pausedLabel != "" {
+			label = b.pausedLabel
+		}
+
+		drawOpts.GeoM.Reset()
+		drawOpts.GeoM.Scale(float64(b.rect.Dx()), float64(b.rect.Dy()))
+		drawOpts.GeoM.Translate(float64(b.rect.Min.X), float64(b.rect.Min.Y))
+		drawOpts.ColorM.Reset()
+		drawOpts.ColorM.Scale(0.25, 0.25, 0.3, 1.0)
+		screen.DrawImage(s.pixel, drawOpts)
+
+		text.Draw(screen, label, s.tFontFace, b.rect.Min.X+controlsButtonPadding, b.rect.Max.Y-controlsButtonPadding/2, s.tFontColor)
+	}
+
+	infoY := s.sY + 2*s.tFontSize + 3*controlsButtonPadding
+	text.Draw(screen, s.info, s.tFontFace, s.sX+controlsButtonPadding, infoY, s.tFontColor)
+}
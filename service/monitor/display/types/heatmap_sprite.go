@@ -0,0 +1,292 @@
+package types
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Heatmap overlay modes (the City metric used to tint).
+const (
+	heatmapOff         = iota
+	heatmapFights      // number of fights started
+	heatmapAliens      // number of Aliens passed (landed or arrived)
+	heatmapDestruction // City destruction probability (batch report)
+)
+
+type (
+	// heatmapSprite keeps the heatmap overlay data collected from World events.
+	// Cities are tinted by the selected metric, roads are tinted by the Aliens traffic (moves in both directions).
+	// Cities geometry is captured on Reset, so destroyed cities stay on the overlay.
+	heatmapSprite struct {
+		sX, sY int // legend bottom-left location
+
+		tFontFace  font.Face   // font
+		tFontColor color.Color // text color
+
+		mode         int                   // heatmap mode
+		cityRects    map[string][4]float64 // City image abs rectangle (x, y, width, height)
+		fights       map[string]int        // key: CityID
+		passed       map[string]int        // key: CityID
+		traffic      map[heatmapRoad]int   // Aliens moves by a road
+		destroyProbs map[string]float64    // key: CityID (optional)
+
+		pixel *ebiten.Image // 1x1 image used to fill rectangles
+	}
+
+	// heatmapRoad defines a two-way road key (city IDs are sorted).
+	heatmapRoad struct {
+		cityA, cityB string
+	}
+
+	heatmapSpriteOption func(s *heatmapSprite) error
+)
+
+// withHeatmapFont sets the heatmap legend text params.
+func withHeatmapFont(fFace font.Face, fColor color.Color) heatmapSpriteOption {
+	return func(s *heatmapSprite) error {
+		if fFace == nil {
+			return fmt.Errorf("heatmap fontFace: nil")
+		}
+		if fColor == nil {
+			return fmt.Errorf("heatmap fontColor: nil")
+		}
+
+		s.tFontFace, s.tFontColor = fFace, fColor
+
+		return nil
+	}
+}
+
+// withHeatmapDestroyProbs sets City destruction probabilities (enables the destruction heatmap mode).
+func withHeatmapDestroyProbs(probs map[string]float64) heatmapSpriteOption {
+	return func(s *heatmapSprite) error {
+		for cityID, p := range probs {
+			if p < 0.0 || p > 1.0 {
+				return fmt.Errorf("heatmap destruction probability (%s): must be in [0.0, 1.0] range", cityID)
+			}
+		}
+		s.destroyProbs = probs
+
+		return nil
+	}
+}
+
+// newHeatmapSprite creates a heatmapSprite instance (the overlay is off).
+func newHeatmapSprite(opts ...heatmapSpriteOption) (*heatmapSprite, error) {
+	// Build
+	s := heatmapSprite{}
+
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate
+	if s.tFontFace == nil {
+		return nil, fmt.Errorf("heatmap fontFace: nil")
+	}
+
+	s.Reset(nil)
+
+	s.pixel = ebiten.NewImage(1, 1)
+	s.pixel.Fill(color.White)
+
+	return &s, nil
+}
+
+// Reset drops collected stats and captures Cities geometry (simulation restart).
+func (s *heatmapSprite) Reset(cities citySprites) {
+	s.cityRects = make(map[string][4]float64, len(cities))
+	for cityID, sprite := range cities {
+		s.cityRects[cityID] = [4]float64{sprite.cX, sprite.cY, sprite.cWidth, sprite.cHeight}
+	}
+
+	s.fights = make(map[string]int)
+	s.passed = make(map[string]int)
+	s.traffic = make(map[heatmapRoad]int)
+}
+
+// SetLocation moves the legend.
+func (s *heatmapSprite) SetLocation(x, y int) {
+	s.sX, s.sY = x, y
+}
+
+// Enabled checks if the overlay is on.
+func (s *heatmapSprite) Enabled() bool {
+	return s.mode != heatmapOff
+}
+
+// CycleMode switches to the next mode (the destruction mode is skipped if there are no probabilities) and returns its name.
+func (s *heatmapSprite) CycleMode() string {
+	s.mode++
+	if s.mode == heatmapDestruction && len(s.destroyProbs) == 0 {
+		s.mode++
+	}
+	if s.mode > heatmapDestruction {
+		s.mode = heatmapOff
+	}
+
+	return s.modeName()
+}
+
+// AddFight counts a City fight.
+func (s *heatmapSprite) AddFight(cityID string) {
+	s.fights[cityID]++
+}
+
+// AddPass counts an Alien passing a City (landing or arrival).
+func (s *heatmapSprite) AddPass(cityID string) {
+	s.passed[cityID]++
+}
+
+// AddTravel counts an Alien move by a road.
+func (s *heatmapSprite) AddTravel(fromCityID, toCityID string) {
+	s.traffic[newHeatmapRoad(fromCityID, toCityID)]++
+}
+
+// Draw draws the overlay applying the {view} (camera) transformation and the legend (screen coordinates).
+func (s *heatmapSprite) Draw(screen *ebiten.Image, view ebiten.GeoM) {
+	const (
+		roadWidth = 14.0
+		cityAlpha = 0.6
+		roadAlpha = 0.8
+	)
+
+	if !s.Enabled() {
+		return
+	}
+
+	drawOpts := &ebiten.DrawImageOptions{}
+
+	// Roads
+	trafficMax := 1
+	for _, cnt := range s.traffic {
+		if cnt > trafficMax {
+			trafficMax = cnt
+		}
+	}
+	for road, cnt := range s.traffic {
+		rectA, okA := s.cityRects[road.cityA]
+		rectB, okB := s.cityRects[road.cityB]
+		if !okA || !okB {
+			continue
+		}
+
+		ax, ay := rectA[0]+rectA[2]/2.0, rectA[1]+rectA[3]/2.0
+		bx, by := rectB[0]+rectB[2]/2.0, rectB[1]+rectB[3]/2.0
+
+		drawOpts.GeoM.Reset()
+		drawOpts.GeoM.Scale(math.Hypot(bx-ax, by-ay), roadWidth)
+		drawOpts.GeoM.Translate(0, -roadWidth/2.0)
+		drawOpts.GeoM.Rotate(math.Atan2(by-ay, bx-ax))
+		drawOpts.GeoM.Translate(ax, ay)
+		drawOpts.GeoM.Concat(view)
+		drawOpts.ColorM.Reset()
+		r, g, b := heatmapColor(float64(cnt) / float64(trafficMax))
+		drawOpts.ColorM.Scale(r, g, b, roadAlpha)
+		screen.DrawImage(s.pixel, drawOpts)
+	}
+
+	// Cities
+	valueMax := 0.0
+	for cityID := range s.cityRects {
+		valueMax = math.Max(valueMax, s.cityValue(cityID))
+	}
+	if s.mode == heatmapDestruction || valueMax == 0.0 {
+		valueMax = math.Max(valueMax, 1.0)
+	}
+	for cityID, rect := range s.cityRects {
+		drawOpts.GeoM.Reset()
+		drawOpts.GeoM.Scale(rect[2], rect[3])
+		drawOpts.GeoM.Translate(rect[0], rect[1])
+		drawOpts.GeoM.Concat(view)
+		drawOpts.ColorM.Reset()
+		r, g, b := heatmapColor(s.cityValue(cityID) / valueMax)
+		drawOpts.ColorM.Scale(r, g, b, cityAlpha)
+		screen.DrawImage(s.pixel, drawOpts)
+	}
+
+	s.drawLegend(screen, valueMax, trafficMax)
+}
+
+// drawLegend draws the mode name and the color scale with its max values.
+func (s *heatmapSprite) drawLegend(screen *ebiten.Image, valueMax float64, trafficMax int) {
+	const (
+		scaleSteps      = 20
+		scaleStepWidth  = 8
+		scaleHeight     = 12
+		scaleTextOffset = 6
+	)
+
+	drawOpts := &ebiten.DrawImageOptions{}
+	for i := 0; i < scaleSteps; i++ {
+		drawOpts.GeoM.Reset()
+		drawOpts.GeoM.Scale(scaleStepWidth, scaleHeight)
+		drawOpts.GeoM.Translate(float64(s.sX+i*scaleStepWidth), float64(s.sY-scaleHeight))
+		drawOpts.ColorM.Reset()
+		r, g, b := heatmapColor(float64(i) / float64(scaleSteps-1))
+		drawOpts.ColorM.Scale(r, g, b, 1.0)
+		screen.DrawImage(s.pixel, drawOpts)
+	}
+
+	maxText := fmt.Sprintf("%.0f", valueMax)
+	if s.mode == heatmapDestruction {
+		maxText = fmt.Sprintf("%.0f%%", valueMax*100.0)
+	}
+	legend := fmt.Sprintf("Heatmap: %s (max %s), roads: moves (max %d)", s.modeName(), maxText, trafficMax)
+	text.Draw(screen, legend, s.tFontFace, s.sX+scaleSteps*scaleStepWidth+scaleTextOffset, s.sY, s.tFontColor)
+}
+
+// cityValue returns the City metric value for the current mode.
+func (s *heatmapSprite) cityValue(cityID string) float64 {
+	switch s.mode {
+	case heatmapFights:
+		return float64(s.fights[cityID])
+	case heatmapAliens:
+		return float64(s.passed[cityID])
+	case heatmapDestruction:
+		return s.destroyProbs[cityID]
+	}
+
+	return 0.0
+}
+
+// modeName returns the current mode name.
+func (s *heatmapSprite) modeName() string {
+	switch s.mode {
+	case heatmapFights:
+		return "fights"
+	case heatmapAliens:
+		return "aliens passed"
+	case heatmapDestruction:
+		return "destruction probability"
+	}
+
+	return "off"
+}
+
+// newHeatmapRoad creates a two-way road key.
+func newHeatmapRoad(cityA, cityB string) heatmapRoad {
+	if cityB < cityA {
+		cityA, cityB = cityB, cityA
+	}
+
+	return heatmapRoad{cityA: cityA, cityB: cityB}
+}
+
+// heatmapColor returns the color for a [0.0, 1.0] value: blue (cold) -> yellow -> red (hot).
+func heatmapColor(v float64) (r, g, b float64) {
+	v = math.Max(0.0, math.Min(1.0, v))
+	if v < 0.5 {
+		k := v / 0.5
+		return k, k, 1.0 - k
+	}
+
+	return 1.0, 1.0 - (v-0.5)/0.5, 0.0
+}
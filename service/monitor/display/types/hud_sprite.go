@@ -0,0 +1,216 @@
+package types
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/itiky/alienInvasion/model"
+	"golang.org/x/image/font"
+)
+
+type (
+	// hudSprite keeps the simulation status HUD data: elapsed time and state counters with their sparkline charts.
+	hudSprite struct {
+		sX, sY int // top-left location
+
+		tFontFace  font.Face   // font
+		tFontColor color.Color // text color
+		tFontSize  int         // font size
+
+		samplesMax int // max number of samples kept per series (sparkline width in bars)
+
+		elapsed string      // elapsed time line
+		series  []hudSeries // counters
+		lastAt  time.Duration
+		sampled bool // at least one status is sampled
+
+		pixel *ebiten.Image // 1x1 image used to draw sparklines
+	}
+
+	// hudSeries keeps a single counter samples.
+	hudSeries struct {
+		label   string
+		value   string  // current value text
+		samples []int   // oldest first
+		r, g, b float64 // sparkline color
+	}
+
+	hudSpriteOption func(s *hudSprite) error
+)
+
+// HUD sparkline params.
+const (
+	hudPadding     = 6
+	hudLabelWidth  = 150 // label and value column width
+	hudBarWidth    = 2   // sparkline bar width
+	hudSparkHeight = 14  // sparkline height
+)
+
+// withHUDFont sets HUD text params.
+func withHUDFont(fFace font.Face, fColor color.Color, fSize int) hudSpriteOption {
+	return func(s *hudSprite) error {
+		if fFace == nil {
+			return fmt.Errorf("HUD fontFace: nil")
+		}
+		if fColor == nil {
+			return fmt.Errorf("HUD fontColor: nil")
+		}
+		if fSize <= 0 {
+			return fmt.Errorf("HUD fontSize: must be GT 0")
+		}
+
+		s.tFontFace = fFace
+		s.tFontColor = fColor
+		s.tFontSize = fSize
+
+		return nil
+	}
+}
+
+// withHUDSamples sets the max number of samples shown by sparklines.
+func withHUDSamples(samplesMax int) hudSpriteOption {
+	return func(s *hudSprite) error {
+		if samplesMax <= 0 {
+			return fmt.Errorf("HUD samples max: must be GT 0")
+		}
+
+		s.samplesMax = samplesMax
+
+		return nil
+	}
+}
+
+// newHUDSprite creates a hudSprite instance.
+func newHUDSprite(opts ...hudSpriteOption) (*hudSprite, error) {
+	// Build
+	s := hudSprite{
+		samplesMax: 60,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate
+	if s.tFontFace == nil {
+		return nil, fmt.Errorf("HUD fontFace: nil")
+	}
+
+	s.Reset()
+
+	s.pixel = ebiten.NewImage(1, 1)
+	s.pixel.Fill(color.White)
+
+	return &s, nil
+}
+
+// Reset drops all samples (simulation restart).
+func (s *hudSprite) Reset() {
+	s.elapsed, s.lastAt, s.sampled = "Elapsed: -", 0, false
+	s.series = []hudSeries{
+		{label: "Aliens", r: 0.4, g: 1.0, b: 0.4},
+		{label: "Waiting", r: 0.6, g: 0.6, b: 1.0},
+		{label: "Cities", r: 1.0, g: 0.85, b: 0.3},
+		{label: "Fights", r: 1.0, g: 0.35, b: 0.35},
+	}
+}
+
+// SetLocation moves the HUD.
+func (s *hudSprite) SetLocation(x, y int) {
+	s.sX, s.sY = x, y
+}
+
+// AddStatus updates counters with a simulation status.
+// A new sparkline sample is added only if the simulated time has advanced (a paused simulation reports the same values).
+func (s *hudSprite) AddStatus(status model.SimStatus) {
+	s.elapsed = fmt.Sprintf("Elapsed: %s (wall %s)", status.SimElapsed.Round(100*time.Millisecond), status.WallElapsed.Round(100*time.Millisecond))
+
+	values := []int{status.Aliens, status.AliensWaiting, status.Cities, status.Fights}
+	texts := []string{
+		fmt.Sprintf("%d", status.Aliens),
+		fmt.Sprintf("%d", status.AliensWaiting),
+		fmt.Sprintf("%d / %d", status.Cities, status.CitiesInitial),
+		fmt.Sprintf("%d", status.Fights),
+	}
+
+	addSample := !s.sampled || status.SimElapsed > s.lastAt
+	s.lastAt, s.sampled = status.SimElapsed, true
+
+	for i := range s.series {
+		series := &s.series[i]
+		series.value = texts[i]
+		if !addSample {
+			continue
+		}
+
+		series.samples = append(series.samples, values[i])
+		if len(series.samples) > s.samplesMax {
+			series.samples = series.samples[len(series.samples)-s.samplesMax:]
+		}
+	}
+}
+
+// Width returns the HUD width.
+func (s *hudSprite) Width() int {
+	return hudLabelWidth + s.samplesMax*hudBarWidth + 3*hudPadding
+}
+
+// Height returns the HUD height.
+func (s *hudSprite) Height() int {
+	return (len(s.series)+1)*s.lineHeight() + 2*hudPadding
+}
+
+// Draw implements the ebiten.Game interface.
+func (s *hudSprite) Draw(screen *ebiten.Image) {
+	lineHeight := s.lineHeight()
+
+	drawOpts := &ebiten.DrawImageOptions{}
+	drawOpts.GeoM.Scale(float64(s.Width()), float64(s.Height()))
+	drawOpts.GeoM.Translate(float64(s.sX), float64(s.sY))
+	drawOpts.ColorM.Scale(0.05, 0.05, 0.08, 0.75)
+	screen.DrawImage(s.pixel, drawOpts)
+
+	x, y := s.sX+hudPadding, s.sY+hudPadding+s.tFontSize
+	text.Draw(screen, s.elapsed, s.tFontFace, x, y, s.tFontColor)
+
+	for _, series := range s.series {
+		y += lineHeight
+		text.Draw(screen, series.label+": "+series.value, s.tFontFace, x, y, s.tFontColor)
+		s.drawSparkline(screen, series, x+hudLabelWidth+hudPadding, y)
+	}
+}
+
+// drawSparkline draws series samples as bars scaled to the series max value (bottom-left is the base point).
+func (s *hudSprite) drawSparkline(screen *ebiten.Image, series hudSeries, x, y int) {
+	valueMax := 1
+	for _, v := range series.samples {
+		if v > valueMax {
+			valueMax = v
+		}
+	}
+
+	drawOpts := &ebiten.DrawImageOptions{}
+	for i, v := range series.samples {
+		height := float64(v) / float64(valueMax) * hudSparkHeight
+		if height < 1 {
+			height = 1 // zero values are still visible
+		}
+
+		drawOpts.GeoM.Reset()
+		drawOpts.GeoM.Scale(hudBarWidth, height)
+		drawOpts.GeoM.Translate(float64(x+i*hudBarWidth), float64(y)-height)
+		drawOpts.ColorM.Reset()
+		drawOpts.ColorM.Scale(series.r, series.g, series.b, 1.0)
+		screen.DrawImage(s.pixel, drawOpts)
+	}
+}
+
+// lineHeight returns the text line height.
+func (s *hudSprite) lineHeight() int {
+	return s.tFontSize + s.tFontSize/2
+}
@@ -0,0 +1,159 @@
+package types
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+type (
+	// inspectSprite keeps the selected City / Alien details panel data.
+	inspectSprite struct {
+		sX, sY int // top-left location
+
+		tFontFace  font.Face   // font
+		tFontColor color.Color // text color
+		tFontSize  int         // font size
+
+		width      int // panel width
+		lineLenMax int // max line length (longer lines are wrapped)
+
+		lines []string      // panel text (wrapped)
+		pixel *ebiten.Image // 1x1 image used to fill the background
+	}
+
+	inspectSpriteOption func(s *inspectSprite) error
+)
+
+// inspectPadding defines the panel text padding.
+const inspectPadding = 10
+
+// withInspectFont sets Inspect panel text params.
+func withInspectFont(fFace font.Face, fColor color.Color, fSize int) inspectSpriteOption {
+	return func(s *inspectSprite) error {
+		if fFace == nil {
+			return fmt.Errorf("inspect fontFace: nil")
+		}
+		if fColor == nil {
+			return fmt.Errorf("inspect fontColor: nil")
+		}
+		if fSize <= 0 {
+			return fmt.Errorf("inspect fontSize: must be GT 0")
+		}
+
+		s.tFontFace = fFace
+		s.tFontColor = fColor
+		s.tFontSize = fSize
+
+		return nil
+	}
+}
+
+// withInspectSize sets Inspect panel width and the max line length.
+func withInspectSize(width, lineLenMax int) inspectSpriteOption {
+	return func(s *inspectSprite) error {
+		if width <= 0 {
+			return fmt.Errorf("inspect width: must be GT 0")
+		}
+		if lineLenMax <= 0 {
+			return fmt.Errorf("inspect line max length: must be GT 0")
+		}
+
+		s.width, s.lineLenMax = width, lineLenMax
+
+		return nil
+	}
+}
+
+// newInspectSprite creates an inspectSprite instance.
+func newInspectSprite(opts ...inspectSpriteOption) (*inspectSprite, error) {
+	// Build
+	s := inspectSprite{
+		width:      320,
+		lineLenMax: 32,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate
+	if s.tFontFace == nil {
+		return nil, fmt.Errorf("inspect fontFace: nil")
+	}
+
+	s.pixel = ebiten.NewImage(1, 1)
+	s.pixel.Fill(color.White)
+
+	return &s, nil
+}
+
+// SetLocation moves the Inspect panel.
+func (s *inspectSprite) SetLocation(x, y int) {
+	s.sX, s.sY = x, y
+}
+
+// Width returns the Inspect panel width.
+func (s *inspectSprite) Width() int {
+	return s.width
+}
+
+// SetLines updates the panel text (long lines are wrapped).
+func (s *inspectSprite) SetLines(lines []string) {
+	s.lines = s.lines[:0]
+	for _, line := range lines {
+		s.lines = append(s.lines, wrapLine(line, s.lineLenMax)...)
+	}
+}
+
+// Draw implements the ebiten.Game interface.
+func (s *inspectSprite) Draw(screen *ebiten.Image) {
+	if len(s.lines) == 0 {
+		return
+	}
+
+	lineHeight := s.tFontSize + s.tFontSize/2
+	height := len(s.lines)*lineHeight + 2*inspectPadding
+
+	drawOpts := &ebiten.DrawImageOptions{}
+	drawOpts.GeoM.Scale(float64(s.width), float64(height))
+	drawOpts.GeoM.Translate(float64(s.sX), float64(s.sY))
+	drawOpts.ColorM.Scale(0.05, 0.05, 0.08, 0.85)
+	screen.DrawImage(s.pixel, drawOpts)
+
+	for i, line := range s.lines {
+		y := s.sY + inspectPadding + s.tFontSize + i*lineHeight
+		text.Draw(screen, line, s.tFontFace, s.sX+inspectPadding, y, s.tFontColor)
+	}
+}
+
+// wrapLine splits a line into lines of {lenMax} runes max (by words if possible), continuation lines are indented.
+func wrapLine(line string, lenMax int) []string {
+	const (
+		indent = "  "
+	)
+
+	var lines []string
+
+	runes := []rune(line)
+	for len(runes) > lenMax {
+		cut := lenMax
+		for i := lenMax; i > lenMax/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+
+		lines = append(lines, string(runes[:cut]))
+		runes = []rune(indent + strings.TrimLeft(string(runes[cut:]), " "))
+	}
+
+	return append(lines, string(runes))
+}
@@ -1,36 +1,53 @@
 package types
 
 import (
-	"container/ring"
 	"fmt"
 	"image/color"
-	"strings"
-	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font"
 )
 
+// Status events log message kinds (used by the log filter).
+const (
+	msgKindSim   = "sim"   // simulation phases, controls and errors
+	msgKindCity  = "city"  // City fights and destruction
+	msgKindAlien = "alien" // Aliens landing, moves and removal
+)
+
+// statusFilters defines the events log filters order (empty kind shows all messages).
+var statusFilters = []string{"", msgKindSim, msgKindCity, msgKindAlien}
+
 type (
+	// statusSprite keeps the events log data: the last {logSize} messages, {msgBufSize} of them are shown.
+	// The log can be scrolled back and filtered by a message kind.
 	statusSprite struct {
-		sync.RWMutex
-
-		sX, sY int
+		sX, sY int // top-left location
 
 		tFontFace  font.Face   // font
 		tFontColor color.Color // text color
 		tFontSize  int         // font size
 
-		msgBuf     *ring.Ring
-		msgBufSize int
-		msgLenMax  int
+		msgs       []statusMsg // log (oldest first)
+		logSize    int         // max number of messages kept
+		msgBufSize int         // number of messages shown
+		msgLenMax  int         // message max length (longer ones are cut)
+
+		filterIdx int // statusFilters index
+		scroll    int // number of (filtered) messages skipped from the log end
+	}
+
+	// statusMsg keeps a single events log message.
+	statusMsg struct {
+		kind string
+		text string
 	}
 
 	statusSpriteOption func(s *statusSprite) error
 )
 
-// withStatusWindowSize sets Status window size.
+// withStatusWindowLocation sets Status window location.
 func withStatusWindowLocation(x, y int) statusSpriteOption {
 	return func(s *statusSprite) error {
 		if x < 0 {
@@ -46,7 +63,7 @@ func withStatusWindowLocation(x, y int) statusSpriteOption {
 	}
 }
 
-// withStatusWindowSize sets Status window size.
+// withStatusMsgBuffer sets the number of messages shown and the message max length.
 func withStatusMsgBuffer(size, msgMaxLen int) statusSpriteOption {
 	return func(s *statusSprite) error {
 		if size <= 0 {
@@ -62,6 +79,19 @@ func withStatusMsgBuffer(size, msgMaxLen int) statusSpriteOption {
 	}
 }
 
+// withStatusLogSize sets the max number of messages kept for scrolling.
+func withStatusLogSize(size int) statusSpriteOption {
+	return func(s *statusSprite) error {
+		if size <= 0 {
+			return fmt.Errorf("status log size: must be GT 0")
+		}
+
+		s.logSize = size
+
+		return nil
+	}
+}
+
 // withStatusNameFont sets Status msg text params.
 func withStatusNameFont(fFace font.Face, fColor color.Color, fSize, fOffsetXY int) statusSpriteOption {
 	return func(s *statusSprite) error {
@@ -89,6 +119,7 @@ func withStatusNameFont(fFace font.Face, fColor color.Color, fSize, fOffsetXY in
 func newStatusSprite(opts ...statusSpriteOption) (*statusSprite, error) {
 	// Build
 	s := statusSprite{
+		logSize:    100,
 		msgBufSize: 5,
 		msgLenMax:  20,
 	}
@@ -99,46 +130,134 @@ func newStatusSprite(opts ...statusSpriteOption) (*statusSprite, error) {
 		}
 	}
 
-	s.msgBuf = ring.New(s.msgBufSize)
-
 	// Validate
 	if s.tFontFace == nil {
 		return nil, fmt.Errorf("status fontFace: nil")
 	}
+	if s.logSize < s.msgBufSize {
+		s.logSize = s.msgBufSize
+	}
 
 	return &s, nil
 }
 
-func (s *statusSprite) AddMsg(msg string) {
-	s.Lock()
-	defer s.Unlock()
-
+// AddMsg appends a message of the kind to the log dropping the oldest one if the log is full.
+// Scrolled back view stays in place.
+func (s *statusSprite) AddMsg(kind, msg string) {
 	msgRunes := []rune(msg)
 	if len(msgRunes) > s.msgLenMax {
 		msgRunes = msgRunes[:s.msgLenMax]
 	}
 
-	s.msgBuf.Value = string(msgRunes)
-	s.msgBuf = s.msgBuf.Next()
+	if len(s.msgs) == s.logSize {
+		s.msgs = append(s.msgs[:0], s.msgs[1:]...)
+	}
+	s.msgs = append(s.msgs, statusMsg{kind: kind, text: string(msgRunes)})
+
+	if s.scroll > 0 && s.matchFilter(kind) {
+		s.scroll++
+	}
+	s.clampScroll()
+}
+
+// Scroll moves the log view back (positive delta) or forward (negative delta) by a number of messages.
+func (s *statusSprite) Scroll(delta int) {
+	s.scroll += delta
+	s.clampScroll()
+}
+
+// ScrollToEnd moves the log view to the latest messages.
+func (s *statusSprite) ScrollToEnd() {
+	s.scroll = 0
+}
+
+// CycleFilter switches to the next message kind filter (the view is moved to the latest messages).
+func (s *statusSprite) CycleFilter() {
+	s.filterIdx = (s.filterIdx + 1) % len(statusFilters)
+	s.scroll = 0
+}
+
+// Contains checks if screen coordinates are within the Status window.
+func (s *statusSprite) Contains(x, y int) bool {
+	return y >= s.sY && y < s.sY+s.Height()
 }
 
+// SetLocation moves the Status window.
+func (s *statusSprite) SetLocation(x, y int) {
+	s.sX, s.sY = x, y
+}
+
+// Height returns the Status window height (header and messages lines).
 func (s *statusSprite) Height() int {
-	return s.msgBufSize * s.tFontSize * 2
+	return (s.msgBufSize + 1) * s.lineHeight()
 }
 
 // Draw implements the ebiten.Game interface.
 func (s *statusSprite) Draw(screen *ebiten.Image) {
-	s.RLock()
-	defer s.RUnlock()
-
-	statusText := strings.Builder{}
-	s.msgBuf.Do(func(i interface{}) {
-		msg, ok := i.(string)
-		if !ok {
-			return
+	const (
+		offsetX = 5
+	)
+
+	msgs := s.filtered()
+	end := len(msgs) - s.scroll
+	start := end - s.msgBufSize
+	if start < 0 {
+		start = 0
+	}
+
+	filter := statusFilters[s.filterIdx]
+	if filter == "" {
+		filter = "all"
+	}
+	header := fmt.Sprintf("Events [%s] %d/%d (L: filter, PgUp/PgDn: scroll)", filter, len(msgs), len(s.msgs))
+	if s.scroll > 0 {
+		header = fmt.Sprintf("Events [%s] %d back (End: latest)", filter, s.scroll)
+	}
+
+	lineHeight := s.lineHeight()
+	baselineOffset := lineHeight - s.tFontFace.Metrics().Descent.Ceil()
+
+	text.Draw(screen, header, s.tFontFace, s.sX+offsetX, s.sY+baselineOffset, color.Gray{Y: 0xA0})
+	for i, msg := range msgs[start:end] {
+		text.Draw(screen, msg.text, s.tFontFace, s.sX+offsetX, s.sY+(i+1)*lineHeight+baselineOffset, s.tFontColor)
+	}
+}
+
+// filtered returns log messages matching the current filter.
+func (s *statusSprite) filtered() []statusMsg {
+	if statusFilters[s.filterIdx] == "" {
+		return s.msgs
+	}
+
+	msgs := make([]statusMsg, 0, len(s.msgs))
+	for _, msg := range s.msgs {
+		if s.matchFilter(msg.kind) {
+			msgs = append(msgs, msg)
 		}
-		statusText.WriteString(msg + "\n")
-	})
+	}
+
+	return msgs
+}
+
+// matchFilter checks if a message kind passes the current filter.
+func (s *statusSprite) matchFilter(kind string) bool {
+	filter := statusFilters[s.filterIdx]
+
+	return filter == "" || filter == kind
+}
+
+// clampScroll keeps the scroll position within the filtered log.
+func (s *statusSprite) clampScroll() {
+	scrollMax := len(s.filtered()) - s.msgBufSize
+	if s.scroll > scrollMax {
+		s.scroll = scrollMax
+	}
+	if s.scroll < 0 {
+		s.scroll = 0
+	}
+}
 
-	text.Draw(screen, statusText.String(), s.tFontFace, s.sX, s.sY, s.tFontColor)
+// lineHeight returns the text line height.
+func (s *statusSprite) lineHeight() int {
+	return s.tFontFace.Metrics().Height.Ceil()
 }
@@ -1,24 +1,53 @@
 package monitor
 
-import "github.com/itiky/alienInvasion/model"
+import (
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+)
 
 // WorldEventsListener defines an external service that reacts to World / City / Alien events.
 type WorldEventsListener interface {
 	// CityUpdated is triggered when a City connection roads have been updated.
 	CityUpdated(city model.City)
 
-	// CityFightStarted is triggered when a City fight has started / prolonged.
-	CityFightStarted(cityID string)
+	// CityFightStarted is triggered when a new City fight has started (duration is the estimated fight duration).
+	CityFightStarted(cityID string, alienIDs []string, duration time.Duration)
+
+	// CityFightProlonged is triggered when an Alien joins an ongoing City fight (duration is the new time left).
+	CityFightProlonged(cityID string, alienIDs []string, duration time.Duration)
+
+	// CityFightEnded is triggered when a City fight is over (duration is the overall fight duration).
+	CityFightEnded(cityID string, alienIDs []string, duration time.Duration)
+
+	// CityFightDeadlineUpdated is triggered when a City fight estimated end (wall-clock) has changed:
+	// the fight has started / prolonged, the simulation has been resumed or its speed has changed.
+	CityFightDeadlineUpdated(cityID string, deadline time.Time)
 
 	// CityDestroyed is triggered when a City has been destroyed.
 	CityDestroyed(cityID string, alienIDs []string)
 
+	// AlienLanded is triggered when an Alien has disembarked to a City.
+	AlienLanded(alienID, cityID string)
+
+	// AlienLandingFailed is triggered when an Alien can't disembark since a target City no longer exists.
+	AlienLandingFailed(alienID, cityID string)
+
 	// AlienRelocated is triggered when an Alien has moved.
 	AlienRelocated(alienID, newCityID string)
 
+	// AlienMoveRefused is triggered when an Alien can't move since it is stuck in a City fight.
+	AlienMoveRefused(alienID, cityID, targetCityID string)
+
+	// AlienTrapped is triggered when an Alien has no roads left to move by.
+	AlienTrapped(alienID, cityID string)
+
 	// AlienDismissed is triggered when an Alien has been dismissed (evacuated / destroyed).
 	AlienDismissed(alienID, reason string)
 
-	// SimStatus is periodically triggered to inform about the current simulation state.
-	SimStatus(aliens, cities int, stimStopped bool)
+	// SimPhaseChanged is triggered when the simulation lifecycle phase has changed.
+	SimPhaseChanged(phase model.SimPhase)
+
+	// SimStatus is periodically triggered to inform about the current simulation state (the final one has a stop reason set).
+	SimStatus(status model.SimStatus)
 }
@@ -0,0 +1,103 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+var _ WorldEventsListener = (*LegacyAdapter)(nil)
+
+type (
+	// LegacyWorldEventsListener defines the original (pre lifecycle events) WorldEventsListener interface.
+	LegacyWorldEventsListener interface {
+		// CityUpdated is triggered when a City connection roads have been updated.
+		CityUpdated(city model.City)
+
+		// CityFightStarted is triggered when a City fight has started / prolonged.
+		CityFightStarted(cityID string)
+
+		// CityDestroyed is triggered when a City has been destroyed.
+		CityDestroyed(cityID string, alienIDs []string)
+
+		// AlienRelocated is triggered when an Alien has moved (landing included).
+		AlienRelocated(alienID, newCityID string)
+
+		// AlienDismissed is triggered when an Alien has been dismissed (evacuated / destroyed).
+		AlienDismissed(alienID, reason string)
+
+		// SimStatus is periodically triggered to inform about the current simulation state.
+		SimStatus(aliens, cities int, stimStopped bool)
+	}
+
+	// LegacyAdapter wraps a LegacyWorldEventsListener to be used as a WorldEventsListener.
+	// Events unknown to the legacy interface are either mapped to the old ones or dropped.
+	LegacyAdapter struct {
+		listener LegacyWorldEventsListener
+	}
+)
+
+// NewLegacyAdapter creates a new LegacyAdapter instance.
+func NewLegacyAdapter(listener LegacyWorldEventsListener) *LegacyAdapter {
+	return &LegacyAdapter{
+		listener: listener,
+	}
+}
+
+// CityUpdated implements the WorldEventsListener interface.
+func (a *LegacyAdapter) CityUpdated(city model.City) {
+	a.listener.CityUpdated(city)
+}
+
+// CityFightStarted implements the WorldEventsListener interface.
+func (a *LegacyAdapter) CityFightStarted(cityID string, _ []string, _ time.Duration) {
+	a.listener.CityFightStarted(cityID)
+}
+
+// CityFightProlonged implements the WorldEventsListener interface.
+func (a *LegacyAdapter) CityFightProlonged(cityID string, _ []string, _ time.Duration) {
+	a.listener.CityFightStarted(cityID)
+}
+
+// CityFightEnded implements the WorldEventsListener interface.
+func (a *LegacyAdapter) CityFightEnded(_ string, _ []string, _ time.Duration) {}
+
+// CityFightDeadlineUpdated implements the WorldEventsListener interface.
+func (a *LegacyAdapter) CityFightDeadlineUpdated(_ string, _ time.Time) {}
+
+// CityDestroyed implements the WorldEventsListener interface.
+func (a *LegacyAdapter) CityDestroyed(cityID string, alienIDs []string) {
+	a.listener.CityDestroyed(cityID, alienIDs)
+}
+
+// AlienLanded implements the WorldEventsListener interface.
+func (a *LegacyAdapter) AlienLanded(alienID, cityID string) {
+	a.listener.AlienRelocated(alienID, cityID)
+}
+
+// AlienLandingFailed implements the WorldEventsListener interface.
+func (a *LegacyAdapter) AlienLandingFailed(_, _ string) {}
+
+// AlienRelocated implements the WorldEventsListener interface.
+func (a *LegacyAdapter) AlienRelocated(alienID, newCityID string) {
+	a.listener.AlienRelocated(alienID, newCityID)
+}
+
+// AlienMoveRefused implements the WorldEventsListener interface.
+func (a *LegacyAdapter) AlienMoveRefused(_, _, _ string) {}
+
+// AlienTrapped implements the WorldEventsListener interface.
+func (a *LegacyAdapter) AlienTrapped(_, _ string) {}
+
+// AlienDismissed implements the WorldEventsListener interface.
+func (a *LegacyAdapter) AlienDismissed(alienID, reason string) {
+	a.listener.AlienDismissed(alienID, reason)
+}
+
+// SimPhaseChanged implements the WorldEventsListener interface.
+func (a *LegacyAdapter) SimPhaseChanged(_ model.SimPhase) {}
+
+// SimStatus implements the WorldEventsListener interface.
+func (a *LegacyAdapter) SimStatus(status model.SimStatus) {
+	a.listener.SimStatus(status.Aliens, status.Cities, status.Stopped)
+}
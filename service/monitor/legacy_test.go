@@ -0,0 +1,117 @@
+package monitor
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+var _ LegacyWorldEventsListener = (*testLegacyListener)(nil)
+
+// testLegacyListener records legacy events.
+type testLegacyListener struct {
+	events []string
+}
+
+func (l *testLegacyListener) CityUpdated(city model.City) {
+	l.events = append(l.events, "CityUpdated "+city.Name)
+}
+
+func (l *testLegacyListener) CityFightStarted(cityID string) {
+	l.events = append(l.events, "CityFightStarted "+cityID)
+}
+
+func (l *testLegacyListener) CityDestroyed(cityID string, alienIDs []string) {
+	l.events = append(l.events, "CityDestroyed "+cityID+" "+strings.Join(alienIDs, ","))
+}
+
+func (l *testLegacyListener) AlienRelocated(alienID, newCityID string) {
+	l.events = append(l.events, "AlienRelocated "+alienID+" "+newCityID)
+}
+
+func (l *testLegacyListener) AlienDismissed(alienID, reason string) {
+	l.events = append(l.events, "AlienDismissed "+alienID+" "+reason)
+}
+
+func (l *testLegacyListener) SimStatus(aliens, cities int, simStopped bool) {
+	l.events = append(l.events, fmt.Sprintf("SimStatus %d %d %v", aliens, cities, simStopped))
+}
+
+func TestLegacyAdapter(t *testing.T) {
+	type testCase struct {
+		name     string
+		send     func(a *LegacyAdapter)
+		expected []string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "CityUpdated",
+			send:     func(a *LegacyAdapter) { a.CityUpdated(model.City{Name: "A"}) },
+			expected: []string{"CityUpdated A"},
+		},
+		{
+			name:     "CityFightStarted",
+			send:     func(a *LegacyAdapter) { a.CityFightStarted("A", []string{"x", "y"}, time.Second) },
+			expected: []string{"CityFightStarted A"},
+		},
+		{
+			name:     "CityFightProlonged: mapped to started",
+			send:     func(a *LegacyAdapter) { a.CityFightProlonged("A", []string{"x", "y", "z"}, time.Second) },
+			expected: []string{"CityFightStarted A"},
+		},
+		{
+			name:     "CityDestroyed",
+			send:     func(a *LegacyAdapter) { a.CityDestroyed("A", []string{"x", "y"}) },
+			expected: []string{"CityDestroyed A x,y"},
+		},
+		{
+			name:     "AlienLanded: mapped to relocated",
+			send:     func(a *LegacyAdapter) { a.AlienLanded("x", "A") },
+			expected: []string{"AlienRelocated x A"},
+		},
+		{
+			name:     "AlienRelocated",
+			send:     func(a *LegacyAdapter) { a.AlienRelocated("x", "B") },
+			expected: []string{"AlienRelocated x B"},
+		},
+		{
+			name:     "AlienDismissed",
+			send:     func(a *LegacyAdapter) { a.AlienDismissed("x", model.AlienDismissReasonEvacuated) },
+			expected: []string{"AlienDismissed x evacuated"},
+		},
+		{
+			name: "SimStatus",
+			send: func(a *LegacyAdapter) {
+				a.SimStatus(model.SimStatus{Aliens: 3, Cities: 5, Stopped: true, StopReason: "aliens left: 3"})
+			},
+			expected: []string{"SimStatus 3 5 true"},
+		},
+		{
+			name: "Unknown to the legacy interface: dropped",
+			send: func(a *LegacyAdapter) {
+				a.CityFightEnded("A", []string{"x", "y"}, time.Second)
+				a.CityFightDeadlineUpdated("A", time.Now())
+				a.AlienLandingFailed("x", "A")
+				a.AlienMoveRefused("x", "A", "B")
+				a.AlienTrapped("x", "A")
+				a.SimPhaseChanged(model.SimPhaseRunning)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			listener := &testLegacyListener{}
+			tc.send(NewLegacyAdapter(listener))
+
+			if !reflect.DeepEqual(listener.events, tc.expected) {
+				t.Errorf("events: got %v, expected %v", listener.events, tc.expected)
+			}
+		})
+	}
+}
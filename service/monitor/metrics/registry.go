@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+)
+
+type (
+	// registry keeps metrics and writes them using the Prometheus text exposition format.
+	registry struct {
+		sync.Mutex
+		metrics []metric
+	}
+
+	// metric defines a single exposed metric.
+	metric interface {
+		write(w io.Writer) error
+	}
+
+	// metricMeta keeps common metric fields.
+	metricMeta struct {
+		name, help, kind string
+	}
+
+	// counter is a monotonically increasing value.
+	counter struct {
+		metricMeta
+		value float64
+	}
+
+	// gauge is a value that can go up and down.
+	gauge struct {
+		metricMeta
+		value float64
+	}
+
+	// histogram counts observations in cumulative buckets.
+	histogram struct {
+		metricMeta
+		bounds []float64 // upper bounds (sorted asc)
+		counts []uint64  // observations per bound (not cumulative)
+		count  uint64
+		sum    float64
+	}
+)
+
+// newCounter registers a new counter.
+func (r *registry) newCounter(name, help string) *counter {
+	m := &counter{metricMeta: metricMeta{name: name, help: help, kind: "counter"}}
+	r.metrics = append(r.metrics, m)
+
+	return m
+}
+
+// newGauge registers a new gauge.
+func (r *registry) newGauge(name, help string) *gauge {
+	m := &gauge{metricMeta: metricMeta{name: name, help: help, kind: "gauge"}}
+	r.metrics = append(r.metrics, m)
+
+	return m
+}
+
+// newHistogram registers a new histogram.
+func (r *registry) newHistogram(name, help string, bounds []float64) *histogram {
+	m := &histogram{
+		metricMeta: metricMeta{name: name, help: help, kind: "histogram"},
+		bounds:     bounds,
+		counts:     make([]uint64, len(bounds)),
+	}
+	r.metrics = append(r.metrics, m)
+
+	return m
+}
+
+// write writes all metrics.
+// Contract: caller holds the lock.
+func (r *registry) write(w io.Writer) error {
+	for _, m := range r.metrics {
+		if err := m.write(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Inc increments the counter.
+func (m *counter) Inc() {
+	m.value++
+}
+
+func (m *counter) write(w io.Writer) error {
+	return m.writeSample(w, m.value)
+}
+
+// Set sets the gauge value.
+func (m *gauge) Set(v float64) {
+	m.value = v
+}
+
+// Add adds a delta to the gauge value.
+func (m *gauge) Add(v float64) {
+	m.value += v
+}
+
+func (m *gauge) write(w io.Writer) error {
+	return m.writeSample(w, m.value)
+}
+
+// Observe adds an observation to the first bucket that fits.
+func (m *histogram) Observe(v float64) {
+	m.count++
+	m.sum += v
+	for i, bound := range m.bounds {
+		if v <= bound {
+			m.counts[i]++
+			break
+		}
+	}
+}
+
+func (m *histogram) write(w io.Writer) error {
+	if err := m.writeHeader(w); err != nil {
+		return err
+	}
+
+	cumulative := uint64(0)
+	for i, bound := range m.bounds {
+		cumulative += m.counts[i]
+		if _, err := fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", m.name, formatFloat(bound), cumulative); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", m.name, m.count); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%s_sum %s\n", m.name, formatFloat(m.sum)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s_count %d\n", m.name, m.count)
+
+	return err
+}
+
+// writeHeader writes the HELP and TYPE lines.
+func (m metricMeta) writeHeader(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+
+	return err
+}
+
+// writeSample writes the header and a single sample line.
+func (m metricMeta) writeSample(w io.Writer, v float64) error {
+	if err := m.writeHeader(w); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s %s\n", m.name, formatFloat(v))
+
+	return err
+}
+
+// formatFloat formats a sample value.
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
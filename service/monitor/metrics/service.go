@@ -0,0 +1,271 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/monitor"
+	"github.com/rs/zerolog"
+)
+
+const (
+	serviceName = "MetricsMonitor"
+
+	metricsPrefix = "alieninvasion_"
+)
+
+var _ monitor.WorldEventsListener = (*Monitor)(nil)
+
+type (
+	// Monitor collects simulation metrics and serves them using the Prometheus text format via the HTTP /metrics endpoint.
+	Monitor struct {
+		address string // HTTP server listen address
+
+		metrics registry
+
+		// Gauges
+		aliensAlive         *gauge
+		aliensWaiting       *gauge
+		citiesAlive         *gauge
+		fightsActive        *gauge
+		alienRequestsQueued *gauge
+		worldRequestsQueued *gauge
+		simElapsed          *gauge
+
+		// Counters
+		alienMoves        *counter
+		alienMovesRefused *counter
+		aliensEvacuated   *counter
+		citiesDestroyed   *counter
+
+		// Histograms
+		fightDurations *histogram
+
+		// Last reported simulation speed (fight durations are reported as wall-clock ones)
+		speed float64
+	}
+
+	// Option defines the New constructor options.
+	Option func(m *Monitor) error
+)
+
+// WithAddress overrides the default HTTP server listen address.
+func WithAddress(address string) Option {
+	return func(m *Monitor) error {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return fmt.Errorf("address (%s): invalid: %w", address, err)
+		}
+		m.address = address
+
+		return nil
+	}
+}
+
+// New creates a new Monitor instance.
+// Map and Aliens define initial gauges values (before the first SimStatus event).
+func New(cityMap model.CityMap, aliens []model.Alien, opts ...Option) (*Monitor, error) {
+	m := Monitor{
+		address: "127.0.0.1:9090",
+		speed:   1.0,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&m); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &m.metrics
+	m.aliensAlive = r.newGauge(metricsPrefix+"aliens_alive", "Number of Aliens on the map.")
+	m.aliensWaiting = r.newGauge(metricsPrefix+"aliens_waiting", "Number of Aliens waiting to land.")
+	m.citiesAlive = r.newGauge(metricsPrefix+"cities_alive", "Number of Cities left.")
+	m.fightsActive = r.newGauge(metricsPrefix+"fights_active", "Number of ongoing City fights.")
+	m.alienRequestsQueued = r.newGauge(metricsPrefix+"alien_requests_queued", "Number of Alien requests waiting in the World queue.")
+	m.worldRequestsQueued = r.newGauge(metricsPrefix+"world_requests_queued", "Number of City / disembark requests waiting in the World queue.")
+	m.simElapsed = r.newGauge(metricsPrefix+"sim_elapsed_seconds", "Simulated time.")
+	m.alienMoves = r.newCounter(metricsPrefix+"alien_moves_total", "Number of Alien moves.")
+	m.alienMovesRefused = r.newCounter(metricsPrefix+"alien_moves_refused_total", "Number of Alien moves refused (Alien is stuck in a fight).")
+	m.aliensEvacuated = r.newCounter(metricsPrefix+"aliens_evacuated_total", "Number of Aliens evacuated.")
+	m.citiesDestroyed = r.newCounter(metricsPrefix+"cities_destroyed_total", "Number of Cities destroyed.")
+	m.fightDurations = r.newHistogram(
+		metricsPrefix+"fight_duration_seconds", "City fight durations (simulated time).",
+		[]float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 60},
+	)
+
+	m.aliensWaiting.Set(float64(len(aliens)))
+	m.citiesAlive.Set(float64(len(cityMap)))
+
+	return &m, nil
+}
+
+// Start starts the HTTP server in a separate routine.
+// Server is stopped when the {ctx} is canceled.
+func (m *Monitor) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", m.address)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", m.address, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", m.handleMetrics)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			m.log(ctx).Warn().Err(err).Msg("HTTP server shutdown failed")
+		}
+	}()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			m.log(ctx).Error().Err(err).Msg("HTTP server failed")
+		}
+	}()
+
+	m.log(ctx).Info().Msgf("Serving metrics on http://%s/metrics", listener.Addr())
+
+	return nil
+}
+
+// handleMetrics writes all metrics.
+func (m *Monitor) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	buf := bytes.Buffer{}
+
+	m.metrics.Lock()
+	err := m.metrics.write(&buf)
+	m.metrics.Unlock()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+// CityUpdated implements the WorldEventsListener interface.
+func (m *Monitor) CityUpdated(city model.City) {}
+
+// CityFightStarted implements the WorldEventsListener interface.
+func (m *Monitor) CityFightStarted(cityID string, alienIDs []string, duration time.Duration) {
+	m.metrics.Lock()
+	defer m.metrics.Unlock()
+
+	m.fightsActive.Add(1)
+}
+
+// CityFightProlonged implements the WorldEventsListener interface.
+func (m *Monitor) CityFightProlonged(cityID string, alienIDs []string, duration time.Duration) {}
+
+// CityFightEnded implements the WorldEventsListener interface.
+func (m *Monitor) CityFightEnded(cityID string, alienIDs []string, duration time.Duration) {
+	m.metrics.Lock()
+	defer m.metrics.Unlock()
+
+	// Wall-clock to the simulated time (a fight lasting 1s at the 2.0 speed takes 2s of the simulated time)
+	m.fightsActive.Add(-1)
+	m.fightDurations.Observe(duration.Seconds() * m.speed)
+}
+
+// CityDestroyed implements the WorldEventsListener interface.
+func (m *Monitor) CityDestroyed(cityID string, alienIDs []string) {
+	m.metrics.Lock()
+	defer m.metrics.Unlock()
+
+	m.citiesAlive.Add(-1)
+	m.citiesDestroyed.Inc()
+}
+
+// AlienLanded implements the WorldEventsListener interface.
+func (m *Monitor) AlienLanded(alienID, cityID string) {
+	m.metrics.Lock()
+	defer m.metrics.Unlock()
+
+	m.aliensAlive.Add(1)
+	m.aliensWaiting.Add(-1)
+}
+
+// AlienLandingFailed implements the WorldEventsListener interface.
+func (m *Monitor) AlienLandingFailed(alienID, cityID string) {
+	m.metrics.Lock()
+	defer m.metrics.Unlock()
+
+	m.aliensWaiting.Add(-1)
+}
+
+// AlienRelocated implements the WorldEventsListener interface.
+func (m *Monitor) AlienRelocated(alienID, newCityID string) {
+	m.metrics.Lock()
+	defer m.metrics.Unlock()
+
+	m.alienMoves.Inc()
+}
+
+// AlienMoveRefused implements the WorldEventsListener interface.
+func (m *Monitor) AlienMoveRefused(alienID, cityID, targetCityID string) {
+	m.metrics.Lock()
+	defer m.metrics.Unlock()
+
+	m.alienMovesRefused.Inc()
+}
+
+// AlienTrapped implements the WorldEventsListener interface.
+func (m *Monitor) AlienTrapped(alienID, cityID string) {}
+
+// AlienDismissed implements the WorldEventsListener interface.
+func (m *Monitor) AlienDismissed(alienID, reason string) {
+	m.metrics.Lock()
+	defer m.metrics.Unlock()
+
+	m.aliensAlive.Add(-1)
+	if reason == model.AlienDismissReasonEvacuated {
+		m.aliensEvacuated.Inc()
+	}
+}
+
+// CityFightDeadlineUpdated implements the WorldEventsListener interface.
+func (m *Monitor) CityFightDeadlineUpdated(_ string, _ time.Time) {}
+
+// SimPhaseChanged implements the WorldEventsListener interface.
+func (m *Monitor) SimPhaseChanged(phase model.SimPhase) {}
+
+// SimStatus implements the WorldEventsListener interface.
+// Gauges are synced with the status, events keep them up to date between statuses.
+func (m *Monitor) SimStatus(status model.SimStatus) {
+	m.metrics.Lock()
+	defer m.metrics.Unlock()
+
+	m.aliensAlive.Set(float64(status.Aliens))
+	m.aliensWaiting.Set(float64(status.AliensWaiting))
+	m.citiesAlive.Set(float64(status.Cities))
+	m.fightsActive.Set(float64(status.Fights))
+	m.alienRequestsQueued.Set(float64(status.AlienRequestsQueued))
+	m.worldRequestsQueued.Set(float64(status.WorldRequestsQueued))
+	m.simElapsed.Set(status.SimElapsed.Seconds())
+	if status.Speed > 0 {
+		m.speed = status.Speed
+	}
+}
+
+// log returns logger with service fields set.
+func (m *Monitor) log(ctx context.Context) *zerolog.Logger {
+	_, logger := logging.GetCtxLogger(ctx)
+	logger = logger.With().Str(logging.ServiceKey, serviceName).Logger()
+
+	return &logger
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+func TestMonitorFightDurations(t *testing.T) {
+	type testCase struct {
+		name          string
+		speeds        []float64 // reported by SimStatus before the fight end (none if empty)
+		wallDuration  time.Duration
+		expectedSum   float64
+		expectedCount uint64
+	}
+
+	testCases := []testCase{
+		{
+			name:          "No status reported: 1.0 speed",
+			wallDuration:  2 * time.Second,
+			expectedSum:   2.0,
+			expectedCount: 1,
+		},
+		{
+			name:          "Faster: simulated time is longer",
+			speeds:        []float64{2.0},
+			wallDuration:  2 * time.Second,
+			expectedSum:   4.0,
+			expectedCount: 1,
+		},
+		{
+			name:          "Slower: simulated time is shorter",
+			speeds:        []float64{0.25},
+			wallDuration:  2 * time.Second,
+			expectedSum:   0.5,
+			expectedCount: 1,
+		},
+		{
+			name:          "Last reported speed is used, zero is ignored",
+			speeds:        []float64{4.0, 0.5, 0},
+			wallDuration:  2 * time.Second,
+			expectedSum:   1.0,
+			expectedCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := New(model.CityMap{}, nil)
+			if err != nil {
+				t.Fatalf("creating monitor: %v", err)
+			}
+
+			for _, speed := range tc.speeds {
+				m.SimStatus(model.SimStatus{Speed: speed})
+			}
+			m.CityFightEnded("A", []string{"x", "y"}, tc.wallDuration)
+
+			if m.fightDurations.sum != tc.expectedSum || m.fightDurations.count != tc.expectedCount {
+				t.Errorf("fight durations: got %d observations (sum %v), expected %d (sum %v)",
+					m.fightDurations.count, m.fightDurations.sum, tc.expectedCount, tc.expectedSum)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package mirror
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/service/monitor"
+)
+
+var _ monitor.WorldEventsListener = (*Monitor)(nil)
+
+type (
+	// Monitor rebuilds the World state from events and fans events out to subscribers.
+	// Listener methods never block: a subscriber that can't keep up is dropped (its channel is closed).
+	Monitor struct {
+		sync.Mutex
+
+		// State
+		cities   map[string]*CityState  // key: CityID
+		aliens   map[string]*AlienState // key: AlienID
+		status   Status
+		eventSeq uint64
+		stopped  bool
+
+		// Subscribers
+		subs    map[int]chan Event // key: subscriber ID
+		subsSeq int
+	}
+)
+
+// New creates a new Monitor instance.
+func New(cityMap model.CityMap, aliens []model.Alien) *Monitor {
+	m := Monitor{
+		cities: make(map[string]*CityState, len(cityMap)),
+		aliens: make(map[string]*AlienState, len(aliens)),
+		status: Status{
+			AliensWaiting: len(aliens),
+			Cities:        len(cityMap),
+			CitiesInitial: len(cityMap),
+		},
+		subs: make(map[int]chan Event),
+	}
+
+	for _, city := range cityMap {
+		m.cities[city.Name] = &CityState{
+			Name:  city.Name,
+			Roads: NewRoads(city),
+		}
+	}
+
+	for _, alien := range aliens {
+		m.aliens[alien.Name] = &AlienState{
+			Name:     alien.Name,
+			Power:    alien.Power,
+			Speed:    alien.Speed,
+			MaxSteps: alien.MaxSteps,
+			State:    AlienStateWaiting,
+		}
+	}
+
+	return &m
+}
+
+// Snapshot returns the current World state.
+func (m *Monitor) Snapshot() Snapshot {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.snapshot()
+}
+
+// Subscribe returns the current World state and an events channel with following events.
+// Channel is closed when the simulation stops, the subscriber can't keep up or the cancel func is called.
+func (m *Monitor) Subscribe(bufferSize int) (Snapshot, <-chan Event, func()) {
+	m.Lock()
+	defer m.Unlock()
+
+	eventsCh := make(chan Event, bufferSize)
+	if m.stopped {
+		close(eventsCh)
+		return m.snapshot(), eventsCh, func() {}
+	}
+
+	m.subsSeq++
+	subID := m.subsSeq
+	m.subs[subID] = eventsCh
+
+	cancel := func() {
+		m.Lock()
+		defer m.Unlock()
+
+		if ch, ok := m.subs[subID]; ok {
+			delete(m.subs, subID)
+			close(ch)
+		}
+	}
+
+	return m.snapshot(), eventsCh, cancel
+}
+
+// CityUpdated implements the WorldEventsListener interface.
+func (m *Monitor) CityUpdated(city model.City) {
+	m.Lock()
+	defer m.Unlock()
+
+	roads := NewRoads(city)
+	if c, ok := m.cities[city.Name]; ok {
+		c.Roads = roads
+	}
+	m.publish(Event{Type: EventCityUpdated, CityID: city.Name, Roads: &roads})
+}
+
+// CityFightStarted implements the WorldEventsListener interface.
+func (m *Monitor) CityFightStarted(cityID string, alienIDs []string, duration time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+
+	if c, ok := m.cities[cityID]; ok {
+		now := time.Now()
+		c.Fight = &FightState{
+			StartedAt: now,
+			Deadline:  now.Add(duration),
+			AlienIDs:  sortedIDs(alienIDs),
+		}
+	}
+	m.publish(Event{Type: EventCityFightStarted, CityID: cityID, AlienIDs: alienIDs, Duration: duration})
+}
+
+// CityFightProlonged implements the WorldEventsListener interface.
+func (m *Monitor) CityFightProlonged(cityID string, alienIDs []string, duration time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+
+	if c, ok := m.cities[cityID]; ok && c.Fight != nil {
+		c.Fight.Deadline = time.Now().Add(duration)
+		c.Fight.AlienIDs = sortedIDs(alienIDs)
+	}
+	m.publish(Event{Type: EventCityFightProlonged, CityID: cityID, AlienIDs: alienIDs, Duration: duration})
+}
+
+// CityFightEnded implements the WorldEventsListener interface.
+func (m *Monitor) CityFightEnded(cityID string, alienIDs []string, duration time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+
+	if c, ok := m.cities[cityID]; ok {
+		c.Fight = nil
+	}
+	m.publish(Event{Type: EventCityFightEnded, CityID: cityID, AlienIDs: alienIDs, Duration: duration})
+}
+
+// CityFightDeadlineUpdated implements the WorldEventsListener interface.
+func (m *Monitor) CityFightDeadlineUpdated(cityID string, deadline time.Time) {
+	m.Lock()
+	defer m.Unlock()
+
+	if c, ok := m.cities[cityID]; ok && c.Fight != nil {
+		c.Fight.Deadline = deadline
+	}
+	m.publish(Event{Type: EventCityFightDeadlineUpdated, CityID: cityID, Deadline: &deadline})
+}
+
+// CityDestroyed implements the WorldEventsListener interface.
+func (m *Monitor) CityDestroyed(cityID string, alienIDs []string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if c, ok := m.cities[cityID]; ok {
+		c.Destroyed, c.Fight = true, nil
+	}
+	m.publish(Event{Type: EventCityDestroyed, CityID: cityID, AlienIDs: alienIDs})
+}
+
+// AlienLanded implements the WorldEventsListener interface.
+func (m *Monitor) AlienLanded(alienID, cityID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if a, ok := m.aliens[alienID]; ok {
+		a.State, a.CityID = AlienStateLanded, cityID
+	}
+	m.publish(Event{Type: EventAlienLanded, AlienID: alienID, CityID: cityID})
+}
+
+// AlienLandingFailed implements the WorldEventsListener interface.
+func (m *Monitor) AlienLandingFailed(alienID, cityID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if a, ok := m.aliens[alienID]; ok {
+		a.State = AlienStateLandingFailed
+	}
+	m.publish(Event{Type: EventAlienLandingFailed, AlienID: alienID, CityID: cityID})
+}
+
+// AlienRelocated implements the WorldEventsListener interface.
+func (m *Monitor) AlienRelocated(alienID, newCityID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if a, ok := m.aliens[alienID]; ok {
+		a.CityID = newCityID
+		a.Moves++
+	}
+	m.publish(Event{Type: EventAlienRelocated, AlienID: alienID, CityID: newCityID})
+}
+
+// AlienMoveRefused implements the WorldEventsListener interface.
+func (m *Monitor) AlienMoveRefused(alienID, cityID, targetCityID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.publish(Event{Type: EventAlienMoveRefused, AlienID: alienID, CityID: cityID, TargetCityID: targetCityID})
+}
+
+// AlienTrapped implements the WorldEventsListener interface.
+func (m *Monitor) AlienTrapped(alienID, cityID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.publish(Event{Type: EventAlienTrapped, AlienID: alienID, CityID: cityID})
+}
+
+// AlienDismissed implements the WorldEventsListener interface.
+func (m *Monitor) AlienDismissed(alienID, reason string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if a, ok := m.aliens[alienID]; ok {
+		switch reason {
+		case model.AlienDismissReasonEvacuated:
+			a.State = AlienStateEvacuated
+		case model.AlienDismissReasonDestroyed:
+			a.State = AlienStateDestroyed
+		}
+	}
+	m.publish(Event{Type: EventAlienDismissed, AlienID: alienID, Reason: reason})
+}
+
+// SimPhaseChanged implements the WorldEventsListener interface.
+func (m *Monitor) SimPhaseChanged(phase model.SimPhase) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.status.Phase = phase
+	m.publish(Event{Type: EventSimPhaseChanged, Phase: phase})
+}
+
+// SimStatus implements the WorldEventsListener interface.
+// Subscribers are closed once the final status is published.
+func (m *Monitor) SimStatus(status model.SimStatus) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.status = NewStatus(status)
+	s := m.status
+	m.publish(Event{Type: EventSimStatus, Status: &s})
+
+	if status.Stopped {
+		m.stopped = true
+		for subID, ch := range m.subs {
+			delete(m.subs, subID)
+			close(ch)
+		}
+	}
+}
+
+// publish sends an event to all subscribers dropping those who can't keep up.
+// Contract: caller holds the lock.
+func (m *Monitor) publish(e Event) {
+	m.eventSeq++
+	e.Seq, e.Time = m.eventSeq, time.Now()
+
+	for subID, ch := range m.subs {
+		select {
+		case ch <- e:
+		default:
+			delete(m.subs, subID)
+			close(ch)
+		}
+	}
+}
+
+// snapshot builds the current World state.
+// Contract: caller holds the lock.
+func (m *Monitor) snapshot() Snapshot {
+	cityAliens := make(map[string][]string, len(m.cities))
+	for _, a := range m.aliens {
+		if a.State == AlienStateLanded {
+			cityAliens[a.CityID] = append(cityAliens[a.CityID], a.Name)
+		}
+	}
+
+	s := Snapshot{
+		Status: m.status,
+		Cities: make([]CityState, 0, len(m.cities)),
+		Aliens: make([]AlienState, 0, len(m.aliens)),
+	}
+
+	for _, c := range m.cities {
+		city := *c
+		city.AlienIDs = sortedIDs(cityAliens[c.Name])
+		if c.Fight != nil {
+			fight := *c.Fight
+			city.Fight = &fight
+		}
+		s.Cities = append(s.Cities, city)
+	}
+	sort.Slice(s.Cities, func(i, j int) bool { return s.Cities[i].Name < s.Cities[j].Name })
+
+	for _, a := range m.aliens {
+		s.Aliens = append(s.Aliens, *a)
+	}
+	sort.Slice(s.Aliens, func(i, j int) bool { return s.Aliens[i].Name < s.Aliens[j].Name })
+
+	return s
+}
+
+// sortedIDs returns a sorted IDs copy (never nil).
+func sortedIDs(ids []string) []string {
+	sorted := make([]string, len(ids))
+	copy(sorted, ids)
+	sort.Strings(sorted)
+
+	return sorted
+}
@@ -0,0 +1,67 @@
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SSE subscriber events buffer (slow clients are dropped).
+const sseBufferSize = 1024
+
+// SSEEventSnapshot is the first SSE event type that keeps the current World state.
+const SSEEventSnapshot = "snapshot"
+
+// ServeEvents streams World events using the Server-Sent Events protocol (event name is the Event type, data is JSON).
+// The first "snapshot" event keeps the current state ({wrapSnapshot} can enrich it, optional).
+// Stream ends when the simulation stops, the client disconnects or can't keep up.
+func (m *Monitor) ServeEvents(w http.ResponseWriter, r *http.Request, wrapSnapshot func(s Snapshot) interface{}) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	snapshot, eventsCh, cancel := m.Subscribe(sseBufferSize)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	writeEvent := func(eventType string, data interface{}) bool {
+		dataBz, err := json.Marshal(data)
+		if err != nil {
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, dataBz); err != nil {
+			return false
+		}
+		flusher.Flush()
+
+		return true
+	}
+
+	var snapshotData interface{} = snapshot
+	if wrapSnapshot != nil {
+		snapshotData = wrapSnapshot(snapshot)
+	}
+	if !writeEvent(SSEEventSnapshot, snapshotData) {
+		return
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-eventsCh:
+			if !ok {
+				return
+			}
+			if !writeEvent(event.Type, event) {
+				return
+			}
+		}
+	}
+}
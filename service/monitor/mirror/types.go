@@ -0,0 +1,145 @@
+package mirror
+
+import (
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+// Event types (WorldEventsListener methods).
+const (
+	EventCityUpdated              = "cityUpdated"
+	EventCityFightStarted         = "cityFightStarted"
+	EventCityFightProlonged       = "cityFightProlonged"
+	EventCityFightEnded           = "cityFightEnded"
+	EventCityFightDeadlineUpdated = "cityFightDeadlineUpdated"
+	EventCityDestroyed            = "cityDestroyed"
+	EventAlienLanded              = "alienLanded"
+	EventAlienLandingFailed       = "alienLandingFailed"
+	EventAlienRelocated           = "alienRelocated"
+	EventAlienMoveRefused         = "alienMoveRefused"
+	EventAlienTrapped             = "alienTrapped"
+	EventAlienDismissed           = "alienDismissed"
+	EventSimPhaseChanged          = "simPhaseChanged"
+	EventSimStatus                = "simStatus"
+)
+
+// Alien states.
+const (
+	AlienStateWaiting       = "waiting"       // not landed yet
+	AlienStateLanded        = "landed"        // on the map
+	AlienStateLandingFailed = "landingFailed" // target City has been destroyed before landing
+	AlienStateEvacuated     = "evacuated"     // dismissed: out of steps
+	AlienStateDestroyed     = "destroyed"     // dismissed: destroyed with a City
+)
+
+type (
+	// Snapshot keeps the World state rebuilt from events.
+	Snapshot struct {
+		Status Status       `json:"status"`
+		Cities []CityState  `json:"cities"` // sorted by name
+		Aliens []AlienState `json:"aliens"` // sorted by name
+	}
+
+	// Status keeps the simulation state counters (model.SimStatus).
+	Status struct {
+		Phase               model.SimPhase `json:"phase"`
+		Aliens              int            `json:"aliens"`
+		AliensWaiting       int            `json:"aliensWaiting"`
+		Cities              int            `json:"cities"`
+		CitiesInitial       int            `json:"citiesInitial"`
+		Fights              int            `json:"fights"`
+		Events              uint64         `json:"events"`
+		AlienRequestsQueued int            `json:"alienRequestsQueued"`
+		WorldRequestsQueued int            `json:"worldRequestsQueued"`
+		SimElapsed          time.Duration  `json:"simElapsed"`
+		WallElapsed         time.Duration  `json:"wallElapsed"`
+		SinceLastMove       time.Duration  `json:"sinceLastMove"`
+		Speed               float64        `json:"speed"`
+		Stopped             bool           `json:"stopped"`
+		StopReason          string         `json:"stopReason,omitempty"`
+	}
+
+	// CityState keeps a single City state.
+	CityState struct {
+		Name      string      `json:"name"`
+		Roads     Roads       `json:"roads"`
+		Destroyed bool        `json:"destroyed"`
+		AlienIDs  []string    `json:"alienIds"` // sorted
+		Fight     *FightState `json:"fight,omitempty"`
+	}
+
+	// Roads keeps City connections (empty if none).
+	Roads struct {
+		North string `json:"north,omitempty"`
+		East  string `json:"east,omitempty"`
+		South string `json:"south,omitempty"`
+		West  string `json:"west,omitempty"`
+	}
+
+	// FightState keeps an ongoing City fight state.
+	FightState struct {
+		StartedAt time.Time `json:"startedAt"`
+		Deadline  time.Time `json:"deadline"` // estimated
+		AlienIDs  []string  `json:"alienIds"`
+	}
+
+	// AlienState keeps a single Alien state.
+	AlienState struct {
+		Name     string        `json:"name"`
+		Power    uint          `json:"power"`
+		Speed    time.Duration `json:"speed"`
+		MaxSteps uint          `json:"maxSteps"`
+		State    string        `json:"state"`
+		CityID   string        `json:"cityId,omitempty"` // current / last City
+		Moves    uint          `json:"moves"`
+	}
+
+	// Event keeps a single WorldEventsListener event (only type related fields are set).
+	Event struct {
+		Seq          uint64         `json:"seq"`
+		Time         time.Time      `json:"time"`
+		Type         string         `json:"type"`
+		CityID       string         `json:"cityId,omitempty"`
+		TargetCityID string         `json:"targetCityId,omitempty"`
+		AlienID      string         `json:"alienId,omitempty"`
+		AlienIDs     []string       `json:"alienIds,omitempty"`
+		Duration     time.Duration  `json:"duration,omitempty"`
+		Deadline     *time.Time     `json:"deadline,omitempty"`
+		Reason       string         `json:"reason,omitempty"`
+		Phase        model.SimPhase `json:"phase,omitempty"`
+		Roads        *Roads         `json:"roads,omitempty"`
+		Status       *Status        `json:"status,omitempty"`
+	}
+)
+
+// NewStatus converts model.SimStatus.
+func NewStatus(s model.SimStatus) Status {
+	return Status{
+		Phase:               s.Phase,
+		Aliens:              s.Aliens,
+		AliensWaiting:       s.AliensWaiting,
+		Cities:              s.Cities,
+		CitiesInitial:       s.CitiesInitial,
+		Fights:              s.Fights,
+		Events:              s.Events,
+		AlienRequestsQueued: s.AlienRequestsQueued,
+		WorldRequestsQueued: s.WorldRequestsQueued,
+		SimElapsed:          s.SimElapsed,
+		WallElapsed:         s.WallElapsed,
+		SinceLastMove:       s.SinceLastMove,
+		Speed:               s.Speed,
+		Stopped:             s.Stopped,
+		StopReason:          s.StopReason,
+	}
+}
+
+// NewRoads converts model.City connections.
+func NewRoads(c model.City) Roads {
+	return Roads{
+		North: c.NorthRoad,
+		East:  c.EastRoad,
+		South: c.SouthRoad,
+		West:  c.WestRoad,
+	}
+}
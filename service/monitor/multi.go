@@ -0,0 +1,124 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+var _ WorldEventsListener = (*Multi)(nil)
+
+// Multi fans out every event to a number of listeners (in order).
+type Multi struct {
+	listeners []WorldEventsListener
+}
+
+// NewMulti creates a new Multi instance (nil listeners are skipped).
+func NewMulti(listeners ...WorldEventsListener) *Multi {
+	m := Multi{}
+	for _, l := range listeners {
+		if l != nil {
+			m.listeners = append(m.listeners, l)
+		}
+	}
+
+	return &m
+}
+
+// CityUpdated implements the WorldEventsListener interface.
+func (m *Multi) CityUpdated(city model.City) {
+	for _, l := range m.listeners {
+		l.CityUpdated(city)
+	}
+}
+
+// CityFightStarted implements the WorldEventsListener interface.
+func (m *Multi) CityFightStarted(cityID string, alienIDs []string, duration time.Duration) {
+	for _, l := range m.listeners {
+		l.CityFightStarted(cityID, alienIDs, duration)
+	}
+}
+
+// CityFightProlonged implements the WorldEventsListener interface.
+func (m *Multi) CityFightProlonged(cityID string, alienIDs []string, duration time.Duration) {
+	for _, l := range m.listeners {
+		l.CityFightProlonged(cityID, alienIDs, duration)
+	}
+}
+
+// CityFightEnded implements the WorldEventsListener interface.
+func (m *Multi) CityFightEnded(cityID string, alienIDs []string, duration time.Duration) {
+	for _, l := range m.listeners {
+		l.CityFightEnded(cityID, alienIDs, duration)
+	}
+}
+
+// CityDestroyed implements the WorldEventsListener interface.
+func (m *Multi) CityDestroyed(cityID string, alienIDs []string) {
+	for _, l := range m.listeners {
+		l.CityDestroyed(cityID, alienIDs)
+	}
+}
+
+// AlienLanded implements the WorldEventsListener interface.
+func (m *Multi) AlienLanded(alienID, cityID string) {
+	for _, l := range m.listeners {
+		l.AlienLanded(alienID, cityID)
+	}
+}
+
+// AlienLandingFailed implements the WorldEventsListener interface.
+func (m *Multi) AlienLandingFailed(alienID, cityID string) {
+	for _, l := range m.listeners {
+		l.AlienLandingFailed(alienID, cityID)
+	}
+}
+
+// AlienRelocated implements the WorldEventsListener interface.
+func (m *Multi) AlienRelocated(alienID, newCityID string) {
+	for _, l := range m.listeners {
+		l.AlienRelocated(alienID, newCityID)
+	}
+}
+
+// AlienMoveRefused implements the WorldEventsListener interface.
+func (m *Multi) AlienMoveRefused(alienID, cityID, targetCityID string) {
+	for _, l := range m.listeners {
+		l.AlienMoveRefused(alienID, cityID, targetCityID)
+	}
+}
+
+// AlienTrapped implements the WorldEventsListener interface.
+func (m *Multi) AlienTrapped(alienID, cityID string) {
+	for _, l := range m.listeners {
+		l.AlienTrapped(alienID, cityID)
+	}
+}
+
+// AlienDismissed implements the WorldEventsListener interface.
+func (m *Multi) AlienDismissed(alienID, reason string) {
+	for _, l := range m.listeners {
+		l.AlienDismissed(alienID, reason)
+	}
+}
+
+// CityFightDeadlineUpdated implements the WorldEventsListener interface.
+func (m *Multi) CityFightDeadlineUpdated(cityID string, deadline time.Time) {
+	for _, l := range m.listeners {
+		l.CityFightDeadlineUpdated(cityID, deadline)
+	}
+}
+
+// SimPhaseChanged implements the WorldEventsListener interface.
+func (m *Multi) SimPhaseChanged(phase model.SimPhase) {
+	for _, l := range m.listeners {
+		l.SimPhaseChanged(phase)
+	}
+}
+
+// SimStatus implements the WorldEventsListener interface.
+func (m *Multi) SimStatus(status model.SimStatus) {
+	for _, l := range m.listeners {
+		l.SimStatus(status)
+	}
+}
@@ -2,6 +2,7 @@ package noop
 
 import (
 	"strings"
+	"time"
 
 	"github.com/itiky/alienInvasion/model"
 	"github.com/itiky/alienInvasion/pkg/logging"
@@ -56,7 +57,7 @@ func (m *Monitor) CityUpdated(city model.City) {
 }
 
 // CityFightStarted implements the WorldEventsListener interface.
-func (m *Monitor) CityFightStarted(cityID string) {
+func (m *Monitor) CityFightStarted(cityID string, alienIDs []string, duration time.Duration) {
 	if !m.logsEnabled {
 		return
 	}
@@ -65,7 +66,46 @@ func (m *Monitor) CityFightStarted(cityID string) {
 		Debug().
 		Str(logging.ServiceKey, serviceName).
 		Str("event", "CityFightStarted").
-		Msgf("CityID = %s", cityID)
+		Msgf("CityID = %s, Aliens = [%s], Duration = %v", cityID, strings.Join(alienIDs, ","), duration)
+}
+
+// CityFightProlonged implements the WorldEventsListener interface.
+func (m *Monitor) CityFightProlonged(cityID string, alienIDs []string, duration time.Duration) {
+	if !m.logsEnabled {
+		return
+	}
+
+	m.logger.
+		Debug().
+		Str(logging.ServiceKey, serviceName).
+		Str("event", "CityFightProlonged").
+		Msgf("CityID = %s, Aliens = [%s], Duration = %v", cityID, strings.Join(alienIDs, ","), duration)
+}
+
+// CityFightEnded implements the WorldEventsListener interface.
+func (m *Monitor) CityFightEnded(cityID string, alienIDs []string, duration time.Duration) {
+	if !m.logsEnabled {
+		return
+	}
+
+	m.logger.
+		Debug().
+		Str(logging.ServiceKey, serviceName).
+		Str("event", "CityFightEnded").
+		Msgf("CityID = %s, Aliens = [%s], Duration = %v", cityID, strings.Join(alienIDs, ","), duration)
+}
+
+// CityFightDeadlineUpdated implements the WorldEventsListener interface.
+func (m *Monitor) CityFightDeadlineUpdated(cityID string, deadline time.Time) {
+	if !m.logsEnabled {
+		return
+	}
+
+	m.logger.
+		Debug().
+		Str(logging.ServiceKey, serviceName).
+		Str("event", "CityFightDeadlineUpdated").
+		Msgf("CityID = %s, Deadline = %s", cityID, deadline.Format(time.RFC3339Nano))
 }
 
 // CityDestroyed implements the WorldEventsListener interface.
@@ -81,6 +121,32 @@ func (m *Monitor) CityDestroyed(cityID string, aliens []string) {
 		Msgf("CityID = %s, Aliens = [%s]", cityID, strings.Join(aliens, ","))
 }
 
+// AlienLanded implements the WorldEventsListener interface.
+func (m *Monitor) AlienLanded(alienID, cityID string) {
+	if !m.logsEnabled {
+		return
+	}
+
+	m.logger.
+		Debug().
+		Str(logging.ServiceKey, serviceName).
+		Str("event", "AlienLanded").
+		Msgf("AlienID = %s, CityID = %s", alienID, cityID)
+}
+
+// AlienLandingFailed implements the WorldEventsListener interface.
+func (m *Monitor) AlienLandingFailed(alienID, cityID string) {
+	if !m.logsEnabled {
+		return
+	}
+
+	m.logger.
+		Debug().
+		Str(logging.ServiceKey, serviceName).
+		Str("event", "AlienLandingFailed").
+		Msgf("AlienID = %s, CityID = %s", alienID, cityID)
+}
+
 // AlienRelocated implements the WorldEventsListener interface.
 func (m *Monitor) AlienRelocated(alienID, newCityID string) {
 	if !m.logsEnabled {
@@ -94,6 +160,32 @@ func (m *Monitor) AlienRelocated(alienID, newCityID string) {
 		Msgf("AlienID = %s, NewCityID = %s", alienID, newCityID)
 }
 
+// AlienMoveRefused implements the WorldEventsListener interface.
+func (m *Monitor) AlienMoveRefused(alienID, cityID, targetCityID string) {
+	if !m.logsEnabled {
+		return
+	}
+
+	m.logger.
+		Debug().
+		Str(logging.ServiceKey, serviceName).
+		Str("event", "AlienMoveRefused").
+		Msgf("AlienID = %s, CityID = %s, TargetCityID = %s", alienID, cityID, targetCityID)
+}
+
+// AlienTrapped implements the WorldEventsListener interface.
+func (m *Monitor) AlienTrapped(alienID, cityID string) {
+	if !m.logsEnabled {
+		return
+	}
+
+	m.logger.
+		Debug().
+		Str(logging.ServiceKey, serviceName).
+		Str("event", "AlienTrapped").
+		Msgf("AlienID = %s, CityID = %s", alienID, cityID)
+}
+
 // AlienDismissed implements the WorldEventsListener interface.
 func (m *Monitor) AlienDismissed(alienID, reason string) {
 	if !m.logsEnabled {
@@ -107,8 +199,21 @@ func (m *Monitor) AlienDismissed(alienID, reason string) {
 		Msgf("AlienID = %s, Reason = %s", alienID, reason)
 }
 
+// SimPhaseChanged implements the WorldEventsListener interface.
+func (m *Monitor) SimPhaseChanged(phase model.SimPhase) {
+	if !m.logsEnabled {
+		return
+	}
+
+	m.logger.
+		Debug().
+		Str(logging.ServiceKey, serviceName).
+		Str("event", "SimPhaseChanged").
+		Msgf("Phase = %s", phase)
+}
+
 // SimStatus implements the WorldEventsListener interface.
-func (m *Monitor) SimStatus(aliens, cities int, simStopped bool) {
+func (m *Monitor) SimStatus(status model.SimStatus) {
 	if !m.logsEnabled {
 		return
 	}
@@ -117,5 +222,8 @@ func (m *Monitor) SimStatus(aliens, cities int, simStopped bool) {
 		Debug().
 		Str(logging.ServiceKey, serviceName).
 		Str("event", "SimStatus").
-		Msgf("Aliens = %d, Cities = %d, Stopped = %v", aliens, cities, simStopped)
+		Msgf("Phase = %s, Aliens = %d (waiting: %d), Cities = %d, Fights = %d, Queued = %d / %d, Stopped = %v, Reason = %s",
+			status.Phase, status.Aliens, status.AliensWaiting, status.Cities, status.Fights,
+			status.AlienRequestsQueued, status.WorldRequestsQueued, status.Stopped, status.StopReason,
+		)
 }
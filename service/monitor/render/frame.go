@@ -0,0 +1,243 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/png" // PNG image format registration
+	"math"
+	"time"
+
+	"github.com/itiky/alienInvasion/pkg/layout"
+	"github.com/itiky/alienInvasion/service/monitor/display/theme"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Status line sizes at the 1.0 scale.
+const (
+	fontDPI       = 72
+	statusHeight  = 40
+	statusOffsetX = 5
+)
+
+// frameRenderer draws World snapshots onto images using pre-scaled theme sprites (same as the native display).
+type frameRenderer struct {
+	grid  layout.Grid
+	scale float64
+
+	cityImage       image.Image
+	roadImageH      image.Image // west / east roads
+	roadImageV      image.Image // north / south roads (rotated)
+	battleImage     image.Image
+	alienImage      image.Image
+	backgroundImage image.Image // scaled to cover the frame (optional)
+	cityNameFace    font.Face
+	statusFace      font.Face
+
+	// Theme sizes (scaled) and colors
+	citySize, cityOffset, roadSize, battleSize, alienSize int
+	cityNameOffsetY                                       int
+	cityNameColor, textColor, backgroundColor             color.Color
+
+	width, height int // frame size
+}
+
+// newFrameRenderer decodes and scales the theme sprites.
+func newFrameRenderer(grid layout.Grid, th theme.Theme, scale float64) (*frameRenderer, error) {
+	r := frameRenderer{
+		grid:            grid,
+		scale:           scale,
+		cityNameColor:   th.CityNameColor,
+		textColor:       th.TextColor,
+		backgroundColor: th.BackgroundColor,
+	}
+	r.citySize, r.cityOffset = r.scaled(th.CitySize), r.scaled(th.CityOffset)
+	r.roadSize, r.battleSize, r.alienSize = r.scaled(th.RoadSize), r.scaled(th.BattleSize), r.scaled(th.AlienSize)
+	r.cityNameOffsetY = r.scaled(th.CityNameFontSize + 1)
+
+	fontData, err := opentype.Parse(th.Font)
+	if err != nil {
+		return nil, fmt.Errorf("decoding Font: %w", err)
+	}
+
+	for _, face := range []struct {
+		name   string
+		size   int
+		target *font.Face
+	}{
+		{name: "CityName", size: th.CityNameFontSize, target: &r.cityNameFace},
+		{name: "status", size: th.PanelFontSize, target: &r.statusFace},
+	} {
+		*face.target, err = opentype.NewFace(fontData, &opentype.FaceOptions{
+			Size:    math.Max(1.0, float64(face.size)*scale),
+			DPI:     fontDPI,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating %s font face: %w", face.name, err)
+		}
+	}
+
+	for _, sprite := range []struct {
+		name   string
+		data   []byte
+		size   int
+		target *image.Image
+	}{
+		{name: "CityImage", data: th.CityImage, size: r.citySize, target: &r.cityImage},
+		{name: "RoadImage", data: th.RoadImage, size: r.roadSize, target: &r.roadImageH},
+		{name: "BattleImage", data: th.BattleImage, size: r.battleSize, target: &r.battleImage},
+		{name: "AlienImage", data: th.AlienImage, size: r.alienSize, target: &r.alienImage},
+	} {
+		img, _, err := image.Decode(bytes.NewReader(sprite.data))
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", sprite.name, err)
+		}
+		*sprite.target = scaleImage(img, sprite.size, sprite.size)
+	}
+	r.roadImageV = rotateImage(r.roadImageH)
+
+	r.width = r.cityOffset + grid.Cols*(r.citySize+r.cityOffset)
+	r.height = r.cityOffset + grid.Rows*(r.citySize+r.cityOffset) + r.scaled(statusHeight)
+
+	// Background image covers the frame keeping the aspect ratio (same as the native display)
+	if th.BackgroundImage != nil {
+		img, _, err := image.Decode(bytes.NewReader(th.BackgroundImage))
+		if err != nil {
+			return nil, fmt.Errorf("decoding BackgroundImage: %w", err)
+		}
+
+		b := img.Bounds()
+		k := math.Max(float64(r.width)/float64(b.Dx()), float64(r.height)/float64(b.Dy()))
+		w, h := int(math.Ceil(float64(b.Dx())*k)), int(math.Ceil(float64(b.Dy())*k))
+		scaled := scaleImage(img, w, h)
+		r.backgroundImage = scaled.(*image.RGBA).SubImage(image.Rect((w-r.width)/2, (h-r.height)/2, (w-r.width)/2+r.width, (h-r.height)/2+r.height))
+	}
+
+	return &r, nil
+}
+
+// Draw renders a World snapshot: cities with roads and battles, landed aliens and the status line.
+func (r *frameRenderer) Draw(snapshot mirror.Snapshot) *image.RGBA {
+	frame := image.NewRGBA(image.Rect(0, 0, r.width, r.height))
+	draw.Draw(frame, frame.Bounds(), image.NewUniform(r.backgroundColor), image.Point{}, draw.Src)
+	if r.backgroundImage != nil {
+		draw.Draw(frame, frame.Bounds(), r.backgroundImage, r.backgroundImage.Bounds().Min, draw.Over)
+	}
+
+	cw, ch := r.citySize, r.citySize
+	rw, rh := r.roadSize, r.roadSize
+
+	for _, city := range snapshot.Cities {
+		pos, ok := r.grid.Positions[city.Name]
+		if !ok || city.Destroyed {
+			continue
+		}
+		x, y := r.cityXY(pos)
+
+		// Roads
+		if city.Roads.North != "" {
+			r.drawSprite(frame, r.roadImageV, x+cw/2-rh/2, y-rw)
+		}
+		if city.Roads.East != "" {
+			r.drawSprite(frame, r.roadImageH, x+cw, y+ch/2-rh/2)
+		}
+		if city.Roads.South != "" {
+			r.drawSprite(frame, r.roadImageV, x+cw/2-rh/2, y+ch)
+		}
+		if city.Roads.West != "" {
+			r.drawSprite(frame, r.roadImageH, x-rw, y+ch/2-rh/2)
+		}
+
+		// City and battle
+		r.drawSprite(frame, r.cityImage, x, y)
+		if city.Fight != nil {
+			bw, bh := r.battleSize, r.battleSize
+			r.drawSprite(frame, r.battleImage, x+cw/2-bw/2, y+ch/2-bh/2)
+		}
+
+		// City name
+		r.drawText(frame, r.cityNameFace, r.cityNameColor, city.Name, x, y+ch+r.cityNameOffsetY)
+	}
+
+	// Aliens (at the City center, as the native display does once the movement animation is over)
+	aw, ah := r.alienSize, r.alienSize
+	for _, alien := range snapshot.Aliens {
+		if alien.State != mirror.AlienStateLanded {
+			continue
+		}
+		pos, ok := r.grid.Positions[alien.CityID]
+		if !ok {
+			continue
+		}
+		x, y := r.cityXY(pos)
+		r.drawSprite(frame, r.alienImage, x+cw/2-aw/2, y+ch/2-ah/2)
+	}
+
+	// Status
+	status := snapshot.Status
+	statusMsg := fmt.Sprintf("%s | aliens: %d (waiting: %d) | cities: %d / %d | fights: %d | %v",
+		status.Phase, status.Aliens, status.AliensWaiting, status.Cities, status.CitiesInitial, status.Fights,
+		status.SimElapsed.Truncate(100*time.Millisecond),
+	)
+	r.drawText(frame, r.statusFace, r.textColor, statusMsg, r.scaled(statusOffsetX), r.height-r.scaled(statusHeight)/3)
+
+	return frame
+}
+
+// cityXY returns the City top-left abs coordinates (same as the native citySprite.SetLocation).
+func (r *frameRenderer) cityXY(pos layout.Position) (int, int) {
+	x := pos.X*r.citySize + (pos.X+1)*r.cityOffset
+	y := pos.Y*r.citySize + (pos.Y+1)*r.cityOffset
+
+	return x, y
+}
+
+// drawSprite draws an image with its top-left corner at the {x, y} position.
+func (r *frameRenderer) drawSprite(dst draw.Image, src image.Image, x, y int) {
+	rect := src.Bounds().Sub(src.Bounds().Min).Add(image.Pt(x, y))
+	draw.Draw(dst, rect, src, src.Bounds().Min, draw.Over)
+}
+
+// drawText draws a text line with its baseline starting at the {x, y} position.
+func (r *frameRenderer) drawText(dst draw.Image, face font.Face, clr color.Color, text string, x, y int) {
+	d := font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(clr),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// scaled returns a size adjusted to the frame scale.
+func (r *frameRenderer) scaled(v int) int {
+	return int(math.Round(float64(v) * r.scale))
+}
+
+// scaleImage resizes an image.
+func scaleImage(src image.Image, width, height int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.ApproxBiLinear.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Over, nil)
+
+	return dst
+}
+
+// rotateImage rotates an image by 90 degrees clockwise.
+func rotateImage(src image.Image) image.Image {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dy(), b.Dx()))
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			dst.Set(b.Max.Y-1-y, x-b.Min.X, src.At(x, y))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,341 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/layout"
+	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/monitor"
+	"github.com/itiky/alienInvasion/service/monitor/display/theme"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+	"github.com/rs/zerolog"
+)
+
+const (
+	serviceName = "RenderMonitor"
+
+	// Mirror subscriber events buffer (a dropped subscriber resubscribes).
+	eventsBufferSize = 1024
+
+	// Final frame GIF delay.
+	gifFinalFrameDelay = 3 * time.Second
+)
+
+var _ monitor.WorldEventsListener = (*Monitor)(nil)
+
+type (
+	// Monitor renders the simulation headlessly (no GPU or window needed) on fixed intervals
+	// into an animated GIF and / or a directory of PNG frames.
+	// Events are handled by the embedded mirror.Monitor.
+	Monitor struct {
+		*mirror.Monitor
+
+		grid      layout.Grid   // Cities grid layout (same as the native display)
+		theme     theme.Theme   // sprite images, sizes and colors (same as the native display)
+		gifPath   string        // animated GIF output path (optional)
+		framesDir string        // PNG frames output directory (optional)
+		interval  time.Duration // frames capture period (simulated time)
+		scale     float64       // frame size scale (1.0 is the native display size)
+		gifMax    int           // max number of GIF frames kept in memory
+
+		renderer    *frameRenderer
+		gifFrames   []*image.Paletted
+		gifDelays   []int
+		gifStep     int // number of captures per GIF frame (doubled once the max number of frames is reached)
+		gifCaptures int // number of captures since the GIF start
+		framesCnt   int
+	}
+
+	// Option defines the New constructor options.
+	Option func(m *Monitor) error
+)
+
+// WithGIF enables the animated GIF output.
+func WithGIF(path string) Option {
+	return func(m *Monitor) error {
+		if path == "" {
+			return fmt.Errorf("GIF path: empty")
+		}
+		m.gifPath = path
+
+		return nil
+	}
+}
+
+// WithFramesDir enables the PNG frames output (directory is created if not exists).
+func WithFramesDir(dir string) Option {
+	return func(m *Monitor) error {
+		if dir == "" {
+			return fmt.Errorf("frames dir: empty")
+		}
+		m.framesDir = dir
+
+		return nil
+	}
+}
+
+// WithInterval overrides the default frames capture period.
+// The period is the simulated time: it is scaled by the simulation speed (a frame per 250ms at the 2.0 speed is captured every 125ms).
+func WithInterval(interval time.Duration) Option {
+	return func(m *Monitor) error {
+		if interval < 10*time.Millisecond {
+			return fmt.Errorf("interval: must be GTE 10ms")
+		}
+		m.interval = interval
+
+		return nil
+	}
+}
+
+// WithScale overrides the default frame size scale.
+func WithScale(scale float64) Option {
+	return func(m *Monitor) error {
+		if scale <= 0 || scale > 4.0 {
+			return fmt.Errorf("scale: must be in (0, 4] range")
+		}
+		m.scale = scale
+
+		return nil
+	}
+}
+
+// WithMaxGIFFrames overrides the default max number of GIF frames kept in memory.
+// Once the limit is reached, every second frame is dropped (the remaining ones last longer) and the following captures are thinned out the same way,
+// so the GIF covers the whole simulation at a lower frame rate.
+func WithMaxGIFFrames(n int) Option {
+	return func(m *Monitor) error {
+		if n < 2 {
+			return fmt.Errorf("max GIF frames: must be GTE 2")
+		}
+		m.gifMax = n
+
+		return nil
+	}
+}
+
+// WithTheme overrides the default frames theme (sprite images, sizes and colors, see the theme.Load function).
+func WithTheme(t theme.Theme) Option {
+	return func(m *Monitor) error {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("theme: %w", err)
+		}
+		m.theme = t
+
+		return nil
+	}
+}
+
+// New creates a new Monitor instance.
+func New(cityMap model.CityMap, aliens []model.Alien, opts ...Option) (*Monitor, error) {
+	m := Monitor{
+		Monitor:  mirror.New(cityMap, aliens),
+		grid:     layout.New(cityMap),
+		theme:    theme.Default(),
+		interval: 250 * time.Millisecond,
+		scale:    0.5,
+		gifMax:   600,
+		gifStep:  1,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&m); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.gifPath == "" && m.framesDir == "" {
+		return nil, fmt.Errorf("no output: GIF path or frames dir must be set")
+	}
+
+	renderer, err := newFrameRenderer(m.grid, m.theme, m.scale)
+	if err != nil {
+		return nil, fmt.Errorf("creating frame renderer: %w", err)
+	}
+	m.renderer = renderer
+
+	if m.framesDir != "" {
+		if err := os.MkdirAll(m.framesDir, 0o755); err != nil {
+			return nil, fmt.Errorf("creating frames dir: %w", err)
+		}
+	}
+
+	return &m, nil
+}
+
+// Run captures frames until the simulation stops or the {ctx} is canceled and writes the outputs.
+// Frames are not captured while the simulation is paused.
+func (m *Monitor) Run(ctx context.Context) error {
+	_, eventsCh, cancel := m.Subscribe(eventsBufferSize)
+	defer func() { cancel() }()
+
+	speed := m.Snapshot().Status.Speed
+	ticker := time.NewTicker(m.capturePeriod(speed))
+	defer ticker.Stop()
+
+	if err := m.captureFrame(m.interval, false); err != nil {
+		return err
+	}
+
+	for stopped := false; !stopped; {
+		select {
+		case <-ctx.Done():
+			stopped = true
+		case _, ok := <-eventsCh:
+			if ok {
+				continue
+			}
+			if m.Snapshot().Status.Stopped {
+				stopped = true
+				continue
+			}
+
+			// Dropped as a slow subscriber
+			cancel()
+			_, eventsCh, cancel = m.Subscribe(eventsBufferSize)
+		case <-ticker.C:
+			status := m.Snapshot().Status
+			if status.Speed != speed {
+				speed = status.Speed
+				ticker.Reset(m.capturePeriod(speed))
+			}
+
+			if status.Phase == model.SimPhasePaused {
+				continue
+			}
+			if err := m.captureFrame(m.interval, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Final state
+	if err := m.captureFrame(gifFinalFrameDelay, true); err != nil {
+		return err
+	}
+
+	if m.gifPath != "" {
+		if err := m.writeGIF(); err != nil {
+			return err
+		}
+		m.log(ctx).Info().Msgf("Animation saved to %s (%d frames)", m.gifPath, len(m.gifFrames))
+	}
+	if m.framesDir != "" {
+		m.log(ctx).Info().Msgf("Frames saved to %s (%d frames)", m.framesDir, m.framesCnt)
+	}
+
+	return nil
+}
+
+// capturePeriod returns the wall-clock frames capture period for the simulation {speed}.
+func (m *Monitor) capturePeriod(speed float64) time.Duration {
+	if speed <= 0 {
+		return m.interval
+	}
+
+	return time.Duration(float64(m.interval) / speed)
+}
+
+// captureFrame renders the current World state and stores it to the outputs.
+// The {final} frame is always added to the GIF.
+func (m *Monitor) captureFrame(delay time.Duration, final bool) error {
+	frame := m.renderer.Draw(m.Snapshot())
+
+	if m.framesDir != "" {
+		path := filepath.Join(m.framesDir, fmt.Sprintf("frame_%05d.png", m.framesCnt))
+		if err := writePNG(path, frame); err != nil {
+			return fmt.Errorf("writing frame (%s): %w", path, err)
+		}
+	}
+	m.framesCnt++
+
+	if m.gifPath != "" {
+		m.addGIFFrame(frame, delay, final)
+	}
+
+	return nil
+}
+
+// addGIFFrame adds a captured frame to the GIF keeping the number of frames within the limit.
+// A skipped (thinned out) capture prolongs the previous frame.
+func (m *Monitor) addGIFFrame(frame image.Image, delay time.Duration, final bool) {
+	delayCs := int(delay / (10 * time.Millisecond))
+
+	captureIdx := m.gifCaptures
+	m.gifCaptures++
+
+	// Drop every second frame merging delays (the following captures are thinned out the same way)
+	if len(m.gifFrames) >= m.gifMax {
+		keepCnt := (len(m.gifFrames) + 1) / 2
+		for i := 0; i < keepCnt; i++ {
+			frameDelay := m.gifDelays[2*i]
+			if 2*i+1 < len(m.gifDelays) {
+				frameDelay += m.gifDelays[2*i+1]
+			}
+			m.gifFrames[i], m.gifDelays[i] = m.gifFrames[2*i], frameDelay
+		}
+		for i := keepCnt; i < len(m.gifFrames); i++ {
+			m.gifFrames[i] = nil
+		}
+		m.gifFrames, m.gifDelays = m.gifFrames[:keepCnt], m.gifDelays[:keepCnt]
+		m.gifStep *= 2
+	}
+
+	if !final && captureIdx%m.gifStep != 0 {
+		m.gifDelays[len(m.gifDelays)-1] += delayCs
+		return
+	}
+
+	paletted := image.NewPaletted(frame.Bounds(), palette.Plan9)
+	draw.Draw(paletted, paletted.Bounds(), frame, image.Point{}, draw.Src)
+
+	m.gifFrames = append(m.gifFrames, paletted)
+	m.gifDelays = append(m.gifDelays, delayCs)
+}
+
+// writeGIF writes the captured frames as an animated GIF.
+func (m *Monitor) writeGIF() error {
+	f, err := os.Create(m.gifPath)
+	if err != nil {
+		return fmt.Errorf("creating GIF file: %w", err)
+	}
+	defer f.Close()
+
+	if err := gif.EncodeAll(f, &gif.GIF{Image: m.gifFrames, Delay: m.gifDelays}); err != nil {
+		return fmt.Errorf("encoding GIF: %w", err)
+	}
+
+	return f.Close()
+}
+
+// writePNG writes an image to a PNG file.
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// log returns logger with service fields set.
+func (m *Monitor) log(ctx context.Context) *zerolog.Logger {
+	_, logger := logging.GetCtxLogger(ctx)
+	logger = logger.With().Str(logging.ServiceKey, serviceName).Logger()
+
+	return &logger
+}
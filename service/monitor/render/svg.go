@@ -0,0 +1,172 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/itiky/alienInvasion/pkg/layout"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+)
+
+// SVG sizes (the native display default theme ones) and colors (status line sizes are shared with the frame renderer).
+const (
+	cityWidth, cityHeight = 100, 100
+	cityOffsetXY          = 50
+	cityNameOffsetY       = 25
+	roadWidth             = 50
+	fontSize              = 24
+
+	svgRoadThickness = 12
+	svgBadgeRadius   = 16
+	svgCityRadius    = 12
+
+	svgColorBackground = "#101018"
+	svgColorRoad       = "#8a8070"
+	svgColorCity       = "#3a6ea5"
+	svgColorFight      = "#d03030"
+	svgColorRuins      = "#505050"
+	svgColorBadge      = "#7cc242"
+	svgColorText       = "#ffffff"
+)
+
+// WriteSVG writes a World snapshot as an SVG image: cities on the grid layout with roads, labels,
+// occupied cities with aliens counters, battles and destroyed cities as ruins.
+// Output is deterministic for the same input (diff friendly).
+func WriteSVG(w io.Writer, grid layout.Grid, snapshot mirror.Snapshot) error {
+	width := cityOffsetXY + grid.Cols*(cityWidth+cityOffsetXY)
+	height := cityOffsetXY + grid.Rows*(cityHeight+cityOffsetXY)
+	if snapshot.Status.Phase != "" {
+		height += statusHeight
+	}
+
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n", width, height, width, height)
+	fmt.Fprintf(&buf, `  <rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgColorBackground)
+
+	// Roads first (cities are drawn on top), two-way roads share the same rect
+	roadRects := make(map[[4]int]bool)
+	roadRect := func(x, y, width, height int) {
+		key := [4]int{x, y, width, height}
+		if roadRects[key] {
+			return
+		}
+		roadRects[key] = true
+		fmt.Fprintf(&buf, `    <rect x="%d" y="%d" width="%d" height="%d"/>`+"\n", x, y, width, height)
+	}
+
+	buf.WriteString(`  <g id="roads" fill="` + svgColorRoad + `">` + "\n")
+	for _, city := range snapshot.Cities {
+		pos, ok := grid.Positions[city.Name]
+		if !ok || city.Destroyed {
+			continue
+		}
+		x, y := svgCityXY(pos)
+		cx, cy := x+cityWidth/2, y+cityHeight/2
+
+		if city.Roads.North != "" {
+			roadRect(cx-svgRoadThickness/2, y-roadWidth, svgRoadThickness, roadWidth)
+		}
+		if city.Roads.East != "" {
+			roadRect(x+cityWidth, cy-svgRoadThickness/2, roadWidth, svgRoadThickness)
+		}
+		if city.Roads.South != "" {
+			roadRect(cx-svgRoadThickness/2, y+cityHeight, svgRoadThickness, roadWidth)
+		}
+		if city.Roads.West != "" {
+			roadRect(x-roadWidth, cy-svgRoadThickness/2, roadWidth, svgRoadThickness)
+		}
+	}
+	buf.WriteString("  </g>\n")
+
+	// Cities
+	buf.WriteString(`  <g id="cities">` + "\n")
+	for _, city := range snapshot.Cities {
+		pos, ok := grid.Positions[city.Name]
+		if !ok {
+			continue
+		}
+		x, y := svgCityXY(pos)
+		cx, cy := x+cityWidth/2, y+cityHeight/2
+
+		fmt.Fprintf(&buf, `    <g id="city-%s">`+"\n", svgEscape(city.Name))
+		switch {
+		case city.Destroyed:
+			fmt.Fprintf(&buf, `      <rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="none" stroke="%s" stroke-width="4" stroke-dasharray="10 6"/>`+"\n",
+				x, y, cityWidth, cityHeight, svgCityRadius, svgColorRuins)
+			fmt.Fprintf(&buf, `      <path d="M%d %d L%d %d M%d %d L%d %d" stroke="%s" stroke-width="4"/>`+"\n",
+				x+20, y+20, x+cityWidth-20, y+cityHeight-20, x+cityWidth-20, y+20, x+20, y+cityHeight-20, svgColorRuins)
+		default:
+			stroke := svgColorCity
+			if city.Fight != nil {
+				stroke = svgColorFight
+			}
+			fmt.Fprintf(&buf, `      <rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="%s" fill-opacity="0.35" stroke="%s" stroke-width="4"/>`+"\n",
+				x, y, cityWidth, cityHeight, svgCityRadius, svgColorCity, stroke)
+		}
+
+		aliensCnt := len(city.AlienIDs)
+		if city.Fight != nil {
+			aliensCnt = len(city.Fight.AlienIDs)
+		}
+		if aliensCnt > 0 && !city.Destroyed {
+			fmt.Fprintf(&buf, `      <circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n", cx, cy, svgBadgeRadius, svgColorBadge)
+			fmt.Fprintf(&buf, `      <text x="%d" y="%d" font-size="18" text-anchor="middle" dominant-baseline="central" fill="#000000">%d</text>`+"\n", cx, cy, aliensCnt)
+		}
+
+		fmt.Fprintf(&buf, `      <text x="%d" y="%d" font-size="%d" fill="%s">%s</text>`+"\n",
+			x, y+cityHeight+cityNameOffsetY, fontSize, svgColorText, svgEscape(city.Name))
+		buf.WriteString("    </g>\n")
+	}
+	buf.WriteString("  </g>\n")
+
+	// Status
+	if status := snapshot.Status; status.Phase != "" {
+		statusMsg := fmt.Sprintf("%s | aliens: %d (waiting: %d) | cities: %d / %d | fights: %d | %v",
+			status.Phase, status.Aliens, status.AliensWaiting, status.Cities, status.CitiesInitial, status.Fights,
+			status.SimElapsed.Truncate(100*time.Millisecond),
+		)
+		if status.Stopped {
+			statusMsg += " | " + status.StopReason
+		}
+		fmt.Fprintf(&buf, `  <text x="%d" y="%d" font-size="18" fill="%s">%s</text>`+"\n",
+			statusOffsetX, height-statusHeight/3, svgColorText, svgEscape(statusMsg))
+	}
+
+	buf.WriteString("</svg>\n")
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// WriteSVGFile writes a World snapshot as an SVG file (see WriteSVG).
+func WriteSVGFile(path string, grid layout.Grid, snapshot mirror.Snapshot) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating SVG file: %w", err)
+	}
+	defer f.Close()
+
+	if err := WriteSVG(f, grid, snapshot); err != nil {
+		return fmt.Errorf("writing SVG: %w", err)
+	}
+
+	return f.Close()
+}
+
+// svgCityXY returns the City top-left abs coordinates (same as the native citySprite.SetLocation).
+func svgCityXY(pos layout.Position) (int, int) {
+	return pos.X*cityWidth + (pos.X+1)*cityOffsetXY, pos.Y*cityHeight + (pos.Y+1)*cityOffsetXY
+}
+
+// svgEscape escapes a text for the XML output.
+func svgEscape(s string) string {
+	buf := bytes.Buffer{}
+	_ = xml.EscapeText(&buf, []byte(s))
+
+	return buf.String()
+}
@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itiky/alienInvasion/pkg/layout"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+)
+
+// ANSI escape codes.
+const (
+	ansiClearScreen = "\x1b[2J"
+	ansiCursorHome  = "\x1b[H"
+	ansiClearLine   = "\x1b[K"
+	ansiClearBelow  = "\x1b[J"
+	ansiHideCursor  = "\x1b[?25l"
+	ansiShowCursor  = "\x1b[?25h"
+
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// Grid cell sizes in characters.
+// City box is placed the same way as the native display does (citySprite.SetLocation): offset + col * (box + offset).
+const (
+	cityBoxWidth  = 12 // including borders
+	cityBoxHeight = 4  // including borders
+	cityOffsetX   = 4  // space for horizontal roads
+	cityOffsetY   = 1  // space for vertical roads
+)
+
+type (
+	// screenCell keeps a screen character with its color.
+	screenCell struct {
+		r     rune
+		color string
+	}
+
+	// screen is a characters buffer.
+	screen struct {
+		cells [][]screenCell // [row][col]
+	}
+)
+
+// newScreen creates an empty screen.
+func newScreen(width, height int) *screen {
+	s := screen{
+		cells: make([][]screenCell, height),
+	}
+	for y := range s.cells {
+		s.cells[y] = make([]screenCell, width)
+		for x := range s.cells[y] {
+			s.cells[y][x].r = ' '
+		}
+	}
+
+	return &s
+}
+
+// text puts a string starting from the {x, y} position (out of range characters are skipped).
+func (s *screen) text(x, y int, str, color string) {
+	if y < 0 || y >= len(s.cells) {
+		return
+	}
+
+	for _, r := range str {
+		if x >= 0 && x < len(s.cells[y]) {
+			s.cells[y][x] = screenCell{r: r, color: color}
+		}
+		x++
+	}
+}
+
+// lines returns screen rows with color escape codes.
+func (s *screen) lines() []string {
+	lines := make([]string, 0, len(s.cells))
+	for _, row := range s.cells {
+		b, color := strings.Builder{}, ""
+		for _, c := range row {
+			if c.color != color {
+				b.WriteString(ansiReset + c.color)
+				color = c.color
+			}
+			b.WriteRune(c.r)
+		}
+		if color != "" {
+			b.WriteString(ansiReset)
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+
+	return lines
+}
+
+// render builds the screen lines: title, cities grid, status panel and events log.
+func render(title string, grid layout.Grid, snapshot mirror.Snapshot, logLines []string, logSize int) []string {
+	s := newScreen(
+		cityOffsetX+grid.Cols*(cityBoxWidth+cityOffsetX),
+		cityOffsetY+grid.Rows*(cityBoxHeight+cityOffsetY),
+	)
+	for _, city := range snapshot.Cities {
+		pos, ok := grid.Positions[city.Name]
+		if !ok {
+			continue
+		}
+		drawCity(s, pos, city)
+	}
+
+	lines := []string{ansiBold + title + ansiReset}
+	lines = append(lines, s.lines()...)
+	lines = append(lines, renderStatus(snapshot.Status)...)
+	lines = append(lines, "", ansiBold+"Events:"+ansiReset)
+	for i := 0; i < logSize; i++ {
+		line := ""
+		if i < len(logLines) {
+			line = logLines[i]
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// drawCity draws a City box with road connectors, aliens counter and fight marker:
+//   +----------+
+//   |Foo       |--
+//   |A:3 FIGHT |
+//   +----------+
+func drawCity(s *screen, pos layout.Position, city mirror.CityState) {
+	x := cityOffsetX + pos.X*(cityBoxWidth+cityOffsetX)
+	y := cityOffsetY + pos.Y*(cityBoxHeight+cityOffsetY)
+
+	boxColor, border, corner := "", "-", "+"
+	switch {
+	case city.Destroyed:
+		boxColor, border, corner = ansiDim, ".", "."
+	case city.Fight != nil:
+		boxColor = ansiRed
+	}
+
+	// Roads (half of the offset from each side)
+	if city.Roads.North != "" {
+		s.text(x+cityBoxWidth/2, y-1, "|", "")
+	}
+	if city.Roads.South != "" {
+		s.text(x+cityBoxWidth/2, y+cityBoxHeight, "|", "")
+	}
+	if city.Roads.West != "" {
+		s.text(x-cityOffsetX/2, y+1, strings.Repeat("-", cityOffsetX/2), "")
+	}
+	if city.Roads.East != "" {
+		s.text(x+cityBoxWidth, y+1, strings.Repeat("-", cityOffsetX/2), "")
+	}
+
+	// Box
+	hLine := corner + strings.Repeat(border, cityBoxWidth-2) + corner
+	s.text(x, y, hLine, boxColor)
+	s.text(x, y+cityBoxHeight-1, hLine, boxColor)
+	for i := 1; i < cityBoxHeight-1; i++ {
+		s.text(x, y+i, "|", boxColor)
+		s.text(x+cityBoxWidth-1, y+i, "|", boxColor)
+	}
+
+	// Content
+	nameColor := ansiBold
+	if city.Destroyed {
+		nameColor = ansiDim
+	}
+	s.text(x+1, y+1, truncate(city.Name, cityBoxWidth-2), nameColor)
+
+	switch {
+	case city.Destroyed:
+		s.text(x+1, y+2, "ruins", ansiDim)
+	case city.Fight != nil:
+		info := fmt.Sprintf("A:%d", len(city.Fight.AlienIDs))
+		s.text(x+1, y+2, info, ansiYellow)
+		s.text(x+1+len(info)+1, y+2, "FIGHT", ansiRed+ansiBold)
+	case len(city.AlienIDs) > 0:
+		s.text(x+1, y+2, fmt.Sprintf("A:%d", len(city.AlienIDs)), ansiYellow)
+	}
+}
+
+// renderStatus builds the status panel lines.
+func renderStatus(status mirror.Status) []string {
+	lines := []string{
+		fmt.Sprintf("%sPhase:%s %s | %sAliens:%s %d (waiting: %d) | %sCities:%s %d / %d | %sFights:%s %d",
+			ansiCyan, ansiReset, status.Phase,
+			ansiCyan, ansiReset, status.Aliens, status.AliensWaiting,
+			ansiCyan, ansiReset, status.Cities, status.CitiesInitial,
+			ansiCyan, ansiReset, status.Fights,
+		),
+		fmt.Sprintf("%sSim time:%s %v | %sWall time:%s %v | %sQueues:%s aliens %d, world %d",
+			ansiCyan, ansiReset, status.SimElapsed.Round(100*time.Millisecond),
+			ansiCyan, ansiReset, status.WallElapsed.Round(100*time.Millisecond),
+			ansiCyan, ansiReset, status.AlienRequestsQueued, status.WorldRequestsQueued,
+		),
+	}
+	if status.Stopped {
+		lines = append(lines, fmt.Sprintf("%sStopped:%s %s", ansiRed+ansiBold, ansiReset, status.StopReason))
+	}
+
+	return lines
+}
+
+// formatEvent builds an events log line (empty for events that are not logged).
+func formatEvent(e mirror.Event) string {
+	msg := ""
+	switch e.Type {
+	case mirror.EventCityFightStarted:
+		msg = fmt.Sprintf("City %s: fight started [%s] (%v)", e.CityID, strings.Join(e.AlienIDs, ","), e.Duration)
+	case mirror.EventCityFightProlonged:
+		msg = fmt.Sprintf("City %s: fight prolonged [%s] (%v)", e.CityID, strings.Join(e.AlienIDs, ","), e.Duration)
+	case mirror.EventCityFightEnded:
+		msg = fmt.Sprintf("City %s: fight ended [%s] (%v)", e.CityID, strings.Join(e.AlienIDs, ","), e.Duration)
+	case mirror.EventCityDestroyed:
+		msg = fmt.Sprintf("City %s: destroyed by [%s]", e.CityID, strings.Join(e.AlienIDs, ","))
+	case mirror.EventAlienLanded:
+		msg = fmt.Sprintf("Alien %s: landed at %s", e.AlienID, e.CityID)
+	case mirror.EventAlienLandingFailed:
+		msg = fmt.Sprintf("Alien %s: landing at %s failed", e.AlienID, e.CityID)
+	case mirror.EventAlienRelocated:
+		msg = fmt.Sprintf("Alien %s: moved to %s", e.AlienID, e.CityID)
+	case mirror.EventAlienMoveRefused:
+		msg = fmt.Sprintf("Alien %s: move %s -> %s refused", e.AlienID, e.CityID, e.TargetCityID)
+	case mirror.EventAlienTrapped:
+		msg = fmt.Sprintf("Alien %s: trapped at %s", e.AlienID, e.CityID)
+	case mirror.EventAlienDismissed:
+		msg = fmt.Sprintf("Alien %s: dismissed (%s)", e.AlienID, e.Reason)
+	case mirror.EventSimPhaseChanged:
+		msg = fmt.Sprintf("Simulation phase: %s", e.Phase)
+	default:
+		return ""
+	}
+
+	return e.Time.Format("15:04:05.000") + " " + msg
+}
+
+// truncate cuts a string to the max length (in runes).
+func truncate(str string, maxLen int) string {
+	if runes := []rune(str); len(runes) > maxLen {
+		return string(runes[:maxLen])
+	}
+
+	return str
+}
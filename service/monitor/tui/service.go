@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/layout"
+	"github.com/itiky/alienInvasion/service/monitor"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+)
+
+// Mirror subscriber events buffer (a dropped subscriber resubscribes).
+const eventsBufferSize = 1024
+
+var _ monitor.WorldEventsListener = (*Monitor)(nil)
+
+type (
+	// Monitor draws the simulation within a terminal using ANSI escape codes (cities grid, status panel and events log).
+	// Events are handled by the embedded mirror.Monitor.
+	Monitor struct {
+		*mirror.Monitor
+
+		out         io.Writer     // Screen output
+		title       string        // Screen header
+		grid        layout.Grid   // Cities grid layout (same as the native display)
+		refreshRate time.Duration // Screen redraw period
+		logSize     int           // Number of events log lines shown
+
+		logMtx   sync.Mutex
+		logLines []string // Last {logSize} events / log lines
+	}
+
+	// Option defines the New constructor options.
+	Option func(m *Monitor) error
+)
+
+// WithOutput overrides the default screen output (stdout).
+func WithOutput(w io.Writer) Option {
+	return func(m *Monitor) error {
+		if w == nil {
+			return fmt.Errorf("output: nil")
+		}
+		m.out = w
+
+		return nil
+	}
+}
+
+// WithTitle overrides the default screen header.
+func WithTitle(title string) Option {
+	return func(m *Monitor) error {
+		m.title = title
+
+		return nil
+	}
+}
+
+// WithRefreshRate overrides the default screen redraw period.
+func WithRefreshRate(rate time.Duration) Option {
+	return func(m *Monitor) error {
+		if rate <= 0 {
+			return fmt.Errorf("refresh rate: must be GT 0")
+		}
+		m.refreshRate = rate
+
+		return nil
+	}
+}
+
+// WithLogSize overrides the default number of events log lines shown.
+func WithLogSize(size int) Option {
+	return func(m *Monitor) error {
+		if size < 0 {
+			return fmt.Errorf("log size: must be GTE 0")
+		}
+		m.logSize = size
+
+		return nil
+	}
+}
+
+// New creates a new Monitor instance.
+func New(cityMap model.CityMap, aliens []model.Alien, opts ...Option) (*Monitor, error) {
+	m := Monitor{
+		Monitor:     mirror.New(cityMap, aliens),
+		out:         os.Stdout,
+		title:       "Alien invasion simulation",
+		grid:        layout.New(cityMap),
+		refreshRate: 200 * time.Millisecond,
+		logSize:     10,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&m); err != nil {
+			return nil, err
+		}
+	}
+
+	return &m, nil
+}
+
+// Run draws the screen until the simulation stops or the {ctx} is canceled.
+// The final state is left on the screen.
+func (m *Monitor) Run(ctx context.Context) {
+	fmt.Fprint(m.out, ansiClearScreen+ansiHideCursor)
+	defer fmt.Fprint(m.out, ansiShowCursor)
+
+	_, eventsCh, cancel := m.Subscribe(eventsBufferSize)
+	defer func() { cancel() }()
+
+	ticker := time.NewTicker(m.refreshRate)
+	defer ticker.Stop()
+
+	m.draw()
+	for {
+		select {
+		case <-ctx.Done():
+			m.draw()
+			return
+		case event, ok := <-eventsCh:
+			if !ok {
+				if m.Snapshot().Status.Stopped {
+					m.draw()
+					return
+				}
+
+				// Dropped as a slow subscriber
+				cancel()
+				_, eventsCh, cancel = m.Subscribe(eventsBufferSize)
+				continue
+			}
+			if line := formatEvent(event); line != "" {
+				m.addLogLine(line)
+			}
+		case <-ticker.C:
+			m.draw()
+		}
+	}
+}
+
+// LogWriter returns a writer that redirects log lines to the events log (a plain text logger output is expected).
+// Logs written directly to the terminal would break the screen.
+func (m *Monitor) LogWriter() io.Writer {
+	return logWriterFunc(func(p []byte) (int, error) {
+		for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+			m.addLogLine(line)
+		}
+
+		return len(p), nil
+	})
+}
+
+// addLogLine appends an events log line dropping the oldest ones.
+func (m *Monitor) addLogLine(line string) {
+	m.logMtx.Lock()
+	defer m.logMtx.Unlock()
+
+	m.logLines = append(m.logLines, line)
+	if len(m.logLines) > m.logSize {
+		m.logLines = m.logLines[len(m.logLines)-m.logSize:]
+	}
+}
+
+// draw renders the current state and writes it to the output (cursor is moved home, no screen flickering).
+func (m *Monitor) draw() {
+	m.logMtx.Lock()
+	logLines := append([]string(nil), m.logLines...)
+	m.logMtx.Unlock()
+
+	buf := bytes.Buffer{}
+	buf.WriteString(ansiCursorHome)
+	for _, line := range render(m.title, m.grid, m.Snapshot(), logLines, m.logSize) {
+		buf.WriteString(line)
+		buf.WriteString(ansiClearLine + "\n")
+	}
+	buf.WriteString(ansiClearBelow)
+
+	_, _ = m.out.Write(buf.Bytes())
+}
+
+// logWriterFunc is an io.Writer adapter.
+type logWriterFunc func(p []byte) (int, error)
+
+// Write implements the io.Writer interface.
+func (f logWriterFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,220 @@
+package web
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"image/color"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/layout"
+	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/monitor"
+	"github.com/itiky/alienInvasion/service/monitor/display/theme"
+	"github.com/itiky/alienInvasion/service/monitor/mirror"
+	"github.com/rs/zerolog"
+)
+
+const serviceName = "WebMonitor"
+
+//go:embed static/index.html
+var indexPage []byte
+
+var _ monitor.WorldEventsListener = (*Monitor)(nil)
+
+type (
+	// Monitor serves a browser viewer page and pushes simulation events to it via Server-Sent Events.
+	// Events are handled by the embedded mirror.Monitor.
+	Monitor struct {
+		*mirror.Monitor
+
+		address string      // HTTP server listen address
+		grid    layout.Grid // Cities grid layout (same as the native display)
+		theme   theme.Theme // sprite images and sizes (same as the native display)
+	}
+
+	// Option defines the New constructor options.
+	Option func(m *Monitor) error
+
+	// tileParams defines the viewer sprite sizes and the background (same as the native display).
+	tileParams struct {
+		CityWidth       int    `json:"cityWidth"`
+		CityHeight      int    `json:"cityHeight"`
+		CityOffsetXY    int    `json:"cityOffsetXY"`
+		CityNameOffsetY int    `json:"cityNameOffsetY"`
+		CityNameSize    int    `json:"cityNameSize"`
+		RoadWidth       int    `json:"roadWidth"`
+		RoadHeight      int    `json:"roadHeight"`
+		BattleWidth     int    `json:"battleWidth"`
+		BattleHeight    int    `json:"battleHeight"`
+		AlienWidth      int    `json:"alienWidth"`
+		AlienHeight     int    `json:"alienHeight"`
+		AlienMoveSpeed  int    `json:"alienMoveSpeed"`  // ticks (used if an Alien speed is unknown, the Alien speed is in the snapshot otherwise)
+		Background      bool   `json:"background"`      // background image is served
+		BackgroundColor string `json:"backgroundColor"` // CSS color below the background image
+	}
+
+	// viewerSnapshot defines the first SSE event data.
+	viewerSnapshot struct {
+		Grid     layout.Grid     `json:"grid"`
+		Tile     tileParams      `json:"tile"`
+		Snapshot mirror.Snapshot `json:"snapshot"`
+	}
+)
+
+// WithAddress overrides the default HTTP server listen address.
+func WithAddress(address string) Option {
+	return func(m *Monitor) error {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return fmt.Errorf("address (%s): invalid: %w", address, err)
+		}
+		m.address = address
+
+		return nil
+	}
+}
+
+// WithTheme overrides the default viewer theme (sprite images and sizes, see the theme.Load function).
+func WithTheme(t theme.Theme) Option {
+	return func(m *Monitor) error {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("theme: %w", err)
+		}
+		m.theme = t
+
+		return nil
+	}
+}
+
+// New creates a new Monitor instance.
+func New(cityMap model.CityMap, aliens []model.Alien, opts ...Option) (*Monitor, error) {
+	m := Monitor{
+		Monitor: mirror.New(cityMap, aliens),
+		address: "127.0.0.1:8090",
+		grid:    layout.New(cityMap),
+		theme:   theme.Default(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&m); err != nil {
+			return nil, err
+		}
+	}
+
+	return &m, nil
+}
+
+// Start starts the HTTP server in a separate routine.
+// Server is stopped when the {ctx} is canceled.
+func (m *Monitor) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", m.address)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", m.address, err)
+	}
+
+	server := &http.Server{
+		Handler:           m.router(),
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			m.log(ctx).Warn().Err(err).Msg("HTTP server shutdown failed")
+		}
+	}()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			m.log(ctx).Error().Err(err).Msg("HTTP server failed")
+		}
+	}()
+
+	m.log(ctx).Info().Msgf("Serving viewer on http://%s", listener.Addr())
+
+	return nil
+}
+
+// router builds the viewer routes:
+//   * GET /                 - viewer page;
+//   * GET /assets/{name}    - sprite and background (if set by the theme) images;
+//   * GET /events           - Server-Sent Events stream (layout with a snapshot first, then events);
+func (m *Monitor) router() http.Handler {
+	th := m.theme
+	assets := map[string][]byte{
+		"Alien.png":  th.AlienImage,
+		"Battle.png": th.BattleImage,
+		"City.png":   th.CityImage,
+		"Road.png":   th.RoadImage,
+	}
+	if th.BackgroundImage != nil {
+		assets["Background.png"] = th.BackgroundImage
+	}
+
+	tile := tileParams{
+		CityWidth:       th.CitySize,
+		CityHeight:      th.CitySize,
+		CityOffsetXY:    th.CityOffset,
+		CityNameOffsetY: th.CityNameFontSize + 1,
+		CityNameSize:    th.CityNameFontSize,
+		RoadWidth:       th.RoadSize,
+		RoadHeight:      th.RoadSize,
+		BattleWidth:     th.BattleSize,
+		BattleHeight:    th.BattleSize,
+		AlienWidth:      th.AlienSize,
+		AlienHeight:     th.AlienSize,
+		AlienMoveSpeed:  th.AlienMoveSpeed,
+		Background:      th.BackgroundImage != nil,
+		BackgroundColor: cssColor(th.BackgroundColor),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(indexPage)
+	})
+	mux.HandleFunc("/assets/", func(w http.ResponseWriter, r *http.Request) {
+		asset, ok := assets[r.URL.Path[len("/assets/"):]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", http.DetectContentType(asset))
+		w.Header().Set("Cache-Control", "max-age=3600")
+		_, _ = w.Write(asset)
+	})
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		m.ServeEvents(w, r, func(snapshot mirror.Snapshot) interface{} {
+			return viewerSnapshot{Grid: m.grid, Tile: tile, Snapshot: snapshot}
+		})
+	})
+
+	return mux
+}
+
+// cssColor converts a color to the CSS rgba() notation.
+func cssColor(clr color.Color) string {
+	c := color.NRGBAModel.Convert(clr).(color.NRGBA)
+
+	return fmt.Sprintf("rgba(%d, %d, %d, %.3f)", c.R, c.G, c.B, float64(c.A)/255.0)
+}
+
+// log returns logger with service fields set.
+func (m *Monitor) log(ctx context.Context) *zerolog.Logger {
+	_, logger := logging.GetCtxLogger(ctx)
+	logger = logger.With().Str(logging.ServiceKey, serviceName).Logger()
+
+	return &logger
+}
@@ -3,29 +3,64 @@ package sim
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/config"
 	"github.com/itiky/alienInvasion/pkg/logging"
 	"github.com/itiky/alienInvasion/service/monitor"
 	"github.com/itiky/alienInvasion/service/monitor/noop"
 	"github.com/itiky/alienInvasion/service/sim/state"
+	"github.com/itiky/alienInvasion/service/sim/stop"
+	"github.com/itiky/alienInvasion/service/sim/types"
+)
+
+// Simulation speed multiplier limits.
+const (
+	SpeedMin = 0.1
+	SpeedMax = 16.0
 )
 
 type (
 	// Processor implements the World simulation engine.
 	Processor struct {
 		// Params
-		cityMap model.CityMap
-		aliens  []model.Alien
-		monitor monitor.WorldEventsListener
+		cfg           config.SimConfig
+		seed          int64
+		cityMap       model.CityMap
+		aliens        []model.Alien
+		stopCondition types.StopCondition
+		monitor       monitor.WorldEventsListener
 
 		// State
 		worldState *state.World
+		simStopCh  chan struct{}
 	}
 
 	Option func(p *Processor) error
 )
 
+// WithConfig is the Processor constructor option that overrides the default SimConfig param.
+func WithConfig(cfg config.SimConfig) Option {
+	return func(p *Processor) error {
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("validating config: %w", err)
+		}
+		p.cfg = cfg
+
+		return nil
+	}
+}
+
+// WithSeed is the Processor constructor option that overrides the default (time based) random source seed param.
+func WithSeed(seed int64) Option {
+	return func(p *Processor) error {
+		p.seed = seed
+
+		return nil
+	}
+}
+
 // WithCityMap is the Processor constructor option that sets the CityMap param.
 func WithCityMap(cm model.CityMap) Option {
 	return func(p *Processor) error {
@@ -47,6 +82,18 @@ func WithAliens(aliens []model.Alien) Option {
 	}
 }
 
+// WithStopCondition is the Processor constructor option that overrides the StopCondition param defined by SimConfig.
+func WithStopCondition(condition types.StopCondition) Option {
+	return func(p *Processor) error {
+		if condition == nil {
+			return fmt.Errorf("stop condition: nil")
+		}
+		p.stopCondition = condition
+
+		return nil
+	}
+}
+
 // WithMonitor is the Processor constructor option that sets the Monitor param.
 func WithMonitor(monitor monitor.WorldEventsListener) Option {
 	return func(p *Processor) error {
@@ -63,6 +110,8 @@ func WithMonitor(monitor monitor.WorldEventsListener) Option {
 func New(opts ...Option) (*Processor, error) {
 	// Construction
 	p := Processor{
+		cfg:     config.DefaultSimConfig(),
+		seed:    time.Now().UnixNano(),
 		monitor: noop.New(),
 	}
 	for _, opt := range opts {
@@ -79,11 +128,23 @@ func New(opts ...Option) (*Processor, error) {
 		return nil, fmt.Errorf("aliens are not defined (empty)")
 	}
 
+	if p.stopCondition == nil {
+		stopCondition, err := stop.Parse(p.cfg.StopConditions)
+		if err != nil {
+			return nil, fmt.Errorf("parsing stop conditions: %w", err)
+		}
+		p.stopCondition = stopCondition
+	}
+
 	return &p, nil
 }
 
-// Start starts the simulation engine and returns simulation stopped channel (close channel)
-// Contract: config is valid.
+// Seed returns the random source seed the simulation is using.
+func (p *Processor) Seed() int64 {
+	return p.seed
+}
+
+// Start starts the simulation engine and returns simulation stopped channel (close channel).
 func (p *Processor) Start(ctx context.Context) chan struct{} {
 	// Enrich logger context
 	ctx, logger := logging.GetCtxLogger(ctx)
@@ -93,9 +154,69 @@ func (p *Processor) Start(ctx context.Context) chan struct{} {
 	// Start the engine worker
 	simStopCh := make(chan struct{})
 
-	worldState := state.NewWorld(p.cityMap, p.monitor)
-	go worldState.Run(ctx, p.aliens, simStopCh)
-	p.worldState = worldState
+	worldState := state.NewWorld(p.cityMap, p.cfg, p.seed, p.stopCondition, p.monitor)
+	p.worldState, p.simStopCh = worldState, simStopCh
+
+	// Stop Alien / City runners once the World is stopped
+	ctx, ctxCancel := context.WithCancel(ctx)
+	go func() {
+		worldState.Run(ctx, p.aliens, simStopCh)
+		ctxCancel()
+	}()
 
 	return simStopCh
 }
+
+// Pause freezes a running simulation (Aliens, fights and disembark), the World is moved to the paused phase.
+func (p *Processor) Pause() error {
+	return p.control(types.NewSimPauseRequest())
+}
+
+// Resume unfreezes a paused simulation.
+func (p *Processor) Resume() error {
+	return p.control(types.NewSimResumeRequest())
+}
+
+// Stop stops the simulation with a reason (no-op if already stopped).
+func (p *Processor) Stop(reason string) error {
+	return p.control(types.NewSimStopRequest(reason))
+}
+
+// SetSpeed changes the simulation speed multiplier (1.0 is the real time): Aliens steps, fights and disembark are scaled.
+func (p *Processor) SetSpeed(speed float64) error {
+	if speed < SpeedMin || speed > SpeedMax {
+		return fmt.Errorf("speed: must be in [%.1f, %.1f] range", SpeedMin, SpeedMax)
+	}
+
+	return p.control(types.NewSimSpeedRequest(speed))
+}
+
+// Step runs a paused simulation for a single Alien step (the longest one) and pauses it again.
+// No-op if the simulation is not paused.
+func (p *Processor) Step() error {
+	return p.control(types.NewSimStepRequest(p.cfg.Alien.StepMaxDur))
+}
+
+// control sends a lifecycle control request to the World.
+func (p *Processor) control(r types.ControlRequest) error {
+	if p.worldState == nil {
+		return fmt.Errorf("%s: simulation is not started", r.ControlName())
+	}
+	p.worldState.Control(r)
+
+	return nil
+}
+
+// Result returns the simulation outcome if the simulation is stopped.
+func (p *Processor) Result() (model.SimResult, bool) {
+	if p.simStopCh == nil {
+		return model.SimResult{}, false
+	}
+
+	select {
+	case <-p.simStopCh:
+		return p.worldState.Result(), true
+	default:
+		return model.SimResult{}, false
+	}
+}
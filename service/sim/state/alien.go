@@ -19,6 +19,15 @@ type alienWorldNotifierExpected interface {
 
 	// EvacuateAlien sends Alien evacuation request if Alien has no steps left.
 	EvacuateAlien(r types.AlienEvacuateRequest)
+
+	// ReportAlienTrapped sends Alien's "no roads left" report.
+	ReportAlienTrapped(r types.AlienTrappedRequest)
+
+	// WaitRunning blocks while the simulation is paused (false is returned if the {ctx} has been canceled).
+	WaitRunning(ctx context.Context) bool
+
+	// ScaleDuration converts a simulated time duration to the wall-clock one (simulation speed).
+	ScaleDuration(d time.Duration) time.Duration
 }
 
 // Alien keeps an Alien runner state.
@@ -26,30 +35,39 @@ type Alien struct {
 	model.Alien
 
 	// State
-	curLocation model.City
-	curSteps    uint
+	curLocation   model.City
+	locationSeq   uint64                           // curLocation event sequence number
+	pendingUpdate *types.AlienLocationUpdatedEvent // update for the City Alien is relocating to (arrived before the relocation)
+	curSteps      uint
+	trapped       bool // "no roads left" has been reported
 
 	// Params
+	rnd           *rand.Rand // next road picker source (owned by the runner)
 	worldNotifier alienWorldNotifierExpected
 
 	// Input event channels
-	worldEventsCh chan types.AlienEvent
+	worldEventsCh     chan types.AlienEvent
+	locationUpdatesCh chan types.AlienLocationUpdatedEvent // latest location update only (see UpdateLocation)
+	sentLocationSeq   uint64                               // last sent location event sequence number (World engine goroutine only)
 }
 
 // NewAlien creates a new Alien state.
 // Contract: inputs are valid.
-func NewAlien(alien model.Alien, startLocation model.City, worldNotifier alienWorldNotifierExpected) *Alien {
+func NewAlien(alien model.Alien, startLocation model.City, rnd *rand.Rand, worldNotifier alienWorldNotifierExpected) *Alien {
 	return &Alien{
-		Alien:         alien,
-		curLocation:   startLocation,
-		worldNotifier: worldNotifier,
-		worldEventsCh: make(chan types.AlienEvent, 1),
+		Alien:             alien,
+		curLocation:       startLocation,
+		rnd:               rnd,
+		worldNotifier:     worldNotifier,
+		worldEventsCh:     make(chan types.AlienEvent, 1),
+		locationUpdatesCh: make(chan types.AlienLocationUpdatedEvent, 1),
 	}
 }
 
 // Run is an Alien lifecycle worker which reacts to World events and sends requests to it.
 func (a *Alien) Run(ctx context.Context) {
-	stepTicker := time.NewTicker(a.Speed)
+	stepDur := a.worldNotifier.ScaleDuration(a.Speed)
+	stepTicker := time.NewTicker(stepDur)
 	defer stepTicker.Stop()
 
 	for working := true; working; {
@@ -71,28 +89,95 @@ func (a *Alien) Run(ctx context.Context) {
 			default:
 				a.log(ctx).Warn().Msgf("Event (%T) skipped: unknown type", eBz)
 			}
+		case e := <-a.locationUpdatesCh:
+			a.handleLocationUpdatedEvent(ctx, e)
 		case <-stepTicker.C:
+			if !a.worldNotifier.WaitRunning(ctx) {
+				working = false
+				break
+			}
 			a.handleNextStepEvent(ctx)
+
+			// Simulation speed might have changed
+			if d := a.worldNotifier.ScaleDuration(a.Speed); d != stepDur {
+				stepDur = d
+				stepTicker.Reset(stepDur)
+			}
 		}
 	}
 }
 
 // Relocate notifies an Alien about a confirmed move.
+// Relocations and location updates are delivered by different channels, so both are numbered to be applied in the sending order.
+// Contract: called by the World engine goroutine only.
 func (a *Alien) Relocate(e types.AlienRelocatedEvent) {
+	a.sentLocationSeq++
+	e.Seq = a.sentLocationSeq
+
 	a.worldEventsCh <- e
 }
 
+// UpdateLocation notifies an Alien about its current City changes.
+// The call never blocks (it is made by the World engine while the Alien's runner might be waiting for it):
+// a pending update is replaced by the {e} one, since only the latest City state matters.
+// Contract: called by the World engine goroutine only.
+func (a *Alien) UpdateLocation(e types.AlienLocationUpdatedEvent) {
+	a.sentLocationSeq++
+	e.Seq = a.sentLocationSeq
+
+	select {
+	case a.locationUpdatesCh <- e:
+		return
+	default:
+	}
+
+	// Coalesce: drop the pending (outdated) update, the runner might have taken it already
+	select {
+	case <-a.locationUpdatesCh:
+	default:
+	}
+	a.locationUpdatesCh <- e
+}
+
 // Dismiss notifies an Alien's runner to stop.
 func (a *Alien) Dismiss(e types.AlienDismissedEvent) {
 	a.worldEventsCh <- e
 }
 
 // handleRelocatedEvent handles a received type.AlienRelocatedEvent event.
+// A pending update for the new City sent after the relocation is applied right away.
 func (a *Alien) handleRelocatedEvent(ctx context.Context, e types.AlienRelocatedEvent) {
-	a.curLocation = e.NewLocation
+	a.curLocation, a.locationSeq = e.NewLocation, e.Seq
+
+	pending := a.pendingUpdate
+	if pending == nil || pending.Seq < e.Seq {
+		a.pendingUpdate = nil
+		return
+	}
+	if pending.Location.Name == a.curLocation.Name {
+		a.pendingUpdate = nil
+		a.curLocation, a.locationSeq = pending.Location, pending.Seq
+	}
 }
 
-// handleRelocatedEvent handles a received type.AlienRelocatedEvent event.
+// handleLocationUpdatedEvent handles a received type.AlienLocationUpdatedEvent event.
+// Updates sent before the current location state are skipped.
+// An update for another City has overtaken the relocation to it: it is kept until the relocation arrives.
+func (a *Alien) handleLocationUpdatedEvent(ctx context.Context, e types.AlienLocationUpdatedEvent) {
+	if e.Seq < a.locationSeq {
+		return
+	}
+
+	if e.Location.Name != a.curLocation.Name {
+		if a.pendingUpdate == nil || a.pendingUpdate.Seq < e.Seq {
+			a.pendingUpdate = &e
+		}
+		return
+	}
+	a.curLocation, a.locationSeq = e.Location, e.Seq
+}
+
+// handleDismissedEvent handles a received type.AlienDismissedEvent event.
 func (a *Alien) handleDismissedEvent(ctx context.Context, e types.AlienDismissedEvent) {
 	a.log(ctx).Info().Msgf("Alien dismissed (%s)", e.Reason)
 }
@@ -109,11 +194,16 @@ func (a *Alien) handleNextStepEvent(ctx context.Context) {
 
 	availableRoads := a.curLocation.AvailableRoads()
 	if len(availableRoads) == 0 {
-		// Nowhere to move
+		// Nowhere to move (roads are never rebuilt, so report only once)
+		if !a.trapped {
+			a.trapped = true
+			r := types.NewAlienTrappedRequest(a.Name)
+			a.worldNotifier.ReportAlienTrapped(r)
+		}
 		return
 	}
 
-	roadIdx := rand.Intn(len(availableRoads)) //nolint:gosec
+	roadIdx := a.rnd.Intn(len(availableRoads))
 	r := types.NewAlienMoveRequest(a.Name, availableRoads[roadIdx])
 	a.worldNotifier.MoveAlien(r)
 }
@@ -0,0 +1,171 @@
+package state
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/service/sim/types"
+)
+
+var _ alienWorldNotifierExpected = (*testWorldNotifier)(nil)
+
+// testWorldNotifier records Alien requests.
+type testWorldNotifier struct {
+	moves     []string
+	evacuated int
+	trapped   int
+}
+
+func (n *testWorldNotifier) MoveAlien(r types.AlienMoveRequest) {
+	n.moves = append(n.moves, r.NewCityID)
+}
+
+func (n *testWorldNotifier) EvacuateAlien(types.AlienEvacuateRequest) {
+	n.evacuated++
+}
+
+func (n *testWorldNotifier) ReportAlienTrapped(types.AlienTrappedRequest) {
+	n.trapped++
+}
+
+func (n *testWorldNotifier) WaitRunning(context.Context) bool {
+	return true
+}
+
+func (n *testWorldNotifier) ScaleDuration(d time.Duration) time.Duration {
+	return d
+}
+
+func TestAlienLocationEvents(t *testing.T) {
+	type testCase struct {
+		name             string
+		events           []types.AlienEvent // in the receiving order
+		expectedLocation model.City
+		expectedTrapped  bool
+	}
+
+	cityA := model.City{Name: "A", EastRoad: "B"}
+	cityB := model.City{Name: "B", WestRoad: "A", EastRoad: "C"}
+	cityBIsolated := model.City{Name: "B"}
+
+	newRelocated := func(location model.City, seq uint64) types.AlienEvent {
+		e := types.NewAlienRelocatedEvent("x", location)
+		e.Seq = seq
+		return e
+	}
+	newUpdated := func(location model.City, seq uint64) types.AlienEvent {
+		e := types.NewAlienLocationUpdatedEvent("x", location)
+		e.Seq = seq
+		return e
+	}
+
+	testCases := []testCase{
+		{
+			name: "Relocation then update",
+			events: []types.AlienEvent{
+				newRelocated(cityB, 1),
+				newUpdated(cityBIsolated, 2),
+			},
+			expectedLocation: cityBIsolated,
+			expectedTrapped:  true,
+		},
+		{
+			name: "Update overtakes the relocation",
+			events: []types.AlienEvent{
+				newUpdated(cityBIsolated, 2),
+				newRelocated(cityB, 1),
+			},
+			expectedLocation: cityBIsolated,
+			expectedTrapped:  true,
+		},
+		{
+			name: "Outdated update for the old City",
+			events: []types.AlienEvent{
+				newRelocated(cityB, 2),
+				newUpdated(cityA, 1),
+			},
+			expectedLocation: cityB,
+		},
+		{
+			name: "Outdated pending update is dropped on relocation",
+			events: []types.AlienEvent{
+				newUpdated(cityBIsolated, 1),
+				newRelocated(cityB, 2),
+			},
+			expectedLocation: cityB,
+		},
+		{
+			name: "Latest pending update wins",
+			events: []types.AlienEvent{
+				newUpdated(model.City{Name: "B", WestRoad: "A"}, 2),
+				newUpdated(cityBIsolated, 3),
+				newRelocated(cityB, 1),
+			},
+			expectedLocation: cityBIsolated,
+			expectedTrapped:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			notifier := &testWorldNotifier{}
+			alien := NewAlien(model.Alien{Name: "x", MaxSteps: 10}, cityA, rand.New(rand.NewSource(1)), notifier)
+
+			for _, eBz := range tc.events {
+				switch e := eBz.(type) {
+				case types.AlienRelocatedEvent:
+					alien.handleRelocatedEvent(ctx, e)
+				case types.AlienLocationUpdatedEvent:
+					alien.handleLocationUpdatedEvent(ctx, e)
+				}
+			}
+
+			if !reflect.DeepEqual(alien.curLocation, tc.expectedLocation) {
+				t.Errorf("location: got %+v, expected %+v", alien.curLocation, tc.expectedLocation)
+			}
+
+			// "No roads left" is reported once and no moves are requested
+			alien.handleNextStepEvent(ctx)
+			alien.handleNextStepEvent(ctx)
+			if tc.expectedTrapped {
+				if notifier.trapped != 1 || len(notifier.moves) != 0 {
+					t.Errorf("trapped: got %d reports and %d moves, expected 1 report and no moves", notifier.trapped, len(notifier.moves))
+				}
+				return
+			}
+			if notifier.trapped != 0 || len(notifier.moves) != 2 {
+				t.Errorf("moves: got %d reports and %d moves, expected no reports and 2 moves", notifier.trapped, len(notifier.moves))
+			}
+		})
+	}
+}
+
+func TestAlienUpdateLocation(t *testing.T) {
+	alien := NewAlien(model.Alien{Name: "x"}, model.City{Name: "A"}, rand.New(rand.NewSource(1)), &testWorldNotifier{})
+
+	// Events are numbered in the sending order, pending updates are coalesced without blocking
+	alien.Relocate(types.NewAlienRelocatedEvent("x", model.City{Name: "B", WestRoad: "A"}))
+	alien.UpdateLocation(types.NewAlienLocationUpdatedEvent("x", model.City{Name: "B", WestRoad: "A"}))
+	alien.UpdateLocation(types.NewAlienLocationUpdatedEvent("x", model.City{Name: "B"}))
+
+	relocated, ok := (<-alien.worldEventsCh).(types.AlienRelocatedEvent)
+	if !ok || relocated.Seq != 1 {
+		t.Fatalf("relocation: got %+v, expected Seq 1", relocated)
+	}
+
+	updated := <-alien.locationUpdatesCh
+	if expected := (types.AlienLocationUpdatedEvent{AlienID: "x", Location: model.City{Name: "B"}, Seq: 3}); updated != expected {
+		t.Errorf("update: got %+v, expected %+v", updated, expected)
+	}
+
+	select {
+	case e := <-alien.locationUpdatesCh:
+		t.Errorf("update: outdated %+v not coalesced", e)
+	default:
+	}
+}
@@ -5,18 +5,27 @@ import (
 	"time"
 
 	"github.com/itiky/alienInvasion/model"
-	"github.com/itiky/alienInvasion/pkg/config"
 	"github.com/itiky/alienInvasion/pkg/logging"
 	"github.com/itiky/alienInvasion/service/sim/types"
 	"github.com/rs/zerolog"
-	"github.com/spf13/viper"
 )
 
-// alienWorldNotifierExpected notifies the World simulation engine about Alien's intentions.
-// Since Alien has no idea about what is happening to the World, it asks a sim engine to do stuff.
+// FightStatus defines a City fight state change caused by a new Alien.
+type FightStatus int
+
+const (
+	FightStatusNone      FightStatus = iota // no fight
+	FightStatusStarted                      // a new fight has started
+	FightStatusProlonged                    // an ongoing fight has been prolonged
+)
+
+// cityWorldNotifierExpected notifies the World simulation engine about City's state changes.
 type cityWorldNotifierExpected interface {
 	// CityDestroyed sends City destroy request when the fight is over.
 	CityDestroyed(r types.CityDestroyRequest)
+
+	// ScaleDuration converts a simulated time duration to the wall-clock one (simulation speed).
+	ScaleDuration(d time.Duration) time.Duration
 }
 
 // City keeps City's state with all Aliens on tile.
@@ -24,19 +33,25 @@ type City struct {
 	model.City
 
 	// State
-	fightTimer *time.Timer
-	aliens     map[string]*Alien // key: AlienID
+	fightTimer     *time.Timer
+	fightStartedAt time.Time
+	fightDeadline  time.Time
+	fightPaused    bool
+	fightTimeLeft  time.Duration     // time left of a paused fight
+	aliens         map[string]*Alien // key: AlienID
 
 	// Params
+	fightDurK     time.Duration // fight duration per Alien power
 	worldNotifier cityWorldNotifierExpected
 }
 
 // NewCity creates a new City state.
 // Contract: inputs are valid.
-func NewCity(location model.City, worldNotifier cityWorldNotifierExpected) *City {
+func NewCity(location model.City, fightDurK time.Duration, worldNotifier cityWorldNotifierExpected) *City {
 	return &City{
 		City:          location,
 		aliens:        make(map[string]*Alien),
+		fightDurK:     fightDurK,
 		worldNotifier: worldNotifier,
 	}
 }
@@ -46,6 +61,24 @@ func (c *City) AtFight() bool {
 	return len(c.aliens) > 1
 }
 
+// FightDuration returns the time passed since the current fight has started.
+func (c *City) FightDuration() time.Duration {
+	if c.fightStartedAt.IsZero() {
+		return 0
+	}
+
+	return time.Since(c.fightStartedAt)
+}
+
+// FightDeadline returns the ongoing fight estimated end (wall-clock), false if there is no fight or it is paused.
+func (c *City) FightDeadline() (time.Time, bool) {
+	if c.fightTimer == nil || c.fightPaused {
+		return time.Time{}, false
+	}
+
+	return c.fightDeadline, true
+}
+
 // AlienIDs returns all Alien IDs on that City tile.
 func (c *City) AlienIDs() []string {
 	ids := make([]string, 0, len(c.aliens))
@@ -72,17 +105,17 @@ func (c *City) RemoveRoadsTo(cityID string) {
 	}
 }
 
-// AddAlien adds Alien on that City tile and informs that a new fight has started.
-func (c *City) AddAlien(alien *Alien) bool {
+// AddAlien adds Alien on that City tile and informs that a new fight has started / prolonged (with the estimated wall-clock duration).
+func (c *City) AddAlien(alien *Alien) (FightStatus, time.Duration) {
 	if alien == nil {
-		return false
+		return FightStatusNone, 0
 	}
 
 	c.aliens[alien.Name] = alien
 
 	// Check if a fight has started
 	if len(c.aliens) == 1 {
-		return false
+		return FightStatusNone, 0
 	}
 
 	// Estimated fight duration
@@ -90,28 +123,72 @@ func (c *City) AddAlien(alien *Alien) bool {
 	for _, alien := range c.aliens {
 		totalAlienPower += alien.Power
 	}
-	fightDuration := viper.GetDuration(config.CityFightDurK) * time.Duration(totalAlienPower)
+	fightDuration := c.worldNotifier.ScaleDuration(c.fightDurK * time.Duration(totalAlienPower))
 
 	// Reset fight timer (prolong the fight)
 	if c.fightTimer != nil {
+		c.fightDeadline = time.Now().Add(fightDuration)
 		c.fightTimer.Reset(fightDuration)
-		return true
+		return FightStatusProlonged, fightDuration
 	}
 
-	// Start the notification routine
-	c.fightTimer = time.NewTimer(fightDuration)
+	// Start the notification routine (the timer is kept till the City is destroyed, the routine doesn't touch the state)
+	c.fightStartedAt = time.Now()
+	c.fightDeadline = c.fightStartedAt.Add(fightDuration)
+	fightTimer := time.NewTimer(fightDuration)
+	c.fightTimer = fightTimer
 	go func() {
-		defer func() {
-			c.fightTimer = nil
-		}()
-
-		<-c.fightTimer.C
+		<-fightTimer.C
 
 		r := types.NewCityDestroyRequest(c.Name)
 		c.worldNotifier.CityDestroyed(r)
 	}()
 
-	return true
+	return FightStatusStarted, fightDuration
+}
+
+// PauseFight freezes the ongoing fight timer.
+func (c *City) PauseFight() {
+	if c.fightTimer == nil {
+		return
+	}
+
+	// Timer might have already fired (destroy request is on its way)
+	if c.fightTimer.Stop() {
+		c.fightPaused = true
+		c.fightTimeLeft = time.Until(c.fightDeadline)
+	}
+}
+
+// ResumeFight restarts a paused fight timer shifting the fight timings by the pause duration.
+func (c *City) ResumeFight(pausedFor time.Duration) {
+	if c.fightTimer == nil || !c.fightPaused {
+		return
+	}
+
+	c.fightStartedAt = c.fightStartedAt.Add(pausedFor)
+	c.fightDeadline = time.Now().Add(c.fightTimeLeft)
+	c.fightTimer.Reset(c.fightTimeLeft)
+	c.fightPaused, c.fightTimeLeft = false, 0
+}
+
+// RescaleFight stretches the ongoing fight time left by the {coef} (simulation speed has changed).
+func (c *City) RescaleFight(coef float64) {
+	if c.fightTimer == nil {
+		return
+	}
+
+	if c.fightPaused {
+		c.fightTimeLeft = time.Duration(float64(c.fightTimeLeft) * coef)
+		return
+	}
+
+	// Timer might have already fired (destroy request is on its way)
+	if c.fightTimer.Stop() {
+		timeLeft := time.Duration(float64(time.Until(c.fightDeadline)) * coef)
+		c.fightDeadline = time.Now().Add(timeLeft)
+		c.fightTimer.Reset(timeLeft)
+	}
 }
 
 // RemoveAlien removes Alien from that City tile.
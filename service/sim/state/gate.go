@@ -0,0 +1,52 @@
+package state
+
+import (
+	"context"
+	"sync"
+)
+
+// pauseGate blocks runners (Aliens, disembark) while the simulation is paused.
+type pauseGate struct {
+	sync.Mutex
+	resumeCh chan struct{} // closed on resume (nil if not paused)
+}
+
+// Pause closes the gate (no-op if already closed).
+func (g *pauseGate) Pause() {
+	g.Lock()
+	defer g.Unlock()
+
+	if g.resumeCh == nil {
+		g.resumeCh = make(chan struct{})
+	}
+}
+
+// Resume opens the gate unblocking all waiters (no-op if already opened).
+func (g *pauseGate) Resume() {
+	g.Lock()
+	defer g.Unlock()
+
+	if g.resumeCh != nil {
+		close(g.resumeCh)
+		g.resumeCh = nil
+	}
+}
+
+// Wait blocks while the gate is closed.
+// Returns false if the {ctx} has been canceled.
+func (g *pauseGate) Wait(ctx context.Context) bool {
+	g.Lock()
+	resumeCh := g.resumeCh
+	g.Unlock()
+
+	if resumeCh == nil {
+		return ctx.Err() == nil
+	}
+
+	select {
+	case <-resumeCh:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
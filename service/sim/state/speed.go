@@ -0,0 +1,33 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// speedScale keeps the simulation speed multiplier shared by the World and runners (Aliens, Cities and disembark).
+type speedScale struct {
+	sync.RWMutex
+	k float64 // 1.0 is the real time
+}
+
+// Get returns the current speed multiplier.
+func (s *speedScale) Get() float64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.k
+}
+
+// Set updates the speed multiplier.
+func (s *speedScale) Set(k float64) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.k = k
+}
+
+// Scale converts a simulated time duration to the wall-clock one.
+func (s *speedScale) Scale(d time.Duration) time.Duration {
+	return time.Duration(float64(d) / s.Get())
+}
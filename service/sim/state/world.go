@@ -2,7 +2,9 @@ package state
 
 import (
 	"context"
+	"hash/fnv"
 	"math/rand"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,7 +14,6 @@ import (
 	"github.com/itiky/alienInvasion/service/monitor"
 	"github.com/itiky/alienInvasion/service/sim/types"
 	"github.com/rs/zerolog"
-	"github.com/spf13/viper"
 )
 
 var (
@@ -25,70 +26,156 @@ type World struct {
 	// State
 	cities       map[string]*City  // Cities state (key: CityID)
 	alienCityMap map[string]string // AlienID-CityID matching map (key: AlienID, value: CityID)
+	phase        model.SimPhase    // current lifecycle phase
+	stopReason   string            // stop condition reason (set on draining)
+	pausedPhase  model.SimPhase    // phase to resume to
+	pausedAt     time.Time         // pause start time
+	gate         pauseGate         // blocks runners while paused
+	speed        speedScale        // simulation speed multiplier
+	stepTimer    *time.Timer       // pauses the World once a step is over (nil if not stepping)
+
+	// Stats
+	aliensTotal   int            // number of Aliens to disembark
+	aliensLanded  int            // number of Aliens landed (or failed to)
+	aliensFailed  int            // number of Aliens failed to land
+	aliensGone    map[string]int // number of dismissed Aliens (key: dismiss reason)
+	citiesGone    []string       // destroyed Cities (in order)
+	citiesInitial int            // initial number of Cities
+	startedAt     time.Time      // simulation start time
+	simElapsed    time.Duration  // simulated time
+	simClockAt    time.Time      // last simulated time update
+	lastMoveAt    time.Duration  // last Alien move simulated time
+	eventsCnt     uint64         // number of requests handled
+	drainStartAt  time.Duration  // draining phase start simulated time
+
+	// Params
+	cfg           config.SimConfig
+	seed          int64 // random source seed for disembark and Aliens movement
+	stopCondition types.StopCondition
 
 	// Notifiers
 	stateNotifier monitor.WorldEventsListener
 
+	// Outcome (set on stop)
+	result model.SimResult
+
 	// Input request channels
-	alienRequestsCh chan types.AlienRequest
-	worldRequestsCh chan types.WorldRequest
+	alienRequestsCh   chan types.AlienRequest
+	worldRequestsCh   chan types.WorldRequest
+	controlRequestsCh chan types.ControlRequest
+
+	// World worker stopped channel (close channel) that unblocks request senders
+	doneCh chan struct{}
 }
 
 // NewWorld creates a new World state.
 // Contract: inputs are valid.
-func NewWorld(cityMap model.CityMap, stateNotifier monitor.WorldEventsListener) *World {
+func NewWorld(cityMap model.CityMap, cfg config.SimConfig, seed int64, stopCondition types.StopCondition, stateNotifier monitor.WorldEventsListener) *World {
 	const inputChSize = 100
 
 	w := World{
-		cities:          make(map[string]*City, len(cityMap)),
-		stateNotifier:   stateNotifier,
-		alienRequestsCh: make(chan types.AlienRequest, inputChSize),
-		worldRequestsCh: make(chan types.WorldRequest, inputChSize),
+		cities:            make(map[string]*City, len(cityMap)),
+		citiesInitial:     len(cityMap),
+		aliensGone:        make(map[string]int),
+		speed:             speedScale{k: 1.0},
+		cfg:               cfg,
+		seed:              seed,
+		stopCondition:     stopCondition,
+		stateNotifier:     stateNotifier,
+		alienRequestsCh:   make(chan types.AlienRequest, inputChSize),
+		worldRequestsCh:   make(chan types.WorldRequest, inputChSize),
+		controlRequestsCh: make(chan types.ControlRequest),
+		doneCh:            make(chan struct{}),
 	}
 
 	for _, city := range cityMap {
-		w.cities[city.Name] = NewCity(city, &w)
+		w.cities[city.Name] = NewCity(city, cfg.City.FightDurK, &w)
 	}
 
 	return &w
 }
 
 // Run is the World lifecycle worker which reacts to input events from Aliens / Cities and notifies an external service.
+// Simulation stopped channel is closed on exit.
 func (w *World) Run(ctx context.Context, aliens []model.Alien, simStopCh chan struct{}) {
+	defer close(simStopCh)
+	defer close(w.doneCh)
+
 	// Aliens disembark
 
 	// List of all cities should be done here, as it might change during the operation
+	// Sorted to make the random pick reproducible for the same seed
 	cityIDs := make([]string, 0, len(w.cities))
 	for _, city := range w.cities {
 		cityIDs = append(cityIDs, city.Name)
 	}
+	sort.Strings(cityIDs)
 
 	w.alienCityMap = make(map[string]string, len(aliens))
+	w.aliensTotal = len(aliens)
+	w.startedAt = time.Now()
+	w.simClockAt = w.startedAt
+	w.setPhase(ctx, model.SimPhaseDisembarking)
 	go w.disembarkAliens(ctx, cityIDs, aliens)
 
 	// Worker
-	stopCheckTicker := time.NewTicker(viper.GetDuration(config.AppSimStopCheckRate))
+	stopCheckTicker := time.NewTicker(w.cfg.StopCheckRate)
 	defer stopCheckTicker.Stop()
 
-	for working := true; working; {
+	for w.phase != model.SimPhaseStopped {
+		// Paused World doesn't handle requests, so senders are blocked
+		alienRequestsCh, worldRequestsCh := w.alienRequestsCh, w.worldRequestsCh
+		if w.phase == model.SimPhasePaused {
+			alienRequestsCh, worldRequestsCh = nil, nil
+		}
+
+		var stepEndCh <-chan time.Time
+		if w.stepTimer != nil {
+			stepEndCh = w.stepTimer.C
+		}
+
 		select {
 		case <-ctx.Done():
-			working = false
+			w.stop(ctx, "context canceled")
 		case <-stopCheckTicker.C:
-			if w.checkStopConditions(ctx) {
-				working = false
-				close(simStopCh)
+			w.handleStopCheck(ctx)
+		case <-stepEndCh:
+			w.stepTimer = nil
+			w.handlePauseRequest(ctx)
+		case rBz := <-w.controlRequestsCh:
+			switch r := rBz.(type) {
+			case types.SimPauseRequest:
+				w.handlePauseRequest(ctx)
+			case types.SimResumeRequest:
+				w.handleResumeRequest(ctx)
+			case types.SimStopRequest:
+				w.stop(ctx, r.Reason)
+			case types.SimSpeedRequest:
+				w.handleSpeedRequest(ctx, r)
+			case types.SimStepRequest:
+				w.handleStepRequest(ctx, r)
+			default:
+				w.log(ctx).Warn().Msgf("Control request (%T) skipped: unknown type", rBz)
 			}
-		case rBz := <-w.alienRequestsCh:
+		case rBz := <-alienRequestsCh:
+			if w.phase == model.SimPhaseDraining {
+				// World is frozen, only ongoing fights are finishing
+				break
+			}
+
+			w.eventsCnt++
 			switch r := rBz.(type) {
 			case types.AlienMoveRequest:
 				w.handleAlienMoveRequest(ctx, r)
 			case types.AlienEvacuateRequest:
 				w.handleAlienEvacuateRequest(ctx, r)
+			case types.AlienTrappedRequest:
+				w.handleAlienTrappedRequest(ctx, r)
 			default:
 				w.log(ctx).Warn().Msgf("Alien request (%T) skipped: unknown type", rBz)
 			}
-		case rBz := <-w.worldRequestsCh:
+		case rBz := <-worldRequestsCh:
+			w.eventsCnt++
 			switch r := rBz.(type) {
 			case types.CityDestroyRequest:
 				w.handleCityDestroyRequest(ctx, r)
@@ -101,57 +188,301 @@ func (w *World) Run(ctx context.Context, aliens []model.Alien, simStopCh chan st
 	}
 }
 
+// Control sends a simulation lifecycle control request (no-op if the World is stopped).
+func (w *World) Control(r types.ControlRequest) {
+	select {
+	case w.controlRequestsCh <- r:
+	case <-w.doneCh:
+	}
+}
+
+// WaitRunning implements the alienWorldNotifierExpected interface.
+func (w *World) WaitRunning(ctx context.Context) bool {
+	return w.gate.Wait(ctx)
+}
+
+// ScaleDuration implements the alienWorldNotifierExpected and the cityWorldNotifierExpected interfaces.
+func (w *World) ScaleDuration(d time.Duration) time.Duration {
+	return w.speed.Scale(d)
+}
+
 // CityDestroyed implements the cityWorldNotifierExpected interface.
 func (w *World) CityDestroyed(r types.CityDestroyRequest) {
-	w.worldRequestsCh <- r
+	select {
+	case w.worldRequestsCh <- r:
+	case <-w.doneCh:
+	}
 }
 
 // MoveAlien implements the alienWorldNotifierExpected interface.
 func (w *World) MoveAlien(r types.AlienMoveRequest) {
-	w.alienRequestsCh <- r
+	select {
+	case w.alienRequestsCh <- r:
+	case <-w.doneCh:
+	}
 }
 
 // EvacuateAlien implements the alienWorldNotifierExpected interface.
 func (w *World) EvacuateAlien(r types.AlienEvacuateRequest) {
-	w.alienRequestsCh <- r
+	select {
+	case w.alienRequestsCh <- r:
+	case <-w.doneCh:
+	}
 }
 
-// handleStopCheckEvent checks if simulation should be stopped.
-//   * no Aliens left;
-//   * no Cities left;
-func (w *World) checkStopConditions(ctx context.Context) (retStop bool) {
-	aliens, cities := len(w.alienCityMap), len(w.cities)
+// ReportAlienTrapped implements the alienWorldNotifierExpected interface.
+func (w *World) ReportAlienTrapped(r types.AlienTrappedRequest) {
+	select {
+	case w.alienRequestsCh <- r:
+	case <-w.doneCh:
+	}
+}
 
-	defer func() {
-		w.stateNotifier.SimStatus(aliens, cities, retStop)
-		if retStop {
-			w.log(ctx).
-				Info().
-				Msgf("Simulation stopped (aliens / cities left: %d / %d)", aliens, cities)
+// handleStopCheck reports the current simulation status and moves the World to the next phase if needed:
+//   * running: checks stop conditions and starts draining if one is met;
+//   * draining: stops the simulation if all fights are over or the drain timeout is reached;
+//   * paused: only reports the status;
+func (w *World) handleStopCheck(ctx context.Context) {
+	switch w.phase {
+	case model.SimPhaseRunning:
+		if stop, reason := w.stopCondition.ShouldStop(w.buildStatus()); stop {
+			w.drain(ctx, reason)
+			return
 		}
-	}()
+	case model.SimPhaseDraining:
+		w.updateSimClock(time.Now())
+		if w.simElapsed-w.drainStartAt >= w.cfg.DrainTimeout {
+			w.log(ctx).Info().Msgf("Drain timeout reached: %d fight(s) interrupted", w.activeFights())
+			w.stop(ctx, w.stopReason)
+			return
+		}
+	}
+
+	w.stateNotifier.SimStatus(w.buildStatus())
+}
+
+// drain moves the World to the draining phase (Alien requests are ignored) and waits for ongoing fights to finish.
+func (w *World) drain(ctx context.Context, reason string) {
+	w.stopReason = reason
+	w.updateSimClock(time.Now())
+	w.drainStartAt = w.simElapsed
+	w.setPhase(ctx, model.SimPhaseDraining)
+
+	w.checkDrained(ctx)
+}
 
-	if aliens <= 1 {
-		retStop = true
+// checkDrained stops the draining World if there are no fights left.
+func (w *World) checkDrained(ctx context.Context) {
+	if w.phase != model.SimPhaseDraining || w.activeFights() > 0 {
+		return
 	}
-	if cities == 01 {
-		retStop = true
+
+	w.stop(ctx, w.stopReason)
+}
+
+// handlePauseRequest freezes the World: requests are not handled, runners and fight timers are paused.
+func (w *World) handlePauseRequest(ctx context.Context) {
+	w.cancelStep()
+	if w.phase == model.SimPhasePaused {
+		return
 	}
 
-	return
+	w.updateSimClock(time.Now())
+	w.pausedPhase, w.pausedAt = w.phase, time.Now()
+	w.gate.Pause()
+	for _, city := range w.cities {
+		city.PauseFight()
+	}
+
+	w.setPhase(ctx, model.SimPhasePaused)
+}
+
+// handleResumeRequest unfreezes the paused World shifting timings by the pause duration.
+func (w *World) handleResumeRequest(ctx context.Context) {
+	w.cancelStep()
+	if w.phase != model.SimPhasePaused {
+		return
+	}
+
+	now := time.Now()
+	pausedFor := now.Sub(w.pausedAt)
+
+	w.updateSimClock(now)
+	for _, city := range w.cities {
+		city.ResumeFight(pausedFor)
+	}
+	w.gate.Resume()
+
+	w.setPhase(ctx, w.pausedPhase)
+	for _, city := range w.cities {
+		w.notifyFightDeadline(city)
+	}
+}
+
+// handleSpeedRequest changes the simulation speed rescaling ongoing fights and reports the status.
+func (w *World) handleSpeedRequest(ctx context.Context, r types.SimSpeedRequest) {
+	if r.Speed <= 0 {
+		w.log(ctx).Warn().Msgf("Speed request skipped: invalid speed (%f)", r.Speed)
+		return
+	}
+
+	w.updateSimClock(time.Now())
+	coef := w.speed.Get() / r.Speed
+	w.speed.Set(r.Speed)
+	for _, city := range w.cities {
+		city.RescaleFight(coef)
+		w.notifyFightDeadline(city)
+	}
+
+	w.log(ctx).Info().Msgf("Simulation speed: x%.2f", r.Speed)
+	w.stateNotifier.SimStatus(w.buildStatus())
+}
+
+// handleStepRequest runs the paused World for the requested simulated time and pauses it again.
+func (w *World) handleStepRequest(ctx context.Context, r types.SimStepRequest) {
+	if w.phase != model.SimPhasePaused || r.Duration <= 0 {
+		return
+	}
+
+	w.handleResumeRequest(ctx)
+	w.stepTimer = time.NewTimer(w.speed.Scale(r.Duration))
+}
+
+// cancelStep stops an ongoing step (explicit pause / resume).
+func (w *World) cancelStep() {
+	if w.stepTimer == nil {
+		return
+	}
+
+	w.stepTimer.Stop()
+	w.stepTimer = nil
+}
+
+// stop moves the World to the stopped phase and reports the final simulation status.
+func (w *World) stop(ctx context.Context, reason string) {
+	w.stopReason = reason
+	w.updateSimClock(time.Now())
+	w.gate.Resume()
+	w.setPhase(ctx, model.SimPhaseStopped)
+
+	status := w.buildStatus()
+	w.result = w.buildResult(status)
+
+	w.stateNotifier.SimStatus(status)
+	w.log(ctx).
+		Info().
+		Msgf("Simulation stopped: %s (aliens / cities left: %d / %d)", status.StopReason, status.Aliens, status.Cities)
+}
+
+// Result returns the simulation outcome.
+// Contract: World worker is stopped.
+func (w *World) Result() model.SimResult {
+	return w.result
+}
+
+// buildResult builds the simulation outcome using the final status.
+func (w *World) buildResult(status model.SimStatus) model.SimResult {
+	citiesLeft := make([]string, 0, len(w.cities))
+	for cityID := range w.cities {
+		citiesLeft = append(citiesLeft, cityID)
+	}
+	sort.Strings(citiesLeft)
+
+	survivorIDs := make([]string, 0, len(w.alienCityMap))
+	for alienID := range w.alienCityMap {
+		survivorIDs = append(survivorIDs, alienID)
+	}
+	sort.Strings(survivorIDs)
+
+	return model.SimResult{
+		Seed:             w.seed,
+		Status:           status,
+		CitiesDestroyed:  append([]string(nil), w.citiesGone...),
+		CitiesLeft:       citiesLeft,
+		AliensTotal:      w.aliensTotal,
+		AliensNotLanded:  w.aliensFailed + (w.aliensTotal - w.aliensLanded),
+		AliensEvacuated:  w.aliensGone[model.AlienDismissReasonEvacuated],
+		AliensDestroyed:  w.aliensGone[model.AlienDismissReasonDestroyed],
+		AliensSurvived:   len(survivorIDs),
+		SurvivorAlienIDs: survivorIDs,
+	}
+}
+
+// setPhase updates the World lifecycle phase and notifies about the change.
+func (w *World) setPhase(ctx context.Context, phase model.SimPhase) {
+	if w.phase == phase {
+		return
+	}
+	w.phase = phase
+
+	w.log(ctx).Info().Msgf("Simulation phase: %s", phase)
+	w.stateNotifier.SimPhaseChanged(phase)
+}
+
+// activeFights returns the number of ongoing City fights.
+func (w *World) activeFights() int {
+	cnt := 0
+	for _, city := range w.cities {
+		if city.AtFight() {
+			cnt++
+		}
+	}
+
+	return cnt
+}
+
+// updateSimClock updates the simulated time (paused time is not counted, the speed multiplier is applied).
+func (w *World) updateSimClock(now time.Time) {
+	if w.phase != model.SimPhasePaused {
+		w.simElapsed += time.Duration(float64(now.Sub(w.simClockAt)) * w.speed.Get())
+	}
+	w.simClockAt = now
+}
+
+// buildStatus updates the simulated time and returns the current World stats.
+func (w *World) buildStatus() model.SimStatus {
+	now := time.Now()
+	w.updateSimClock(now)
+
+	return model.SimStatus{
+		Phase:               w.phase,
+		Aliens:              len(w.alienCityMap),
+		AliensWaiting:       w.aliensTotal - w.aliensLanded,
+		Cities:              len(w.cities),
+		CitiesInitial:       w.citiesInitial,
+		Fights:              w.activeFights(),
+		Events:              w.eventsCnt,
+		AlienRequestsQueued: len(w.alienRequestsCh),
+		WorldRequestsQueued: len(w.worldRequestsCh),
+		SimElapsed:          w.simElapsed,
+		WallElapsed:         now.Sub(w.startedAt),
+		SinceLastMove:       w.simElapsed - w.lastMoveAt,
+		Speed:               w.speed.Get(),
+		Stopped:             w.phase == model.SimPhaseStopped,
+		StopReason:          w.stopReason,
+	}
 }
 
 // handleAlienDisembarkRequest handles Alien's request to disembark (be created).
 func (w *World) handleAlienDisembarkRequest(ctx context.Context, r types.AlienDisembarkRequest) {
+	w.aliensLanded++
+	defer func() {
+		if w.phase == model.SimPhaseDisembarking && w.aliensLanded >= w.aliensTotal {
+			w.setPhase(ctx, model.SimPhaseRunning)
+		}
+	}()
+
 	// Check city exists
 	city, ok := w.cities[r.CityID]
 	if !ok {
 		w.log(ctx).Warn().Msgf("Alien disembark failed: city (%s) not found", r.CityID)
+		w.aliensFailed++
+		w.stateNotifier.AlienLandingFailed(r.Alien.Name, r.CityID)
 		return
 	}
 
 	// Disembark
-	alienState := NewAlien(r.Alien, city.City, w)
+	alienState := NewAlien(r.Alien, city.City, w.newAlienRand(r.Alien.Name), w)
 	w.moveAlienTo(ctx, alienState, nil, city)
 	go alienState.Run(ctx)
 }
@@ -185,7 +516,17 @@ func (w *World) handleAlienMoveRequest(ctx context.Context, r types.AlienMoveReq
 
 // handleAlienEvacuateRequest handles Alien's request to evacuate which dismisses Alien from the map.
 func (w *World) handleAlienEvacuateRequest(ctx context.Context, r types.AlienEvacuateRequest) {
-	w.dismissAlien(ctx, r.AlienID, "evacuated")
+	w.dismissAlien(ctx, r.AlienID, model.AlienDismissReasonEvacuated)
+}
+
+// handleAlienTrappedRequest handles Alien's report that there are no roads left to move by.
+func (w *World) handleAlienTrappedRequest(ctx context.Context, r types.AlienTrappedRequest) {
+	cityID, ok := w.alienCityMap[r.AlienID]
+	if !ok {
+		return
+	}
+
+	w.stateNotifier.AlienTrapped(r.AlienID, cityID)
 }
 
 // handleCityDestroyRequest handles City's request to be destroyed dismissing all Aliens, removing City from the map and notifying an external service.
@@ -204,6 +545,10 @@ func (w *World) handleCityDestroyRequest(ctx context.Context, r types.CityDestro
 		}
 
 		connectedCity.RemoveRoadsTo(r.CityID)
+		for _, alien := range connectedCity.aliens {
+			e := types.NewAlienLocationUpdatedEvent(alien.Name, connectedCity.City)
+			alien.UpdateLocation(e)
+		}
 		w.stateNotifier.CityUpdated(connectedCity.City)
 	}
 
@@ -212,20 +557,26 @@ func (w *World) handleCityDestroyRequest(ctx context.Context, r types.CityDestro
 	removeConnection(city.SouthRoad)
 	removeConnection(city.WestRoad)
 
-	// Dismiss aliens
+	// End the fight
 	aliensInvolved := city.AlienIDs()
+	w.stateNotifier.CityFightEnded(city.Name, aliensInvolved, city.FightDuration())
+
+	// Dismiss aliens
 	for _, alien := range city.aliens {
-		w.dismissAlien(ctx, alien.Name, "destroyed")
+		w.dismissAlien(ctx, alien.Name, model.AlienDismissReasonDestroyed)
 	}
 
 	// Remove city
 	delete(w.cities, city.Name)
+	w.citiesGone = append(w.citiesGone, city.Name)
 
 	// Log
 	city.Log(ctx).Info().Msgf("Destroyed by: %s", strings.Join(aliensInvolved, ", "))
 
 	// Notify
 	w.stateNotifier.CityDestroyed(city.Name, aliensInvolved)
+
+	w.checkDrained(ctx)
 }
 
 // dismissAlien dismisses a single Alien removing it from a City.
@@ -253,6 +604,7 @@ func (w *World) dismissAlien(ctx context.Context, alienID, reason string) {
 	// Remove bindings
 	delete(city.aliens, alien.Name)
 	delete(w.alienCityMap, alien.Name)
+	w.aliensGone[reason]++
 
 	// Notify
 	w.stateNotifier.AlienDismissed(alien.Name, reason)
@@ -267,50 +619,92 @@ func (w *World) moveAlienTo(ctx context.Context, alien *Alien, oldCity, newCity
 	// Check if Alien can be moved
 	if oldCity != nil && oldCity.AtFight() {
 		// Alien can't escape the fight
+		w.stateNotifier.AlienMoveRefused(alien.Name, oldCity.Name, newCity.Name)
 		return
 	}
 
 	// Update the map
+	w.updateSimClock(time.Now())
+	w.lastMoveAt = w.simElapsed
 	w.alienCityMap[alien.Name] = newCity.Name
 	if oldCity != nil {
 		oldCity.RemoveAlien(alien)
 	}
-	newCityFightStarted := newCity.AddAlien(alien)
+	fightStatus, fightDuration := newCity.AddAlien(alien)
 
 	// Send relocate event to the Alien
 	e := types.NewAlienRelocatedEvent(alien.Name, newCity.City)
 	alien.Relocate(e)
 
 	// Notify
-	w.stateNotifier.AlienRelocated(alien.Name, newCity.Name)
-	if newCityFightStarted {
-		w.stateNotifier.CityFightStarted(newCity.Name)
+	if oldCity != nil {
+		w.stateNotifier.AlienRelocated(alien.Name, newCity.Name)
+	} else {
+		w.stateNotifier.AlienLanded(alien.Name, newCity.Name)
+	}
+
+	switch fightStatus {
+	case FightStatusStarted:
+		w.stateNotifier.CityFightStarted(newCity.Name, newCity.AlienIDs(), fightDuration)
+	case FightStatusProlonged:
+		w.stateNotifier.CityFightProlonged(newCity.Name, newCity.AlienIDs(), fightDuration)
+	}
+	if fightStatus != FightStatusNone {
+		w.notifyFightDeadline(newCity)
+	}
+}
+
+// notifyFightDeadline reports the City ongoing fight deadline (skipped if there is no fight or it is paused).
+func (w *World) notifyFightDeadline(city *City) {
+	if deadline, ok := city.FightDeadline(); ok {
+		w.stateNotifier.CityFightDeadlineUpdated(city.Name, deadline)
 	}
 }
 
 // disembarkAliens drops Aliens to a random City.
 // Not all Aliens can land, since a target City might be already destroyed (it happens).
 func (w *World) disembarkAliens(ctx context.Context, cityIDs []string, aliens []model.Alien) {
-	disembarkMinRate, disembarkMaxRate := viper.GetDuration(config.AppAliensDisembarkMinRate), viper.GetDuration(config.AppAliensDisembarkMaxRate)
+	disembarkMinRate, disembarkMaxRate := w.cfg.AliensDisembarkMinRate, w.cfg.AliensDisembarkMaxRate
 	disembarkDiff := int64(disembarkMaxRate - disembarkMinRate)
+	rnd := rand.New(rand.NewSource(w.seed)) //nolint:gosec
 
 	for _, alien := range aliens {
 		// Delay
 		disembarkDelay := disembarkMinRate
 		if disembarkMaxRate != disembarkMinRate {
-			disembarkDelay += time.Duration(rand.Int63n(disembarkDiff)) //nolint:gosec
+			disembarkDelay += time.Duration(rnd.Int63n(disembarkDiff))
+		}
+		select {
+		case <-time.After(w.speed.Scale(disembarkDelay)):
+		case <-w.doneCh:
+			return
+		}
+		if !w.gate.Wait(ctx) {
+			return
 		}
-		time.Sleep(disembarkDelay)
 
 		// Pick a target location
-		cityID := cityIDs[rand.Intn(len(cityIDs))] //nolint:gosec
+		cityID := cityIDs[rnd.Intn(len(cityIDs))]
 
 		// Disembark request
 		r := types.NewAlienDisembarkRequest(alien, cityID)
-		w.worldRequestsCh <- r
+		select {
+		case w.worldRequestsCh <- r:
+		case <-w.doneCh:
+			return
+		}
 	}
 }
 
+// newAlienRand creates an Alien runner random source derived from the World seed and AlienID.
+// Derivation doesn't depend on the disembark order, so every Alien gets the same source for the same seed.
+func (w *World) newAlienRand(alienID string) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(alienID))
+
+	return rand.New(rand.NewSource(w.seed ^ int64(h.Sum64()))) //nolint:gosec
+}
+
 // log returns logger with object related fields set.
 func (w *World) log(ctx context.Context) *zerolog.Logger {
 	_, logger := logging.GetCtxLogger(ctx)
@@ -0,0 +1,172 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/config"
+	"github.com/itiky/alienInvasion/service/monitor"
+	"github.com/itiky/alienInvasion/service/monitor/noop"
+	"github.com/itiky/alienInvasion/service/sim/types"
+)
+
+var (
+	_ monitor.WorldEventsListener = (*testEventsRecorder)(nil)
+	_ types.StopCondition         = testNoAliensCondition{}
+)
+
+type (
+	// testEventsRecorder records City / Alien lifecycle events.
+	testEventsRecorder struct {
+		*noop.Monitor
+
+		sync.Mutex
+		events []string
+	}
+
+	// testNoAliensCondition stops the simulation once all Aliens are gone.
+	testNoAliensCondition struct{}
+)
+
+func (r *testEventsRecorder) record(format string, args ...interface{}) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+}
+
+func (r *testEventsRecorder) CityFightStarted(cityID string, alienIDs []string, _ time.Duration) {
+	r.record("fightStarted %s %s", cityID, strings.Join(alienIDs, ","))
+}
+
+func (r *testEventsRecorder) CityFightEnded(cityID string, alienIDs []string, _ time.Duration) {
+	r.record("fightEnded %s %s", cityID, strings.Join(alienIDs, ","))
+}
+
+func (r *testEventsRecorder) CityDestroyed(cityID string, _ []string) {
+	r.record("destroyed %s", cityID)
+}
+
+func (r *testEventsRecorder) AlienLanded(alienID, cityID string) {
+	r.record("landed %s %s", alienID, cityID)
+}
+
+func (r *testEventsRecorder) AlienTrapped(alienID, cityID string) {
+	r.record("trapped %s %s", alienID, cityID)
+}
+
+func (r *testEventsRecorder) AlienDismissed(alienID, reason string) {
+	r.record("dismissed %s %s", alienID, reason)
+}
+
+func (c testNoAliensCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	return status.Aliens == 0, "no aliens"
+}
+
+func (c testNoAliensCondition) String() string {
+	return "noAliens"
+}
+
+func TestWorldLifecycleEvents(t *testing.T) {
+	type testCase struct {
+		name            string
+		aliens          []model.Alien
+		expectedEvents  []string // sorted, "trapped" excluded
+		expectedTrapped []string // reported exactly once (others at most once)
+	}
+
+	newAlien := func(name string, maxSteps uint) model.Alien {
+		return model.Alien{Name: name, Power: 1, Speed: 2 * time.Millisecond, MaxSteps: maxSteps}
+	}
+
+	testCases := []testCase{
+		{
+			name:   "Isolated city: landed, trapped once, evacuated",
+			aliens: []model.Alien{newAlien("x", 5)},
+			expectedEvents: []string{
+				"dismissed x evacuated",
+				"landed x A",
+			},
+			expectedTrapped: []string{"x"},
+		},
+		{
+			name:   "Isolated city: fight, destroyed",
+			aliens: []model.Alien{newAlien("x", 1000), newAlien("y", 1000)},
+			expectedEvents: []string{
+				"destroyed A",
+				"dismissed x destroyed",
+				"dismissed y destroyed",
+				"fightEnded A x,y",
+				"fightStarted A x,y",
+				"landed x A",
+				"landed y A",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.DefaultSimConfig()
+			cfg.AliensDisembarkMinRate, cfg.AliensDisembarkMaxRate = time.Millisecond, time.Millisecond
+			cfg.StopCheckRate = 5 * time.Millisecond
+			cfg.City.FightDurK = 50 * time.Millisecond
+
+			recorder := &testEventsRecorder{Monitor: noop.New()}
+			w := NewWorld(model.CityMap{"A": model.City{Name: "A"}}, cfg, 1, testNoAliensCondition{}, recorder)
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer ctxCancel()
+
+			simStopCh := make(chan struct{})
+			go w.Run(ctx, tc.aliens, simStopCh)
+			<-simStopCh
+
+			if reason := w.Result().Status.StopReason; reason != "no aliens" {
+				t.Fatalf("stop reason: got %q, expected %q", reason, "no aliens")
+			}
+
+			// Alien IDs within events are sorted to make them comparable
+			recorder.Lock()
+			defer recorder.Unlock()
+
+			var events []string
+			trapped := make(map[string]int)
+			for _, e := range recorder.events {
+				parts := strings.Split(e, " ")
+				if parts[0] == "trapped" {
+					trapped[parts[1]]++
+					continue
+				}
+				if len(parts) == 3 && strings.Contains(parts[2], ",") {
+					alienIDs := strings.Split(parts[2], ",")
+					sort.Strings(alienIDs)
+					parts[2] = strings.Join(alienIDs, ",")
+				}
+				events = append(events, strings.Join(parts, " "))
+			}
+			sort.Strings(events)
+
+			if !reflect.DeepEqual(events, tc.expectedEvents) {
+				t.Errorf("events:\n  got:      %v\n  expected: %v", events, tc.expectedEvents)
+			}
+
+			for _, alienID := range tc.expectedTrapped {
+				if trapped[alienID] != 1 {
+					t.Errorf("alien %s: trapped reported %d times, expected once", alienID, trapped[alienID])
+				}
+			}
+			for alienID, cnt := range trapped {
+				if cnt > 1 {
+					t.Errorf("alien %s: trapped reported %d times", alienID, cnt)
+				}
+			}
+		})
+	}
+}
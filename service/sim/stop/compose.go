@@ -0,0 +1,94 @@
+package stop
+
+import (
+	"strings"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/service/sim/types"
+)
+
+var (
+	_ types.StopCondition = allCondition{}
+	_ types.StopCondition = anyCondition{}
+)
+
+type (
+	// allCondition triggers when all the nested conditions are triggered (AND).
+	allCondition []types.StopCondition
+
+	// anyCondition triggers when any of the nested conditions is triggered (OR).
+	anyCondition []types.StopCondition
+)
+
+// All creates an AND composition of conditions.
+func All(conditions ...types.StopCondition) types.StopCondition {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+
+	return allCondition(conditions)
+}
+
+// Any creates an OR composition of conditions.
+func Any(conditions ...types.StopCondition) types.StopCondition {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+
+	return anyCondition(conditions)
+}
+
+// Default returns the default simulation stop condition (last alien standing or the entire world is destroyed).
+func Default() types.StopCondition {
+	return Any(LastAlienStanding(), AllCitiesDestroyed())
+}
+
+// ShouldStop implements the types.StopCondition interface.
+func (c allCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	reasons := make([]string, 0, len(c))
+	for _, condition := range c {
+		stop, reason := condition.ShouldStop(status)
+		if !stop {
+			return false, ""
+		}
+		reasons = append(reasons, reason)
+	}
+
+	return true, strings.Join(reasons, " and ")
+}
+
+// String implements the types.StopCondition interface.
+func (c allCondition) String() string {
+	return joinConditions(c, opAnd)
+}
+
+// ShouldStop implements the types.StopCondition interface.
+func (c anyCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	for _, condition := range c {
+		if stop, reason := condition.ShouldStop(status); stop {
+			return true, reason
+		}
+	}
+
+	return false, ""
+}
+
+// String implements the types.StopCondition interface.
+func (c anyCondition) String() string {
+	return joinConditions(c, opOr)
+}
+
+// joinConditions builds a composition definition wrapping nested compositions with brackets.
+func joinConditions(conditions []types.StopCondition, op string) string {
+	strs := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		str := condition.String()
+		switch condition.(type) {
+		case allCondition, anyCondition:
+			str = "(" + str + ")"
+		}
+		strs = append(strs, str)
+	}
+
+	return strings.Join(strs, " "+op+" ")
+}
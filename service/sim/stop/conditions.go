@@ -0,0 +1,210 @@
+package stop
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/service/sim/types"
+)
+
+var (
+	_ types.StopCondition = lastAlienCondition{}
+	_ types.StopCondition = allCitiesDestroyedCondition{}
+	_ types.StopCondition = citiesDestroyedCondition{}
+	_ types.StopCondition = simTimeCondition{}
+	_ types.StopCondition = wallTimeCondition{}
+	_ types.StopCondition = maxEventsCondition{}
+	_ types.StopCondition = idleCondition{}
+)
+
+type (
+	// lastAlienCondition stops the simulation when one (or none) Alien is left.
+	lastAlienCondition struct{}
+
+	// allCitiesDestroyedCondition stops the simulation when no Cities are left.
+	allCitiesDestroyedCondition struct{}
+
+	// citiesDestroyedCondition stops the simulation when the percentage of destroyed Cities has been reached.
+	citiesDestroyedCondition struct {
+		pct float64
+	}
+
+	// simTimeCondition stops the simulation when the simulated time limit has been reached.
+	simTimeCondition struct {
+		limit time.Duration
+	}
+
+	// wallTimeCondition stops the simulation when the wall-clock time limit has been reached.
+	wallTimeCondition struct {
+		limit time.Duration
+	}
+
+	// maxEventsCondition stops the simulation when the number of World events has been reached.
+	maxEventsCondition struct {
+		limit uint64
+	}
+
+	// idleCondition stops the simulation when no Alien has moved for a period of time.
+	idleCondition struct {
+		limit time.Duration
+	}
+)
+
+// LastAlienStanding creates a condition that triggers when one (or none) Alien is left on the map.
+func LastAlienStanding() types.StopCondition {
+	return lastAlienCondition{}
+}
+
+// AllCitiesDestroyed creates a condition that triggers when the entire world is destroyed.
+func AllCitiesDestroyed() types.StopCondition {
+	return allCitiesDestroyedCondition{}
+}
+
+// CitiesDestroyed creates a condition that triggers when {pct} percent of Cities are destroyed.
+func CitiesDestroyed(pct float64) (types.StopCondition, error) {
+	if pct <= 0 || pct > 100 {
+		return nil, fmt.Errorf("percentage: must be in (0, 100] range")
+	}
+
+	return citiesDestroyedCondition{pct: pct}, nil
+}
+
+// SimTimeLimit creates a condition that triggers when the simulated time exceeds the {limit}.
+func SimTimeLimit(limit time.Duration) (types.StopCondition, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("time limit: must be GT 0")
+	}
+
+	return simTimeCondition{limit: limit}, nil
+}
+
+// WallTimeLimit creates a condition that triggers when the wall-clock time exceeds the {limit}.
+func WallTimeLimit(limit time.Duration) (types.StopCondition, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("time limit: must be GT 0")
+	}
+
+	return wallTimeCondition{limit: limit}, nil
+}
+
+// MaxEvents creates a condition that triggers when the number of World events reaches the {limit}.
+func MaxEvents(limit uint64) (types.StopCondition, error) {
+	if limit == 0 {
+		return nil, fmt.Errorf("events limit: must be GT 0")
+	}
+
+	return maxEventsCondition{limit: limit}, nil
+}
+
+// NoMovesFor creates a condition that triggers when no Alien has moved for the {limit} period.
+func NoMovesFor(limit time.Duration) (types.StopCondition, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("idle period: must be GT 0")
+	}
+
+	return idleCondition{limit: limit}, nil
+}
+
+// ShouldStop implements the types.StopCondition interface.
+func (c lastAlienCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	if status.Aliens > 1 {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("aliens left: %d", status.Aliens)
+}
+
+// String implements the types.StopCondition interface.
+func (c lastAlienCondition) String() string {
+	return lastAlienName
+}
+
+// ShouldStop implements the types.StopCondition interface.
+func (c allCitiesDestroyedCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	if status.Cities > 0 {
+		return false, ""
+	}
+
+	return true, "all cities destroyed"
+}
+
+// String implements the types.StopCondition interface.
+func (c allCitiesDestroyedCondition) String() string {
+	return allCitiesDestroyedName
+}
+
+// ShouldStop implements the types.StopCondition interface.
+func (c citiesDestroyedCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	if status.CitiesInitial == 0 {
+		return false, ""
+	}
+
+	destroyedPct := float64(status.CitiesDestroyed()) * 100.0 / float64(status.CitiesInitial)
+	if destroyedPct < c.pct {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("cities destroyed: %.1f%% (%d / %d)", destroyedPct, status.CitiesDestroyed(), status.CitiesInitial)
+}
+
+// String implements the types.StopCondition interface.
+func (c citiesDestroyedCondition) String() string {
+	return fmt.Sprintf("%s(%g%%)", citiesDestroyedName, c.pct)
+}
+
+// ShouldStop implements the types.StopCondition interface.
+func (c simTimeCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	if status.SimElapsed < c.limit {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("simulated time limit reached (%v)", c.limit)
+}
+
+// String implements the types.StopCondition interface.
+func (c simTimeCondition) String() string {
+	return fmt.Sprintf("%s(%v)", simTimeName, c.limit)
+}
+
+// ShouldStop implements the types.StopCondition interface.
+func (c wallTimeCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	if status.WallElapsed < c.limit {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("wall-clock time limit reached (%v)", c.limit)
+}
+
+// String implements the types.StopCondition interface.
+func (c wallTimeCondition) String() string {
+	return fmt.Sprintf("%s(%v)", wallTimeName, c.limit)
+}
+
+// ShouldStop implements the types.StopCondition interface.
+func (c maxEventsCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	if status.Events < c.limit {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("events limit reached (%d)", c.limit)
+}
+
+// String implements the types.StopCondition interface.
+func (c maxEventsCondition) String() string {
+	return fmt.Sprintf("%s(%d)", maxEventsName, c.limit)
+}
+
+// ShouldStop implements the types.StopCondition interface.
+func (c idleCondition) ShouldStop(status model.SimStatus) (bool, string) {
+	if status.SinceLastMove < c.limit {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("no alien moved for %v", c.limit)
+}
+
+// String implements the types.StopCondition interface.
+func (c idleCondition) String() string {
+	return fmt.Sprintf("%s(%v)", idleName, c.limit)
+}
@@ -0,0 +1,266 @@
+package stop
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/itiky/alienInvasion/service/sim/types"
+)
+
+// Condition names and composition operators.
+const (
+	lastAlienName          = "lastAlien"
+	allCitiesDestroyedName = "allCitiesDestroyed"
+	citiesDestroyedName    = "citiesDestroyed"
+	simTimeName            = "simTime"
+	wallTimeName           = "wallTime"
+	maxEventsName          = "maxEvents"
+	idleName               = "idle"
+
+	opAnd = "and"
+	opOr  = "or"
+)
+
+// Parse builds a StopCondition from a text definition.
+// Format (AND has a higher priority than OR, brackets are supported):
+//   {condition} [(and/or) {condition}]
+// Conditions:
+//   lastAlien              - one (or none) Alien is left;
+//   allCitiesDestroyed     - no Cities left;
+//   citiesDestroyed(50%)   - percentage of Cities destroyed;
+//   simTime(5m)            - simulated time limit;
+//   wallTime(5m)           - wall-clock time limit;
+//   maxEvents(1000)        - World events limit;
+//   idle(10s)              - no Alien moved for a period;
+// Example:
+//   lastAlien or (citiesDestroyed(50%) and simTime(1m)) or wallTime(10m)
+func Parse(definition string) (types.StopCondition, error) {
+	tokens, err := tokenize(definition)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty definition")
+	}
+
+	p := parser{tokens: tokens}
+	condition, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected token %q", p.peek())
+	}
+
+	return condition, nil
+}
+
+// parser is a recursive descent parser for the Parse definition grammar.
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+// parseOr parses OR composition: {and} [or {and}].
+func (p *parser) parseOr() (types.StopCondition, error) {
+	var conditions []types.StopCondition
+	for {
+		condition, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+
+		if !p.accept(opOr, "||") {
+			break
+		}
+	}
+
+	return Any(conditions...), nil
+}
+
+// parseAnd parses AND composition: {term} [and {term}].
+func (p *parser) parseAnd() (types.StopCondition, error) {
+	var conditions []types.StopCondition
+	for {
+		condition, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+
+		if !p.accept(opAnd, "&&") {
+			break
+		}
+	}
+
+	return All(conditions...), nil
+}
+
+// parseTerm parses a single condition or a bracketed composition.
+func (p *parser) parseTerm() (types.StopCondition, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of definition")
+	}
+
+	if p.accept("(") {
+		condition, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("closing bracket expected")
+		}
+
+		return condition, nil
+	}
+
+	name := p.next()
+	var arg string
+	if p.accept("(") {
+		if p.done() {
+			return nil, fmt.Errorf("%s: argument expected", name)
+		}
+		arg = p.next()
+		if !p.accept(")") {
+			return nil, fmt.Errorf("%s: closing bracket expected", name)
+		}
+	}
+
+	condition, err := newCondition(name, arg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return condition, nil
+}
+
+// accept moves to the next token if the current one matches any of {values} (case-insensitive).
+func (p *parser) accept(values ...string) bool {
+	if p.done() {
+		return false
+	}
+
+	for _, v := range values {
+		if strings.EqualFold(p.peek(), v) {
+			p.pos++
+			return true
+		}
+	}
+
+	return false
+}
+
+// peek returns the current token.
+func (p *parser) peek() string {
+	return p.tokens[p.pos]
+}
+
+// next returns the current token and moves to the next one.
+func (p *parser) next() string {
+	token := p.tokens[p.pos]
+	p.pos++
+
+	return token
+}
+
+// done checks if all tokens are consumed.
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// newCondition creates a single condition by its name and an optional argument.
+func newCondition(name, arg string) (types.StopCondition, error) {
+	noArgs := func(c types.StopCondition) (types.StopCondition, error) {
+		if arg != "" {
+			return nil, fmt.Errorf("no argument expected")
+		}
+		return c, nil
+	}
+
+	parseDuration := func() (time.Duration, error) {
+		d, err := time.ParseDuration(arg)
+		if err != nil {
+			return 0, fmt.Errorf("parsing duration argument: %w", err)
+		}
+		return d, nil
+	}
+
+	switch {
+	case strings.EqualFold(name, lastAlienName):
+		return noArgs(LastAlienStanding())
+	case strings.EqualFold(name, allCitiesDestroyedName):
+		return noArgs(AllCitiesDestroyed())
+	case strings.EqualFold(name, citiesDestroyedName):
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(arg, "%"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing percentage argument: %w", err)
+		}
+		return CitiesDestroyed(pct)
+	case strings.EqualFold(name, simTimeName):
+		d, err := parseDuration()
+		if err != nil {
+			return nil, err
+		}
+		return SimTimeLimit(d)
+	case strings.EqualFold(name, wallTimeName):
+		d, err := parseDuration()
+		if err != nil {
+			return nil, err
+		}
+		return WallTimeLimit(d)
+	case strings.EqualFold(name, maxEventsName):
+		n, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing events argument: %w", err)
+		}
+		return MaxEvents(n)
+	case strings.EqualFold(name, idleName):
+		d, err := parseDuration()
+		if err != nil {
+			return nil, err
+		}
+		return NoMovesFor(d)
+	}
+
+	return nil, fmt.Errorf("unknown condition")
+}
+
+// tokenize splits a definition to brackets, operators and words.
+func tokenize(definition string) ([]string, error) {
+	var tokens []string
+	word := strings.Builder{}
+	flushWord := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+
+	runes := []rune(definition)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			flushWord()
+		case r == '(' || r == ')':
+			flushWord()
+			tokens = append(tokens, string(r))
+		case r == '&' || r == '|':
+			flushWord()
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, fmt.Errorf("invalid operator at position %d (%c%c is expected)", i, r, r)
+			}
+			tokens = append(tokens, string([]rune{r, r}))
+			i++
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flushWord()
+
+	return tokens, nil
+}
@@ -0,0 +1,215 @@
+package stop
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itiky/alienInvasion/model"
+)
+
+func TestParse(t *testing.T) {
+	type testCase struct {
+		name        string
+		definition  string
+		expected    string // normalized definition (condition String())
+		errExpected bool
+	}
+
+	testCases := []testCase{
+		{
+			name:       "OK: single condition",
+			definition: "lastAlien",
+			expected:   "lastAlien",
+		},
+		{
+			name:       "OK: case-insensitive names and operators",
+			definition: "LASTALIEN OR AllCitiesDestroyed",
+			expected:   "lastAlien or allCitiesDestroyed",
+		},
+		{
+			name:       "OK: conditions with arguments",
+			definition: "citiesDestroyed(50%) and simTime(1m) and wallTime(30s) and maxEvents(100) and idle(10s)",
+			expected:   "citiesDestroyed(50%) and simTime(1m0s) and wallTime(30s) and maxEvents(100) and idle(10s)",
+		},
+		{
+			name:       "OK: percentage without the sign",
+			definition: "citiesDestroyed(25.5)",
+			expected:   "citiesDestroyed(25.5%)",
+		},
+		{
+			name:       "OK: AND has a higher priority than OR",
+			definition: "lastAlien or citiesDestroyed(50%) and simTime(1m)",
+			expected:   "lastAlien or (citiesDestroyed(50%) and simTime(1m0s))",
+		},
+		{
+			name:       "OK: brackets",
+			definition: "(lastAlien or allCitiesDestroyed) and wallTime(10m)",
+			expected:   "(lastAlien or allCitiesDestroyed) and wallTime(10m0s)",
+		},
+		{
+			name:       "OK: symbolic operators",
+			definition: "lastAlien || allCitiesDestroyed && maxEvents(10)",
+			expected:   "lastAlien or (allCitiesDestroyed and maxEvents(10))",
+		},
+		{
+			name:       "OK: redundant brackets and spaces",
+			definition: "  ((lastAlien))  ",
+			expected:   "lastAlien",
+		},
+		{
+			name:        "Fail: empty",
+			definition:  "   ",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: unknown condition",
+			definition:  "lastCity",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: unexpected argument",
+			definition:  "lastAlien(1)",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: missing argument",
+			definition:  "simTime",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: invalid duration",
+			definition:  "simTime(1parsec)",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: percentage out of range",
+			definition:  "citiesDestroyed(150%)",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: zero events limit",
+			definition:  "maxEvents(0)",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: dangling operator",
+			definition:  "lastAlien or",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: unclosed bracket",
+			definition:  "(lastAlien or allCitiesDestroyed",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: unexpected closing bracket",
+			definition:  "lastAlien)",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: missing operator",
+			definition:  "lastAlien allCitiesDestroyed",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: single char operator",
+			definition:  "lastAlien | allCitiesDestroyed",
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition, err := Parse(tc.definition)
+			if tc.errExpected {
+				if err == nil {
+					t.Fatalf("error expected, got condition: %s", condition)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := condition.String(); got != tc.expected {
+				t.Errorf("condition: got %q, expected %q", got, tc.expected)
+			}
+
+			// Normalized definition must be parsed to the same condition
+			reparsed, err := Parse(condition.String())
+			if err != nil {
+				t.Fatalf("parsing normalized definition: %v", err)
+			}
+			if got := reparsed.String(); got != tc.expected {
+				t.Errorf("reparsed condition: got %q, expected %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseShouldStop(t *testing.T) {
+	type testCase struct {
+		name       string
+		definition string
+		status     model.SimStatus
+		expected   bool
+	}
+
+	const definition = "lastAlien or (citiesDestroyed(50%) and simTime(1m))"
+
+	testCases := []testCase{
+		{
+			name:       "Stop: last alien",
+			definition: definition,
+			status:     model.SimStatus{Aliens: 1, Cities: 10, CitiesInitial: 10},
+			expected:   true,
+		},
+		{
+			name:       "Stop: both AND conditions triggered",
+			definition: definition,
+			status:     model.SimStatus{Aliens: 5, Cities: 5, CitiesInitial: 10, SimElapsed: 2 * time.Minute},
+			expected:   true,
+		},
+		{
+			name:       "Continue: only cities destroyed",
+			definition: definition,
+			status:     model.SimStatus{Aliens: 5, Cities: 5, CitiesInitial: 10, SimElapsed: 30 * time.Second},
+			expected:   false,
+		},
+		{
+			name:       "Continue: only sim time",
+			definition: definition,
+			status:     model.SimStatus{Aliens: 5, Cities: 6, CitiesInitial: 10, SimElapsed: 2 * time.Minute},
+			expected:   false,
+		},
+		{
+			name:       "Stop: idle",
+			definition: "idle(10s) and maxEvents(5)",
+			status:     model.SimStatus{Events: 5, SinceLastMove: 10 * time.Second},
+			expected:   true,
+		},
+		{
+			name:       "Continue: wall time not reached",
+			definition: "wallTime(1m)",
+			status:     model.SimStatus{WallElapsed: 59 * time.Second},
+			expected:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition, err := Parse(tc.definition)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			stop, reason := condition.ShouldStop(tc.status)
+			if stop != tc.expected {
+				t.Fatalf("stop: got %v (%s), expected %v", stop, reason, tc.expected)
+			}
+			if stop && reason == "" {
+				t.Errorf("stop reason: empty")
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package types
+
+import "time"
+
+// External to World simulation lifecycle control requests.
+type (
+	// ControlRequest defines a common request interface.
+	ControlRequest interface {
+		ControlName() string
+	}
+
+	// SimPauseRequest defines a request to freeze the simulation (Aliens, fights and disembark).
+	SimPauseRequest struct{}
+
+	// SimResumeRequest defines a request to unfreeze a paused simulation.
+	SimResumeRequest struct{}
+
+	// SimStopRequest defines a request to stop the simulation with a reason comment.
+	SimStopRequest struct {
+		Reason string
+	}
+
+	// SimSpeedRequest defines a request to change the simulation speed multiplier (1.0 is the real time).
+	SimSpeedRequest struct {
+		Speed float64
+	}
+
+	// SimStepRequest defines a request to run a paused simulation for a simulated time duration and pause it again.
+	SimStepRequest struct {
+		Duration time.Duration
+	}
+)
+
+// ControlName implements the ControlRequest interface.
+func (r SimPauseRequest) ControlName() string {
+	return "pause"
+}
+
+// ControlName implements the ControlRequest interface.
+func (r SimResumeRequest) ControlName() string {
+	return "resume"
+}
+
+// ControlName implements the ControlRequest interface.
+func (r SimStopRequest) ControlName() string {
+	return "stop"
+}
+
+// ControlName implements the ControlRequest interface.
+func (r SimSpeedRequest) ControlName() string {
+	return "speed"
+}
+
+// ControlName implements the ControlRequest interface.
+func (r SimStepRequest) ControlName() string {
+	return "step"
+}
+
+// NewSimPauseRequest creates a new SimPauseRequest object.
+func NewSimPauseRequest() SimPauseRequest {
+	return SimPauseRequest{}
+}
+
+// NewSimResumeRequest creates a new SimResumeRequest object.
+func NewSimResumeRequest() SimResumeRequest {
+	return SimResumeRequest{}
+}
+
+// NewSimStopRequest creates a new SimStopRequest object.
+func NewSimStopRequest(reason string) SimStopRequest {
+	return SimStopRequest{
+		Reason: reason,
+	}
+}
+
+// NewSimSpeedRequest creates a new SimSpeedRequest object.
+func NewSimSpeedRequest(speed float64) SimSpeedRequest {
+	return SimSpeedRequest{
+		Speed: speed,
+	}
+}
+
+// NewSimStepRequest creates a new SimStepRequest object.
+func NewSimStepRequest(duration time.Duration) SimStepRequest {
+	return SimStepRequest{
+		Duration: duration,
+	}
+}
@@ -10,9 +10,19 @@ type (
 	}
 
 	// AlienRelocatedEvent defines a confirmed Alien move from old to new City.
+	// Seq orders the event with AlienLocationUpdatedEvent ones (set by the Alien on send).
 	AlienRelocatedEvent struct {
 		AlienID     string
 		NewLocation model.City
+		Seq         uint64
+	}
+
+	// AlienLocationUpdatedEvent defines an Alien's current City data change (road connections have changed).
+	// Seq orders the event with AlienRelocatedEvent ones (set by the Alien on send).
+	AlienLocationUpdatedEvent struct {
+		AlienID  string
+		Location model.City
+		Seq      uint64
 	}
 
 	// AlienDismissedEvent defines a confirmed Alien dismiss event with a reason comment.
@@ -27,6 +37,11 @@ func (e AlienRelocatedEvent) TargetID() string {
 	return e.AlienID
 }
 
+// TargetID implements the AlienEvent interface.
+func (e AlienLocationUpdatedEvent) TargetID() string {
+	return e.AlienID
+}
+
 // TargetID implements the AlienEvent interface.
 func (e AlienDismissedEvent) TargetID() string {
 	return e.AlienID
@@ -40,6 +55,14 @@ func NewAlienRelocatedEvent(alienID string, newLocation model.City) AlienRelocat
 	}
 }
 
+// NewAlienLocationUpdatedEvent creates a new AlienLocationUpdatedEvent object.
+func NewAlienLocationUpdatedEvent(alienID string, location model.City) AlienLocationUpdatedEvent {
+	return AlienLocationUpdatedEvent{
+		AlienID:  alienID,
+		Location: location,
+	}
+}
+
 // NewAlienDismissedEvent creates a new AlienDismissedEvent object.
 func NewAlienDismissedEvent(alienID, reason string) AlienDismissedEvent {
 	return AlienDismissedEvent{
@@ -19,6 +19,11 @@ type (
 	AlienEvacuateRequest struct {
 		AlienID string
 	}
+
+	// AlienTrappedRequest defines Alien's report that there are no roads left to move by.
+	AlienTrappedRequest struct {
+		AlienID string
+	}
 )
 
 // SourceID implements the AlienRequest interface.
@@ -31,6 +36,11 @@ func (r AlienEvacuateRequest) SourceID() string {
 	return r.AlienID
 }
 
+// SourceID implements the AlienRequest interface.
+func (r AlienTrappedRequest) SourceID() string {
+	return r.AlienID
+}
+
 // NewAlienMoveRequest creates a new AlienMoveRequest object.
 func NewAlienMoveRequest(alienID, newCityID string) AlienMoveRequest {
 	return AlienMoveRequest{
@@ -46,6 +56,13 @@ func NewAlienEvacuateRequest(alienID string) AlienEvacuateRequest {
 	}
 }
 
+// NewAlienTrappedRequest creates a new AlienTrappedRequest object.
+func NewAlienTrappedRequest(alienID string) AlienTrappedRequest {
+	return AlienTrappedRequest{
+		AlienID: alienID,
+	}
+}
+
 // World to World requests.
 type (
 	// WorldRequest defines a common request interface.
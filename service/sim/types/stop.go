@@ -0,0 +1,12 @@
+package types
+
+import "github.com/itiky/alienInvasion/model"
+
+// StopCondition defines a simulation stop criteria.
+type StopCondition interface {
+	// ShouldStop checks the current simulation status and returns a reason if simulation should be stopped.
+	ShouldStop(status model.SimStatus) (bool, string)
+
+	// String returns the condition definition (the one Parse accepts).
+	String() string
+}
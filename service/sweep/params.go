@@ -0,0 +1,238 @@
+package sweep
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/itiky/alienInvasion/pkg/config"
+)
+
+// ParamAliens is the special sweep param key that defines the number of Aliens (not a config key).
+const ParamAliens = "aliens"
+
+type (
+	// Param defines a single swept key with its values.
+	Param struct {
+		Key    string
+		Values []string
+	}
+
+	// Point is a single parameters space point (values are ordered the same as params).
+	Point []string
+)
+
+// ParseParam parses a sweep param definition.
+// Supported formats:
+//   * list: "alien.maxSteps=10,25,50";
+//   * range (inclusive): "alien.maxSteps=10:50:10", "city.fightDurationCoef=100ms:300ms:50ms", "k=0.5:1.5:0.25";
+func ParseParam(def string) (Param, error) {
+	keyValues := strings.SplitN(def, "=", 2)
+	if len(keyValues) != 2 {
+		return Param{}, fmt.Errorf("param (%s): key=values format expected", def)
+	}
+
+	key, valuesDef := strings.TrimSpace(keyValues[0]), strings.TrimSpace(keyValues[1])
+	if err := validateKey(key); err != nil {
+		return Param{}, fmt.Errorf("param (%s): %w", def, err)
+	}
+	if valuesDef == "" {
+		return Param{}, fmt.Errorf("param (%s): values are empty", def)
+	}
+
+	var values []string
+	if rangeParts := strings.Split(valuesDef, ":"); len(rangeParts) > 1 {
+		if len(rangeParts) != 3 {
+			return Param{}, fmt.Errorf("param (%s): from:to:step range format expected", def)
+		}
+
+		rangeValues, err := parseRange(rangeParts[0], rangeParts[1], rangeParts[2])
+		if err != nil {
+			return Param{}, fmt.Errorf("param (%s): parsing range: %w", def, err)
+		}
+		values = rangeValues
+	} else {
+		for _, value := range strings.Split(valuesDef, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				return Param{}, fmt.Errorf("param (%s): empty list value", def)
+			}
+			values = append(values, value)
+		}
+	}
+
+	return Param{Key: key, Values: values}, nil
+}
+
+// Grid returns the cartesian product of all params values.
+func Grid(params []Param) []Point {
+	points := []Point{{}}
+	for _, param := range params {
+		nextPoints := make([]Point, 0, len(points)*len(param.Values))
+		for _, point := range points {
+			for _, value := range param.Values {
+				nextPoint := make(Point, len(point), len(point)+1)
+				copy(nextPoint, point)
+				nextPoints = append(nextPoints, append(nextPoint, value))
+			}
+		}
+		points = nextPoints
+	}
+
+	return points
+}
+
+// Sample returns {n} random points (grid order is kept).
+// All points are returned if {n} is GTE to the number of points.
+func Sample(rnd *rand.Rand, points []Point, n int) []Point {
+	if n >= len(points) {
+		return points
+	}
+
+	idxs := rnd.Perm(len(points))[:n]
+	picked := make(map[int]struct{}, n)
+	for _, idx := range idxs {
+		picked[idx] = struct{}{}
+	}
+
+	sampled := make([]Point, 0, n)
+	for idx, point := range points {
+		if _, ok := picked[idx]; ok {
+			sampled = append(sampled, point)
+		}
+	}
+
+	return sampled
+}
+
+// validateKey checks if the key is supported for sweeping.
+func validateKey(key string) error {
+	if key == ParamAliens {
+		return nil
+	}
+
+	for _, simKey := range config.SimKeys() {
+		if key == simKey {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("key (%s): unknown simulation config key", key)
+}
+
+// parseRange builds a values list for an inclusive range.
+// Integer, float and duration ranges are supported.
+func parseRange(fromStr, toStr, stepStr string) ([]string, error) {
+	fromStr, toStr, stepStr = strings.TrimSpace(fromStr), strings.TrimSpace(toStr), strings.TrimSpace(stepStr)
+
+	// Integers
+	if from, to, step, err := parseInts(fromStr, toStr, stepStr); err == nil {
+		if err := validateRange(float64(from), float64(to), float64(step)); err != nil {
+			return nil, err
+		}
+
+		var values []string
+		for v := from; v <= to; v += step {
+			values = append(values, strconv.FormatInt(v, 10))
+		}
+
+		return values, nil
+	}
+
+	// Durations
+	if from, to, step, err := parseDurations(fromStr, toStr, stepStr); err == nil {
+		if err := validateRange(float64(from), float64(to), float64(step)); err != nil {
+			return nil, err
+		}
+
+		var values []string
+		for v := from; v <= to; v += step {
+			values = append(values, v.String())
+		}
+
+		return values, nil
+	}
+
+	// Floats
+	from, to, step, err := parseFloats(fromStr, toStr, stepStr)
+	if err != nil {
+		return nil, fmt.Errorf("int, float or duration values expected")
+	}
+	if err := validateRange(from, to, step); err != nil {
+		return nil, err
+	}
+
+	// Steps are counted to avoid the floating point accumulation error
+	precision := decimals(fromStr)
+	if stepPrecision := decimals(stepStr); stepPrecision > precision {
+		precision = stepPrecision
+	}
+
+	stepsCnt := int(math.Floor((to-from)/step+1e-9)) + 1
+	values := make([]string, 0, stepsCnt)
+	for i := 0; i < stepsCnt; i++ {
+		values = append(values, strconv.FormatFloat(from+float64(i)*step, 'f', precision, 64))
+	}
+
+	return values, nil
+}
+
+// validateRange checks range bounds and step.
+func validateRange(from, to, step float64) error {
+	if step <= 0 {
+		return fmt.Errorf("step: must be GT 0")
+	}
+	if from > to {
+		return fmt.Errorf("from: must be LTE to")
+	}
+
+	return nil
+}
+
+func parseInts(fromStr, toStr, stepStr string) (from, to, step int64, retErr error) {
+	if from, retErr = strconv.ParseInt(fromStr, 10, 64); retErr != nil {
+		return
+	}
+	if to, retErr = strconv.ParseInt(toStr, 10, 64); retErr != nil {
+		return
+	}
+	step, retErr = strconv.ParseInt(stepStr, 10, 64)
+
+	return
+}
+
+func parseDurations(fromStr, toStr, stepStr string) (from, to, step time.Duration, retErr error) {
+	if from, retErr = time.ParseDuration(fromStr); retErr != nil {
+		return
+	}
+	if to, retErr = time.ParseDuration(toStr); retErr != nil {
+		return
+	}
+	step, retErr = time.ParseDuration(stepStr)
+
+	return
+}
+
+func parseFloats(fromStr, toStr, stepStr string) (from, to, step float64, retErr error) {
+	if from, retErr = strconv.ParseFloat(fromStr, 64); retErr != nil {
+		return
+	}
+	if to, retErr = strconv.ParseFloat(toStr, 64); retErr != nil {
+		return
+	}
+	step, retErr = strconv.ParseFloat(stepStr, 64)
+
+	return
+}
+
+// decimals returns the number of decimal digits of a float string.
+func decimals(v string) int {
+	if idx := strings.IndexByte(v, '.'); idx >= 0 {
+		return len(v) - idx - 1
+	}
+
+	return 0
+}
@@ -0,0 +1,228 @@
+package sweep
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestParseParam(t *testing.T) {
+	type testCase struct {
+		name        string
+		def         string
+		expected    Param
+		errExpected bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "OK: list",
+			def:      "alien.maxSteps=10,25,50",
+			expected: Param{Key: "alien.maxSteps", Values: []string{"10", "25", "50"}},
+		},
+		{
+			name:     "OK: list with spaces",
+			def:      " alien.maxSteps = 10 , 25 ",
+			expected: Param{Key: "alien.maxSteps", Values: []string{"10", "25"}},
+		},
+		{
+			name:     "OK: single value",
+			def:      "aliens=100",
+			expected: Param{Key: ParamAliens, Values: []string{"100"}},
+		},
+		{
+			name:     "OK: int range",
+			def:      "alien.maxSteps=10:50:10",
+			expected: Param{Key: "alien.maxSteps", Values: []string{"10", "20", "30", "40", "50"}},
+		},
+		{
+			name:     "OK: int range with the upper bound not on a step",
+			def:      "aliens=1:10:4",
+			expected: Param{Key: ParamAliens, Values: []string{"1", "5", "9"}},
+		},
+		{
+			name:     "OK: single point range",
+			def:      "aliens=5:5:1",
+			expected: Param{Key: ParamAliens, Values: []string{"5"}},
+		},
+		{
+			name:     "OK: duration range",
+			def:      "city.fightDurationCoef=100ms:300ms:50ms",
+			expected: Param{Key: "city.fightDurationCoef", Values: []string{"100ms", "150ms", "200ms", "250ms", "300ms"}},
+		},
+		{
+			name:     "OK: float range",
+			def:      "alien.maxPower=0.5:1.5:0.25",
+			expected: Param{Key: "alien.maxPower", Values: []string{"0.50", "0.75", "1.00", "1.25", "1.50"}},
+		},
+		{
+			name:     "OK: float range without accumulation error",
+			def:      "alien.maxPower=0:1:0.1",
+			expected: Param{Key: "alien.maxPower", Values: []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0"}},
+		},
+		{
+			name:        "Fail: no values separator",
+			def:         "alien.maxSteps",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: unknown key",
+			def:         "alien.maxLegs=4",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: non-sim config key",
+			def:         "app.logLevel=debug",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: empty values",
+			def:         "aliens= ",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: empty list value",
+			def:         "aliens=1,,3",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: incomplete range",
+			def:         "aliens=1:10",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: zero step",
+			def:         "aliens=1:10:0",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: negative step",
+			def:         "city.fightDurationCoef=1s:2s:-1s",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: reversed bounds",
+			def:         "aliens=10:1:1",
+			errExpected: true,
+		},
+		{
+			name:        "Fail: non-numeric range",
+			def:         "aliens=a:b:c",
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			param, err := ParseParam(tc.def)
+			if tc.errExpected {
+				if err == nil {
+					t.Fatalf("error expected, got: %+v", param)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(param, tc.expected) {
+				t.Errorf("param: got %+v, expected %+v", param, tc.expected)
+			}
+		})
+	}
+}
+
+func TestGrid(t *testing.T) {
+	type testCase struct {
+		name     string
+		params   []Param
+		expected []Point
+	}
+
+	testCases := []testCase{
+		{
+			name:     "No params: single empty point",
+			expected: []Point{{}},
+		},
+		{
+			name: "Single param",
+			params: []Param{
+				{Key: ParamAliens, Values: []string{"1", "2"}},
+			},
+			expected: []Point{{"1"}, {"2"}},
+		},
+		{
+			name: "Cartesian product keeps params order",
+			params: []Param{
+				{Key: ParamAliens, Values: []string{"1", "2"}},
+				{Key: "alien.maxSteps", Values: []string{"a", "b", "c"}},
+			},
+			expected: []Point{
+				{"1", "a"}, {"1", "b"}, {"1", "c"},
+				{"2", "a"}, {"2", "b"}, {"2", "c"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			points := Grid(tc.params)
+			if !reflect.DeepEqual(points, tc.expected) {
+				t.Errorf("points: got %v, expected %v", points, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSample(t *testing.T) {
+	type testCase struct {
+		name        string
+		pointsCnt   int
+		n           int
+		expectedCnt int
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Subset",
+			pointsCnt:   10,
+			n:           4,
+			expectedCnt: 4,
+		},
+		{
+			name:        "All points: n equals points",
+			pointsCnt:   5,
+			n:           5,
+			expectedCnt: 5,
+		},
+		{
+			name:        "All points: n exceeds points",
+			pointsCnt:   3,
+			n:           10,
+			expectedCnt: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values := make([]string, 0, tc.pointsCnt)
+			for i := 0; i < tc.pointsCnt; i++ {
+				values = append(values, string(rune('a'+i)))
+			}
+			points := Grid([]Param{{Key: ParamAliens, Values: values}})
+
+			sampled := Sample(rand.New(rand.NewSource(1)), points, tc.n)
+			if len(sampled) != tc.expectedCnt {
+				t.Fatalf("sampled: got %d points, expected %d", len(sampled), tc.expectedCnt)
+			}
+
+			// Grid order must be kept without duplicates
+			for i := 1; i < len(sampled); i++ {
+				if sampled[i-1][0] >= sampled[i][0] {
+					t.Errorf("sampled: grid order broken (%v)", sampled)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package sweep
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// CSVWriter writes run results as CSV rows (one row per run).
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter creates a new CSVWriter instance and writes the header.
+func NewCSVWriter(w io.Writer, params []Param) (*CSVWriter, error) {
+	header := []string{"point"}
+	for _, param := range params {
+		header = append(header, param.Key)
+	}
+	header = append(header,
+		"seed", "stopReason", "simTime",
+		"aliensTotal", "aliensNotLanded", "aliensEvacuated", "aliensDestroyed", "aliensSurvived",
+		"citiesDestroyed", "citiesLeft",
+	)
+
+	csvW := csv.NewWriter(w)
+	if err := csvW.Write(header); err != nil {
+		return nil, fmt.Errorf("writing header: %w", err)
+	}
+
+	return &CSVWriter{w: csvW}, nil
+}
+
+// Write writes a run result row and flushes it (results are streamed, so an interrupted sweep keeps finished runs).
+func (w *CSVWriter) Write(result Result) error {
+	r := result.SimResult
+
+	row := []string{strconv.Itoa(result.PointIdx)}
+	row = append(row, result.Point...)
+	row = append(row,
+		strconv.FormatInt(result.Seed, 10),
+		r.Status.StopReason,
+		r.Status.SimElapsed.String(),
+		strconv.Itoa(r.AliensTotal),
+		strconv.Itoa(r.AliensNotLanded),
+		strconv.Itoa(r.AliensEvacuated),
+		strconv.Itoa(r.AliensDestroyed),
+		strconv.Itoa(r.AliensSurvived),
+		strconv.Itoa(len(r.CitiesDestroyed)),
+		strconv.Itoa(len(r.CitiesLeft)),
+	)
+
+	if err := w.w.Write(row); err != nil {
+		return fmt.Errorf("writing row: %w", err)
+	}
+	w.w.Flush()
+
+	return w.w.Error()
+}
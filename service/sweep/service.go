@@ -0,0 +1,294 @@
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+
+	"github.com/itiky/alienInvasion/model"
+	"github.com/itiky/alienInvasion/pkg/config"
+	"github.com/itiky/alienInvasion/pkg/logging"
+	"github.com/itiky/alienInvasion/service/batch"
+	"github.com/rs/zerolog"
+)
+
+const serviceName = "SweepRunner"
+
+type (
+	// Runner runs headless simulations for every point of a parameters space with a number of seeds per point.
+	Runner struct {
+		// Params
+		cfg           config.SimConfig // base config (overridden by point values)
+		cityMap       model.CityMap
+		aliensCount   uint    // base number of Aliens (overridden by the ParamAliens param)
+		params        []Param // swept params
+		sample        uint    // number of randomly sampled points (0 - the whole grid)
+		seedsPerPoint uint    // number of runs (seeds) per point
+		parallel      uint    // number of runs executed in parallel
+		baseSeed      int64   // seed of the first run of a point (incremented for the following ones)
+	}
+
+	// Result keeps a single run result.
+	Result struct {
+		PointIdx    int
+		Point       Point
+		Seed        int64
+		AliensCount uint
+		SimResult   model.SimResult
+	}
+
+	// Option defines the New constructor options.
+	Option func(r *Runner) error
+
+	// pointJob defines a single point inputs.
+	pointJob struct {
+		idx         int
+		point       Point
+		cfg         config.SimConfig
+		aliensCount uint
+	}
+
+	// runJob defines a single run inputs.
+	runJob struct {
+		point *pointJob
+		seed  int64
+	}
+)
+
+// WithConfig is the Runner constructor option that overrides the default base SimConfig param.
+func WithConfig(cfg config.SimConfig) Option {
+	return func(r *Runner) error {
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("validating config: %w", err)
+		}
+		r.cfg = cfg
+
+		return nil
+	}
+}
+
+// WithCityMap is the Runner constructor option that sets the CityMap param.
+func WithCityMap(cm model.CityMap) Option {
+	return func(r *Runner) error {
+		if err := cm.Validate(); err != nil {
+			return fmt.Errorf("validating city map: %w", err)
+		}
+		r.cityMap = cm
+
+		return nil
+	}
+}
+
+// WithAliensCount is the Runner constructor option that sets the base number of Aliens.
+func WithAliensCount(n uint) Option {
+	return func(r *Runner) error {
+		if n == 0 {
+			return fmt.Errorf("aliens count: must be GT 0")
+		}
+		r.aliensCount = n
+
+		return nil
+	}
+}
+
+// WithParams is the Runner constructor option that sets swept params.
+func WithParams(params ...Param) Option {
+	return func(r *Runner) error {
+		keys := make(map[string]struct{}, len(params))
+		for _, param := range params {
+			if _, ok := keys[param.Key]; ok {
+				return fmt.Errorf("param (%s): duplicated", param.Key)
+			}
+			if len(param.Values) == 0 {
+				return fmt.Errorf("param (%s): values are empty", param.Key)
+			}
+			keys[param.Key] = struct{}{}
+		}
+		r.params = params
+
+		return nil
+	}
+}
+
+// WithSample is the Runner constructor option that limits the grid with {n} randomly sampled points.
+func WithSample(n uint) Option {
+	return func(r *Runner) error {
+		r.sample = n
+
+		return nil
+	}
+}
+
+// WithSeeds is the Runner constructor option that sets the number of runs per point.
+func WithSeeds(seedsPerPoint, parallel uint) Option {
+	return func(r *Runner) error {
+		if seedsPerPoint == 0 {
+			return fmt.Errorf("seeds: must be GT 0")
+		}
+		if parallel == 0 {
+			return fmt.Errorf("parallel: must be GT 0")
+		}
+		r.seedsPerPoint, r.parallel = seedsPerPoint, parallel
+
+		return nil
+	}
+}
+
+// WithBaseSeed is the Runner constructor option that sets the first run seed (run N of every point uses baseSeed + N).
+// Points share seeds, so their results are comparable.
+func WithBaseSeed(seed int64) Option {
+	return func(r *Runner) error {
+		r.baseSeed = seed
+
+		return nil
+	}
+}
+
+// New creates a new Runner instance.
+func New(opts ...Option) (*Runner, error) {
+	r := Runner{
+		cfg:           config.DefaultSimConfig(),
+		aliensCount:   25,
+		seedsPerPoint: 1,
+		parallel:      1,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&r); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(r.cityMap) == 0 {
+		return nil, fmt.Errorf("city map is not defined (empty)")
+	}
+	if len(r.params) == 0 {
+		return nil, fmt.Errorf("params are not defined (empty)")
+	}
+
+	return &r, nil
+}
+
+// Params returns swept params.
+func (r *Runner) Params() []Param {
+	return r.params
+}
+
+// Points returns the parameters space points to run (sampled if requested).
+func (r *Runner) Points() []Point {
+	points := Grid(r.params)
+	if r.sample > 0 {
+		points = Sample(rand.New(rand.NewSource(r.baseSeed)), points, int(r.sample)) //nolint:gosec
+	}
+
+	return points
+}
+
+// Run executes all runs calling {onResult} for every finished one (calls are serialized).
+// Points with an invalid config are skipped.
+// Canceled {ctx} interrupts ongoing runs and skips pending ones (interrupted runs are not reported).
+func (r *Runner) Run(ctx context.Context, onResult func(result Result) error) error {
+	// Simulation logs are too noisy for hundreds of runs
+	ctx, logger := logging.GetCtxLogger(ctx)
+	logger = logger.With().Str(logging.ServiceKey, serviceName).Logger()
+	simCtx := logging.SetCtxLogger(ctx, logger.Level(zerolog.WarnLevel))
+
+	// Points build
+	var points []*pointJob
+	for idx, point := range r.Points() {
+		job, err := r.buildPointJob(idx, point)
+		if err != nil {
+			logger.Warn().Err(err).Msgf("Point %d skipped", idx)
+			continue
+		}
+		points = append(points, job)
+	}
+
+	// Workers
+	jobsCh := make(chan runJob)
+	resultsMtx := sync.Mutex{}
+	var runErr error
+	wg := sync.WaitGroup{}
+	for i := uint(0); i < r.parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobsCh {
+				simResult, err := batch.RunSim(simCtx, job.point.cfg, r.cityMap, job.point.aliensCount, job.seed)
+
+				resultsMtx.Lock()
+				switch {
+				case runErr != nil:
+				case err != nil:
+					runErr = fmt.Errorf("point (%d) run (seed %d): %w", job.point.idx, job.seed, err)
+				case simResult != nil:
+					runErr = onResult(Result{
+						PointIdx:    job.point.idx,
+						Point:       job.point.point,
+						Seed:        job.seed,
+						AliensCount: job.point.aliensCount,
+						SimResult:   *simResult,
+					})
+				}
+				resultsMtx.Unlock()
+			}
+		}()
+	}
+
+	// Jobs
+	hasFailed := func() bool {
+		resultsMtx.Lock()
+		defer resultsMtx.Unlock()
+
+		return runErr != nil
+	}
+
+	func() {
+		for _, point := range points {
+			for seedIdx := uint(0); seedIdx < r.seedsPerPoint; seedIdx++ {
+				if ctx.Err() != nil || hasFailed() {
+					return
+				}
+				jobsCh <- runJob{point: point, seed: r.baseSeed + int64(seedIdx)}
+			}
+		}
+	}()
+	close(jobsCh)
+	wg.Wait()
+
+	return runErr
+}
+
+// buildPointJob builds a point config overriding the base one.
+func (r *Runner) buildPointJob(idx int, point Point) (*pointJob, error) {
+	job := pointJob{
+		idx:         idx,
+		point:       point,
+		aliensCount: r.aliensCount,
+	}
+
+	overrides := make(map[string]string, len(point))
+	for paramIdx, param := range r.params {
+		value := point[paramIdx]
+		if param.Key != ParamAliens {
+			overrides[param.Key] = value
+			continue
+		}
+
+		aliensCount, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || aliensCount == 0 {
+			return nil, fmt.Errorf("%s param (%s): positive integer expected", ParamAliens, value)
+		}
+		job.aliensCount = uint(aliensCount)
+	}
+
+	cfg, err := r.cfg.Override(overrides)
+	if err != nil {
+		return nil, fmt.Errorf("building config: %w", err)
+	}
+	job.cfg = cfg
+
+	return &job, nil
+}